@@ -4,7 +4,16 @@ package config
 type P2PConfig struct {
 	Node                NodeConfig
 	KadDhtPeerDiscovery KadDhtPeerDiscoveryConfig
+	MDNSDiscovery       MDNSDiscoveryConfig
 	Sharding            ShardingConfig
+	PeerScoring         PeerScoringConfig
+	LargePayloads       LargePayloadsConfig
+	InboundRateLimiter  InboundRateLimiterConfig
+	Relay               RelayConfig
+	Peerstore           PeerstoreConfig
+	ConnectionGater     ConnectionGaterConfig
+	PeerDenial          PeerDenialConfig
+	ResourceLimits      ResourceLimitsConfig
 }
 
 // NodeConfig will hold basic p2p settings
@@ -14,6 +23,73 @@ type NodeConfig struct {
 	ThresholdMinConnectedPeers      uint32
 	MinNumPeersToWaitForOnBootstrap uint32
 	Transports                      TransportConfig
+	AutoNAT                         AutoNATConfig
+	AnnouncedAddresses              AnnouncedAddressesConfig
+}
+
+// AutoNATConfig will hold the AutoNAT settings. The AutoNAT client that detects the node's reachability is always
+// started, the service can be enabled on the publicly reachable nodes so the other peers can detect their own
+// reachability
+type AutoNATConfig struct {
+	EnableService bool
+}
+
+// AnnouncedAddressesConfig will hold the settings for the addresses advertised to the other peers
+// If Addresses is set, it replaces the listen addresses. The %d markup, if present in an address, is replaced with
+// the port used by the node. The filters are applied on the final list of addresses
+type AnnouncedAddressesConfig struct {
+	Addresses            []string
+	DenyPrivateAddresses bool
+	DenyCIDRs            []string
+}
+
+// ConnectionGaterConfig will hold the IP rules checked by the connection gater before dialing a peer address and
+// before accepting or securing a connection. An address in one of the DenyCIDRs is refused, unless it is also in one of
+// the AllowCIDRs, so the allowed ranges act as exceptions to the denied ones. The peers denied by the
+// PeerDenialEvaluator are refused regardless of their addresses
+type ConnectionGaterConfig struct {
+	AllowCIDRs []string
+	DenyCIDRs  []string
+}
+
+// PeerDenialConfig will hold the IP and subnet aware peer denial settings. The subnets are the /24 IPv4 and the /64
+// IPv6 networks. The connection caps are enforced on each new connection, 0 disabling a cap, the preferred peers not
+// being counted. The other values are used by the evaluator created with denialEvaluator.NewSubnetDenialEvaluator
+type PeerDenialConfig struct {
+	MaxConnectionsPerIP     uint32
+	MaxConnectionsPerSubnet uint32
+	// SubnetDenialThreshold is the number of denied IPs in a subnet that denies the whole subnet. 0 disables the
+	// automatic subnet denial
+	SubnetDenialThreshold uint32
+	// MaxTrackedPeers is the maximum number of peer IDs whose last IP is remembered by the evaluator
+	MaxTrackedPeers uint32
+}
+
+// ResourceLimitsConfig will hold the limits applied through the libp2p resource manager. Each zero value keeps the
+// libp2p default, scaled with the available memory and file descriptors
+type ResourceLimitsConfig struct {
+	System    ScopeLimitsConfig
+	Transient ScopeLimitsConfig
+	// Peer is applied to each peer
+	Peer ScopeLimitsConfig
+	// DirectSend, PubSub, Kad and RequestResponse are applied to the direct send, the pubsub, the kad DHT and the
+	// request-response protocols. Only the streams and the memory limits apply to a protocol
+	DirectSend      ScopeLimitsConfig
+	PubSub          ScopeLimitsConfig
+	Kad             ScopeLimitsConfig
+	RequestResponse ScopeLimitsConfig
+}
+
+// ScopeLimitsConfig will hold the limits of a resource manager scope, 0 keeping the libp2p default
+type ScopeLimitsConfig struct {
+	Streams         uint32
+	StreamsInbound  uint32
+	StreamsOutbound uint32
+	Conns           uint32
+	ConnsInbound    uint32
+	ConnsOutbound   uint32
+	FD              uint32
+	MemoryInMB      uint32
 }
 
 // TransportConfig specify the supported protocols by the node
@@ -39,6 +115,28 @@ type KadDhtPeerDiscoveryConfig struct {
 	InitialPeerList                  []string
 	BucketSize                       uint32
 	RoutingTableRefreshIntervalInSec uint32
+	// SeedersMaxBackoffInSec caps the exponential backoff applied to the seeders failing to connect. 0 uses the
+	// default of 5 minutes
+	SeedersMaxBackoffInSec uint32
+	Rendezvous             RendezvousConfig
+}
+
+// RendezvousConfig will hold the shard-aware rendezvous discovery settings. When enabled, the node advertises itself
+// in the DHT under the <NamespacePrefix>/shard/<shard ID>/<validator|observer> and <NamespacePrefix>/<validator|observer>
+// namespaces and queries them to fill the sharder's categories that are under quota. It requires the optimized kad-dht
+// discovery and one of the ListsSharder, DiversityListsSharder or LatencyListsSharder
+type RendezvousConfig struct {
+	Enabled          bool
+	NamespacePrefix  string
+	MaxPeersPerQuery uint32
+}
+
+// MDNSDiscoveryConfig will hold the mDNS local network discovery settings. It can not be enabled together with the
+// kad-dht discovery and it is intended for the local networks (devnets, CI setups) as the peers are found only if they
+// are on the same LAN
+type MDNSDiscoveryConfig struct {
+	Enabled     bool
+	ServiceName string
 }
 
 // ShardingConfig will hold the network sharding config settings
@@ -51,6 +149,35 @@ type ShardingConfig struct {
 	MaxSeeders              uint32
 	Type                    string
 	AdditionalConnections   AdditionalConnectionsConfig
+	NetworkDiversity        NetworkDiversityConfig
+	Latency                 LatencyConfig
+}
+
+// NetworkDiversityConfig will hold the settings of the DiversityListsSharder which, within each peers category, prefers
+// keeping peers from distinct IP prefixes and autonomous systems. The caps are applied within each category, 0
+// disabling a cap
+type NetworkDiversityConfig struct {
+	// IPv4PrefixLength and IPv6PrefixLength are the lengths of the prefixes the peers are grouped by. 0 uses the
+	// default /24 and /48 prefixes
+	IPv4PrefixLength  uint32
+	IPv6PrefixLength  uint32
+	MaxPeersPerPrefix uint32
+	MaxPeersPerASN    uint32
+	// ASNPrefixFilePath is the optional path of a local file mapping the IP prefixes to their autonomous systems. Each
+	// line holds a CIDR followed by the AS number, like `192.0.2.0/24 64496`. The empty lines and the lines starting
+	// with # are ignored
+	ASNPrefixFilePath string
+}
+
+// LatencyConfig will hold the settings of the LatencyListsSharder which, within each peers category, prefers keeping
+// the peers with the lowest latency, as measured by the libp2p ping protocol
+type LatencyConfig struct {
+	// PingIntervalInSec is the interval between the pings sent to each connected peer in order to refresh its latency.
+	// It should be at least 10 seconds, the maximum duration of a ping
+	PingIntervalInSec uint32
+	// RandomPeersFraction is the fraction, between 0 and 1, of each category's quota kept for randomly picked peers
+	// so the connections do not all end up in the same region
+	RandomPeersFraction float64
 }
 
 // AdditionalConnectionsConfig will hold the additional connections that will be open when certain conditions are met
@@ -58,3 +185,115 @@ type ShardingConfig struct {
 type AdditionalConnectionsConfig struct {
 	MaxFullHistoryObservers uint32
 }
+
+// PeerScoringConfig will hold the gossipsub peer scoring settings
+// The thresholds should be negative and ordered as GraylistThreshold <= PublishThreshold <= GossipThreshold <= 0
+type PeerScoringConfig struct {
+	Enabled                     bool
+	DecayIntervalInSec          uint32
+	DecayToZero                 float64
+	RetainScoreInSec            uint32
+	TopicScoreCap               float64
+	IPColocationFactorWeight    float64
+	IPColocationFactorThreshold int
+	BehaviourPenaltyWeight      float64
+	BehaviourPenaltyThreshold   float64
+	BehaviourPenaltyDecay       float64
+	GossipThreshold             float64
+	PublishThreshold            float64
+	GraylistThreshold           float64
+	AcceptPXThreshold           float64
+	OpportunisticGraftThreshold float64
+	InspectIntervalInSec        uint32
+	Topics                      []TopicScoringConfig
+}
+
+// TopicScoringConfig will hold the gossipsub peer scoring settings for a single topic
+type TopicScoringConfig struct {
+	Name                           string
+	TopicWeight                    float64
+	TimeInMeshWeight               float64
+	TimeInMeshQuantumInMs          uint32
+	TimeInMeshCap                  float64
+	FirstMessageDeliveriesWeight   float64
+	FirstMessageDeliveriesDecay    float64
+	FirstMessageDeliveriesCap      float64
+	InvalidMessageDeliveriesWeight float64
+	InvalidMessageDeliveriesDecay  float64
+}
+
+// LargePayloadsConfig will hold the settings used when sending direct messages that exceed the maximum message size
+type LargePayloadsConfig struct {
+	Enabled               bool
+	MaxPayloadSizeInBytes uint64
+	MaxPendingSizeInBytes uint64
+	// MaxPendingSizePerPeerInBytes caps the pending transfers' size of a single peer. 0 means MaxPayloadSizeInBytes
+	MaxPendingSizePerPeerInBytes uint64
+	// MaxPendingTransfersPerPeer caps the number of pending transfers of a single peer. 0 means 8 transfers
+	MaxPendingTransfersPerPeer uint32
+	TransferTimeoutInSec       uint32
+}
+
+// InboundRateLimiterConfig will hold the settings of the per peer, per topic inbound messages rate limiter
+type InboundRateLimiterConfig struct {
+	Enabled                  bool
+	DefaultMessagesPerSecond uint32
+	DefaultBurst             uint32
+	Topics                   []TopicRateLimitConfig
+	MaxViolations            uint32
+	ViolationsWindowInSec    uint32
+	BanDurationInSec         uint32
+}
+
+// TopicRateLimitConfig will hold the inbound rate limits of a topic
+type TopicRateLimitConfig struct {
+	Name              string
+	MessagesPerSecond uint32
+	Burst             uint32
+}
+
+// RelayConfig will hold the circuit relay v2 and hole punching settings
+type RelayConfig struct {
+	// Enabled activates the relay transport, required to dial and accept relayed connections
+	Enabled            bool
+	EnableHolePunching bool
+	Service            RelayServiceConfig
+	Client             RelayClientConfig
+}
+
+// RelayServiceConfig will hold the settings of the relay service that relays connections for other nodes.
+// A node running the relay service is considered publicly reachable. 0 values mean the libp2p defaults
+type RelayServiceConfig struct {
+	Enabled                 bool
+	MaxReservations         uint32
+	MaxReservationsPerPeer  uint32
+	MaxReservationsPerIP    uint32
+	MaxCircuits             uint32
+	BufferSizeInBytes       uint32
+	ReservationTTLInSec     uint32
+	MaxCircuitDurationInSec uint32
+	MaxCircuitDataInBytes   uint64
+}
+
+// RelayClientConfig will hold the settings used by a node behind NAT to reserve slots on relays
+type RelayClientConfig struct {
+	Enabled      bool
+	StaticRelays []string
+	// NumRelays is the number of relays the node keeps reservations with. 0 means all the static relays
+	NumRelays uint32
+}
+
+// PeerstoreConfig will hold the persistent peerstore settings. When enabled, the addresses, the protocols and the last
+// time the identified peers were seen are saved in a LevelDB database and, on Bootstrap, the most recently seen peers
+// are redialed before the peers discovery falls back to the seeders
+type PeerstoreConfig struct {
+	Enabled        bool
+	DatabasePath   string
+	RecordTTLInSec uint32
+	MaxPeers       uint32
+	// GCIntervalInSec is the interval between two consecutive removals of the expired records
+	GCIntervalInSec uint32
+	// WarmStartMaxPeers is the maximum number of peers redialed on Bootstrap. 0 disables the warm start
+	WarmStartMaxPeers     uint32
+	WarmStartTimeoutInSec uint32
+}
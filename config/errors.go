@@ -0,0 +1,42 @@
+package config
+
+import "errors"
+
+// ErrInvalidValue signals that an invalid value has been provided
+var ErrInvalidValue = errors.New("invalid value")
+
+// ErrInvalidPortValue signals that an invalid port value has been provided
+var ErrInvalidPortValue = errors.New("invalid port value")
+
+// ErrInvalidPortsRangeString signals that an invalid ports range string has been provided
+var ErrInvalidPortsRangeString = errors.New("invalid ports range string")
+
+// ErrInvalidStartingPortValue signals that an invalid starting port value has been provided
+var ErrInvalidStartingPortValue = errors.New("invalid starting port value")
+
+// ErrInvalidEndingPortValue signals that an invalid ending port value has been provided
+var ErrInvalidEndingPortValue = errors.New("invalid ending port value")
+
+// ErrEndPortIsSmallerThanStartPort signals that the ending port value is smaller than the starting port value
+var ErrEndPortIsSmallerThanStartPort = errors.New("ending port value is smaller than the starting port value")
+
+// ErrInvalidTCPAddress signals that an invalid TCP address was used
+var ErrInvalidTCPAddress = errors.New("invalid TCP address")
+
+// ErrInvalidQUICAddress signals that an invalid QUIC address was used
+var ErrInvalidQUICAddress = errors.New("invalid QUIC address")
+
+// ErrInvalidWSAddress signals that an invalid WebSocket address was used
+var ErrInvalidWSAddress = errors.New("invalid WebSocket address")
+
+// ErrInvalidWebTransportAddress signals that an invalid WebTransport address was used
+var ErrInvalidWebTransportAddress = errors.New("invalid WebTransport address")
+
+// ErrNoTransportsDefined signals that no transports were defined
+var ErrNoTransportsDefined = errors.New("no transports defined")
+
+// ErrInvalidProtocolID signals that an invalid protocol ID has been provided
+var ErrInvalidProtocolID = errors.New("invalid protocol ID")
+
+// ErrInvalidConfig signals that the configuration is not valid
+var ErrInvalidConfig = errors.New("invalid p2p config")
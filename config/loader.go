@@ -0,0 +1,23 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+)
+
+// LoadP2PConfig loads the P2P configuration from the provided TOML file and validates it
+func LoadP2PConfig(path string) (*P2PConfig, error) {
+	p2pConfig := &P2PConfig{}
+	err := core.LoadTomlFile(p2pConfig, path)
+	if err != nil {
+		return nil, fmt.Errorf("%w while loading the p2p config from %s", err, path)
+	}
+
+	err = p2pConfig.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return p2pConfig, nil
+}
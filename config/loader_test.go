@@ -0,0 +1,99 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const p2pConfigToml = `
+[Node]
+    Port = "37373-38383"
+    ThresholdMinConnectedPeers = 3
+    [Node.Transports]
+        QUICAddress = ""
+        WebSocketAddress = ""
+        WebTransportAddress = ""
+        [Node.Transports.TCP]
+            ListenAddress = "/ip4/0.0.0.0/tcp/%d"
+            PreventPortReuse = false
+
+[KadDhtPeerDiscovery]
+    Enabled = true
+    Type = "optimized"
+    RefreshIntervalInSec = 10
+    ProtocolID = "/drt/kad/1.0.0"
+    InitialPeerList = ["/ip4/127.0.0.1/tcp/10000/p2p/16Uiu2HAm6yvbp1oZ6zjnWsn9FdRqBSaQkbhELyaThuq48ybdojvJ"]
+    BucketSize = 100
+    RoutingTableRefreshIntervalInSec = 300
+
+[Sharding]
+    TargetPeerCount = 36
+    MaxIntraShardValidators = 7
+    MaxCrossShardValidators = 15
+    MaxIntraShardObservers = 2
+    MaxCrossShardObservers = 3
+    MaxSeeders = 2
+    Type = "ListsSharder"
+    [Sharding.AdditionalConnections]
+        MaxFullHistoryObservers = 10
+`
+
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "p2p.toml")
+	err := os.WriteFile(path, []byte(content), 0644)
+	require.Nil(t, err)
+
+	return path
+}
+
+func TestLoadP2PConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file should error", func(t *testing.T) {
+		t.Parallel()
+
+		p2pConfig, err := config.LoadP2PConfig(filepath.Join(t.TempDir(), "missing.toml"))
+		assert.NotNil(t, err)
+		assert.Nil(t, p2pConfig)
+	})
+	t.Run("malformed file should error", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeConfigFile(t, "[Node\nPort = ")
+		p2pConfig, err := config.LoadP2PConfig(path)
+		assert.NotNil(t, err)
+		assert.Nil(t, p2pConfig)
+	})
+	t.Run("invalid config should error", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeConfigFile(t, "[Node]\nPort = \"0\"\n")
+		p2pConfig, err := config.LoadP2PConfig(path)
+		assert.True(t, errors.Is(err, p2p.ErrNoTransportsDefined))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Nil(t, p2pConfig)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeConfigFile(t, p2pConfigToml)
+		p2pConfig, err := config.LoadP2PConfig(path)
+		require.Nil(t, err)
+
+		assert.Equal(t, "37373-38383", p2pConfig.Node.Port)
+		assert.Equal(t, uint32(3), p2pConfig.Node.ThresholdMinConnectedPeers)
+		assert.Equal(t, "/ip4/0.0.0.0/tcp/%d", p2pConfig.Node.Transports.TCP.ListenAddress)
+		assert.Equal(t, "/drt/kad/1.0.0", p2pConfig.KadDhtPeerDiscovery.ProtocolID)
+		assert.Equal(t, 1, len(p2pConfig.KadDhtPeerDiscovery.InitialPeerList))
+		assert.Equal(t, uint32(36), p2pConfig.Sharding.TargetPeerCount)
+		assert.Equal(t, p2p.ListsSharder, p2pConfig.Sharding.Type)
+		assert.Equal(t, uint32(10), p2pConfig.Sharding.AdditionalConnections.MaxFullHistoryObservers)
+	})
+}
@@ -0,0 +1,588 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// the values should be kept in sync with the sharder types defined in the p2p package
+const (
+	listsSharderType          = "ListsSharder"
+	oneListSharderType        = "OneListSharder"
+	nilListSharderType        = "NilListSharder"
+	diversityListsSharderType = "DiversityListsSharder"
+	latencyListsSharderType   = "LatencyListsSharder"
+)
+
+const (
+	legacyDiscoveryType    = "legacy"
+	optimizedDiscoveryType = "optimized"
+)
+
+const (
+	intMarkup                           = "%d"
+	minRangePortValue                   = 1025
+	maxPortValue                        = 65535
+	minAllowedConnectedPeersListSharder = 5
+	minAllowedConnectedPeersOneSharder  = 3
+	minAllowedValidators                = 1
+	minAllowedObservers                 = 1
+	minUnknownPeers                     = 1
+)
+
+// minLatencyPingIntervalInSec matches the maximum duration of a ping so a peer is not pinged again before its previous
+// ping times out
+const minLatencyPingIntervalInSec = 10
+
+// FieldError holds the problem found in one of the configuration fields
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+// Error returns the field path followed by the problem description
+func (fe *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", fe.Field, fe.Err.Error())
+}
+
+// Unwrap returns the problem found in the field
+func (fe *FieldError) Unwrap() error {
+	return fe.Err
+}
+
+// ValidationError holds all the problems found while validating a P2PConfig
+type ValidationError struct {
+	FieldErrors []*FieldError
+}
+
+// Error returns all the problems found in the configuration
+func (ve *ValidationError) Error() string {
+	messages := make([]string, 0, len(ve.FieldErrors))
+	for _, fieldErr := range ve.FieldErrors {
+		messages = append(messages, fieldErr.Error())
+	}
+
+	return fmt.Sprintf("%s: %s", ErrInvalidConfig.Error(), strings.Join(messages, "; "))
+}
+
+// Unwrap returns ErrInvalidConfig followed by the problems found in each field
+func (ve *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(ve.FieldErrors)+1)
+	errs = append(errs, ErrInvalidConfig)
+	for _, fieldErr := range ve.FieldErrors {
+		errs = append(errs, fieldErr)
+	}
+
+	return errs
+}
+
+type configValidator struct {
+	fieldErrors []*FieldError
+}
+
+func (cv *configValidator) addError(field string, err error) {
+	if err == nil {
+		return
+	}
+
+	cv.fieldErrors = append(cv.fieldErrors, &FieldError{
+		Field: field,
+		Err:   err,
+	})
+}
+
+// Validate checks the configuration and returns a *ValidationError holding all the problems found, or nil if
+// the configuration is valid
+func (p2pConfig P2PConfig) Validate() error {
+	cv := &configValidator{}
+
+	cv.validateNode(p2pConfig.Node)
+	cv.validateKadDhtPeerDiscovery(p2pConfig.KadDhtPeerDiscovery)
+	cv.validateMDNSDiscovery(p2pConfig.MDNSDiscovery, p2pConfig.KadDhtPeerDiscovery.Enabled)
+	cv.validateRendezvous(p2pConfig.KadDhtPeerDiscovery, p2pConfig.Sharding.Type)
+	cv.validateSharding(p2pConfig.Sharding)
+	cv.validateRelay(p2pConfig.Relay)
+	cv.validatePeerstore(p2pConfig.Peerstore)
+	cv.validateConnectionGater(p2pConfig.ConnectionGater)
+	cv.validateResourceLimits(p2pConfig.ResourceLimits)
+	if p2pConfig.PeerScoring.Enabled {
+		cv.validatePeerScoring(p2pConfig.PeerScoring)
+	}
+	if p2pConfig.LargePayloads.Enabled {
+		cv.validateLargePayloads(p2pConfig.LargePayloads)
+	}
+	if p2pConfig.InboundRateLimiter.Enabled {
+		cv.validateInboundRateLimiter(p2pConfig.InboundRateLimiter)
+	}
+	cv.validatePeerDenial(p2pConfig.PeerDenial)
+
+	return cv.validationError()
+}
+
+// Validate checks the peer scoring configuration, regardless of the Enabled flag
+func (cfg PeerScoringConfig) Validate() error {
+	cv := &configValidator{}
+	cv.validatePeerScoring(cfg)
+
+	return cv.validationError()
+}
+
+// Validate checks the large payloads configuration, regardless of the Enabled flag
+func (cfg LargePayloadsConfig) Validate() error {
+	cv := &configValidator{}
+	cv.validateLargePayloads(cfg)
+
+	return cv.validationError()
+}
+
+// Validate checks the inbound rate limiter configuration, regardless of the Enabled flag
+func (cfg InboundRateLimiterConfig) Validate() error {
+	cv := &configValidator{}
+	cv.validateInboundRateLimiter(cfg)
+
+	return cv.validationError()
+}
+
+// Validate checks the peer denial configuration
+func (cfg PeerDenialConfig) Validate() error {
+	cv := &configValidator{}
+	cv.validatePeerDenial(cfg)
+
+	return cv.validationError()
+}
+
+func (cv *configValidator) validationError() error {
+	if len(cv.fieldErrors) == 0 {
+		return nil
+	}
+
+	return &ValidationError{
+		FieldErrors: cv.fieldErrors,
+	}
+}
+
+func (cv *configValidator) validateNode(nodeConfig NodeConfig) {
+	cv.addError("Node.Port", checkPort(nodeConfig.Port))
+
+	transports := []struct {
+		field   string
+		address string
+		err     error
+	}{
+		{"Node.Transports.TCP.ListenAddress", nodeConfig.Transports.TCP.ListenAddress, ErrInvalidTCPAddress},
+		{"Node.Transports.QUICAddress", nodeConfig.Transports.QUICAddress, ErrInvalidQUICAddress},
+		{"Node.Transports.WebSocketAddress", nodeConfig.Transports.WebSocketAddress, ErrInvalidWSAddress},
+		{"Node.Transports.WebTransportAddress", nodeConfig.Transports.WebTransportAddress, ErrInvalidWebTransportAddress},
+	}
+	numTransports := 0
+	for _, transport := range transports {
+		if len(transport.address) == 0 {
+			continue
+		}
+
+		numTransports++
+		if strings.Count(transport.address, intMarkup) != 1 {
+			cv.addError(transport.field, fmt.Errorf("%w, `%s` should contain the %s markup exactly once",
+				transport.err, transport.address, intMarkup))
+		}
+	}
+	if numTransports == 0 {
+		cv.addError("Node.Transports", ErrNoTransportsDefined)
+	}
+
+	cv.validateAnnouncedAddresses(nodeConfig.AnnouncedAddresses)
+}
+
+func (cv *configValidator) validateAnnouncedAddresses(announcedConfig AnnouncedAddressesConfig) {
+	for idx, address := range announcedConfig.Addresses {
+		field := fmt.Sprintf("Node.AnnouncedAddresses.Addresses[%d]", idx)
+		if strings.Count(address, intMarkup) > 1 {
+			cv.addError(field, fmt.Errorf("%w, `%s` should contain the %s markup at most once",
+				ErrInvalidValue, address, intMarkup))
+			continue
+		}
+
+		_, err := multiaddr.NewMultiaddr(strings.Replace(address, intMarkup, "0", 1))
+		if err != nil {
+			cv.addError(field, fmt.Errorf("%w, `%s` is not a valid address: %s", ErrInvalidValue, address, err.Error()))
+		}
+	}
+
+	cv.validateCIDRs("Node.AnnouncedAddresses.DenyCIDRs", announcedConfig.DenyCIDRs)
+}
+
+// checkPort accepts either a single port value or a `start-end` ports range
+func checkPort(port string) error {
+	val, err := strconv.Atoi(port)
+	if err == nil {
+		if val < 0 || val > maxPortValue {
+			return fmt.Errorf("%w, %d should be between 0 and %d", ErrInvalidPortValue, val, maxPortValue)
+		}
+
+		return nil
+	}
+
+	ports := strings.Split(port, "-")
+	if len(ports) != 2 {
+		return fmt.Errorf("%w, provided port string `%s` is not in the correct format, expected `start-end`", ErrInvalidPortsRangeString, port)
+	}
+
+	startPort, err := strconv.Atoi(ports[0])
+	if err != nil {
+		return fmt.Errorf("%w, `%s` is not a number", ErrInvalidStartingPortValue, ports[0])
+	}
+	endPort, err := strconv.Atoi(ports[1])
+	if err != nil {
+		return fmt.Errorf("%w, `%s` is not a number", ErrInvalidEndingPortValue, ports[1])
+	}
+	if startPort < minRangePortValue {
+		return fmt.Errorf("%w, provided starting port should be >= %d", ErrInvalidValue, minRangePortValue)
+	}
+	if endPort > maxPortValue {
+		return fmt.Errorf("%w, provided ending port should be <= %d", ErrInvalidEndingPortValue, maxPortValue)
+	}
+	if endPort < startPort {
+		return ErrEndPortIsSmallerThanStartPort
+	}
+
+	return nil
+}
+
+func (cv *configValidator) validateKadDhtPeerDiscovery(kadDhtConfig KadDhtPeerDiscoveryConfig) {
+	if !kadDhtConfig.Enabled {
+		return
+	}
+
+	switch kadDhtConfig.Type {
+	case legacyDiscoveryType, optimizedDiscoveryType:
+	default:
+		cv.addError("KadDhtPeerDiscovery.Type", fmt.Errorf("%w, unknown discovery type `%s`, expected %s or %s",
+			ErrInvalidValue, kadDhtConfig.Type, legacyDiscoveryType, optimizedDiscoveryType))
+	}
+
+	cv.addError("KadDhtPeerDiscovery.ProtocolID", checkProtocolID(kadDhtConfig.ProtocolID))
+	if kadDhtConfig.RefreshIntervalInSec == 0 {
+		cv.addError("KadDhtPeerDiscovery.RefreshIntervalInSec", fmt.Errorf("%w, should be at least 1", ErrInvalidValue))
+	}
+	if kadDhtConfig.RoutingTableRefreshIntervalInSec == 0 {
+		cv.addError("KadDhtPeerDiscovery.RoutingTableRefreshIntervalInSec", fmt.Errorf("%w, should be at least 1", ErrInvalidValue))
+	}
+}
+
+func (cv *configValidator) validateMDNSDiscovery(mdnsConfig MDNSDiscoveryConfig, isKadDhtEnabled bool) {
+	if !mdnsConfig.Enabled {
+		return
+	}
+
+	if isKadDhtEnabled {
+		cv.addError("MDNSDiscovery.Enabled", fmt.Errorf("%w, can not be enabled together with the kad dht discovery", ErrInvalidValue))
+	}
+	if len(mdnsConfig.ServiceName) == 0 || strings.ContainsAny(mdnsConfig.ServiceName, " \t\r\n.") {
+		cv.addError("MDNSDiscovery.ServiceName", fmt.Errorf("%w, `%s` should be a non-empty name without whitespaces and dots",
+			ErrInvalidValue, mdnsConfig.ServiceName))
+	}
+}
+
+func (cv *configValidator) validateRendezvous(kadDhtConfig KadDhtPeerDiscoveryConfig, sharderType string) {
+	rendezvousConfig := kadDhtConfig.Rendezvous
+	if !rendezvousConfig.Enabled {
+		return
+	}
+
+	if !kadDhtConfig.Enabled || kadDhtConfig.Type != optimizedDiscoveryType {
+		cv.addError("KadDhtPeerDiscovery.Rendezvous.Enabled", fmt.Errorf("%w, requires the %s kad dht discovery",
+			ErrInvalidValue, optimizedDiscoveryType))
+	}
+	switch sharderType {
+	case listsSharderType, diversityListsSharderType, latencyListsSharderType:
+	default:
+		cv.addError("KadDhtPeerDiscovery.Rendezvous.Enabled", fmt.Errorf("%w, requires the %s, the %s or the %s",
+			ErrInvalidValue, listsSharderType, diversityListsSharderType, latencyListsSharderType))
+	}
+	prefix := rendezvousConfig.NamespacePrefix
+	if len(prefix) == 0 || strings.ContainsAny(prefix, " \t\r\n") || strings.HasSuffix(prefix, "/") {
+		cv.addError("KadDhtPeerDiscovery.Rendezvous.NamespacePrefix", fmt.Errorf("%w, `%s` should be non-empty, "+
+			"without whitespaces and should not end with /", ErrInvalidValue, prefix))
+	}
+	if rendezvousConfig.MaxPeersPerQuery == 0 {
+		cv.addError("KadDhtPeerDiscovery.Rendezvous.MaxPeersPerQuery", fmt.Errorf("%w, should be at least 1", ErrInvalidValue))
+	}
+}
+
+func checkProtocolID(protocolID string) error {
+	if len(protocolID) == 0 {
+		return fmt.Errorf("%w, empty protocol ID", ErrInvalidProtocolID)
+	}
+	if !strings.HasPrefix(protocolID, "/") {
+		return fmt.Errorf("%w, `%s` should start with /", ErrInvalidProtocolID, protocolID)
+	}
+	if strings.ContainsAny(protocolID, " \t\r\n") {
+		return fmt.Errorf("%w, `%s` should not contain whitespaces", ErrInvalidProtocolID, protocolID)
+	}
+
+	return nil
+}
+
+func (cv *configValidator) validateSharding(shardingConfig ShardingConfig) {
+	switch shardingConfig.Type {
+	case listsSharderType:
+		cv.validateListsSharder(shardingConfig)
+	case oneListSharderType:
+		if shardingConfig.TargetPeerCount < minAllowedConnectedPeersOneSharder {
+			cv.addError("Sharding.TargetPeerCount", fmt.Errorf("%w, should be at least %d for %s",
+				ErrInvalidValue, minAllowedConnectedPeersOneSharder, oneListSharderType))
+		}
+	case nilListSharderType:
+	case diversityListsSharderType:
+		cv.validateListsSharder(shardingConfig)
+		cv.validateNetworkDiversity(shardingConfig.NetworkDiversity)
+	case latencyListsSharderType:
+		cv.validateListsSharder(shardingConfig)
+		cv.validateLatency(shardingConfig.Latency)
+	default:
+		cv.addError("Sharding.Type", fmt.Errorf("%w, unknown sharder type `%s`, expected %s, %s, %s, %s or %s",
+			ErrInvalidValue, shardingConfig.Type, listsSharderType, oneListSharderType, nilListSharderType,
+			diversityListsSharderType, latencyListsSharderType))
+	}
+}
+
+func (cv *configValidator) validateLatency(latencyConfig LatencyConfig) {
+	if latencyConfig.PingIntervalInSec < minLatencyPingIntervalInSec {
+		cv.addError("Sharding.Latency.PingIntervalInSec", fmt.Errorf("%w, should be at least %d",
+			ErrInvalidValue, minLatencyPingIntervalInSec))
+	}
+	fraction := latencyConfig.RandomPeersFraction
+	if math.IsNaN(fraction) || fraction < 0 || fraction > 1 {
+		cv.addError("Sharding.Latency.RandomPeersFraction", fmt.Errorf("%w, should be between 0 and 1", ErrInvalidValue))
+	}
+}
+
+func (cv *configValidator) validateNetworkDiversity(diversityConfig NetworkDiversityConfig) {
+	if diversityConfig.IPv4PrefixLength > 8*net.IPv4len {
+		cv.addError("Sharding.NetworkDiversity.IPv4PrefixLength", fmt.Errorf("%w, should not exceed %d",
+			ErrInvalidValue, 8*net.IPv4len))
+	}
+	if diversityConfig.IPv6PrefixLength > 8*net.IPv6len {
+		cv.addError("Sharding.NetworkDiversity.IPv6PrefixLength", fmt.Errorf("%w, should not exceed %d",
+			ErrInvalidValue, 8*net.IPv6len))
+	}
+	if diversityConfig.MaxPeersPerASN > 0 && len(strings.TrimSpace(diversityConfig.ASNPrefixFilePath)) == 0 {
+		cv.addError("Sharding.NetworkDiversity.ASNPrefixFilePath", fmt.Errorf("%w, empty path, required by MaxPeersPerASN",
+			ErrInvalidValue))
+	}
+}
+
+func (cv *configValidator) validateListsSharder(shardingConfig ShardingConfig) {
+	minimums := []struct {
+		field   string
+		value   uint32
+		minimum uint32
+	}{
+		{"Sharding.TargetPeerCount", shardingConfig.TargetPeerCount, minAllowedConnectedPeersListSharder},
+		{"Sharding.MaxIntraShardValidators", shardingConfig.MaxIntraShardValidators, minAllowedValidators},
+		{"Sharding.MaxCrossShardValidators", shardingConfig.MaxCrossShardValidators, minAllowedValidators},
+		{"Sharding.MaxIntraShardObservers", shardingConfig.MaxIntraShardObservers, minAllowedObservers},
+		{"Sharding.MaxCrossShardObservers", shardingConfig.MaxCrossShardObservers, minAllowedObservers},
+	}
+	for _, m := range minimums {
+		if m.value < m.minimum {
+			cv.addError(m.field, fmt.Errorf("%w, should be at least %d for %s", ErrInvalidValue, m.minimum, listsSharderType))
+		}
+	}
+
+	// the full history observers are added to both the provided peers and the target peer count, so they are not
+	// part of the sum
+	providedPeers := uint64(shardingConfig.MaxIntraShardValidators) + uint64(shardingConfig.MaxCrossShardValidators) +
+		uint64(shardingConfig.MaxIntraShardObservers) + uint64(shardingConfig.MaxCrossShardObservers) +
+		uint64(shardingConfig.MaxSeeders)
+	if providedPeers+minUnknownPeers > uint64(shardingConfig.TargetPeerCount) {
+		cv.addError("Sharding.TargetPeerCount", fmt.Errorf("%w, the sum of the validators, observers and seeders "+
+			"limits (%d) should be less than %d", ErrInvalidValue, providedPeers, shardingConfig.TargetPeerCount))
+	}
+}
+
+func (cv *configValidator) validateRelay(relayConfig RelayConfig) {
+	if !relayConfig.Enabled {
+		if relayConfig.EnableHolePunching {
+			cv.addError("Relay.EnableHolePunching", fmt.Errorf("%w, hole punching requires the relay transport", ErrInvalidValue))
+		}
+		if relayConfig.Service.Enabled {
+			cv.addError("Relay.Service.Enabled", fmt.Errorf("%w, the relay service requires the relay transport", ErrInvalidValue))
+		}
+		if relayConfig.Client.Enabled {
+			cv.addError("Relay.Client.Enabled", fmt.Errorf("%w, the relay client requires the relay transport", ErrInvalidValue))
+		}
+
+		return
+	}
+	if !relayConfig.Client.Enabled {
+		return
+	}
+
+	if len(relayConfig.Client.StaticRelays) == 0 {
+		cv.addError("Relay.Client.StaticRelays", fmt.Errorf("%w, at least one relay should be provided", ErrInvalidValue))
+	}
+	for idx, address := range relayConfig.Client.StaticRelays {
+		_, err := peer.AddrInfoFromString(address)
+		if err != nil {
+			cv.addError(fmt.Sprintf("Relay.Client.StaticRelays[%d]", idx), fmt.Errorf("%w, `%s` is not a valid peer address: %s",
+				ErrInvalidValue, address, err.Error()))
+		}
+	}
+	if int(relayConfig.Client.NumRelays) > len(relayConfig.Client.StaticRelays) {
+		cv.addError("Relay.Client.NumRelays", fmt.Errorf("%w, should not exceed the number of static relays (%d)",
+			ErrInvalidValue, len(relayConfig.Client.StaticRelays)))
+	}
+}
+
+func (cv *configValidator) validatePeerstore(peerstoreConfig PeerstoreConfig) {
+	if !peerstoreConfig.Enabled {
+		return
+	}
+
+	if len(strings.TrimSpace(peerstoreConfig.DatabasePath)) == 0 {
+		cv.addError("Peerstore.DatabasePath", fmt.Errorf("%w, empty database path", ErrInvalidValue))
+	}
+	if peerstoreConfig.RecordTTLInSec == 0 {
+		cv.addError("Peerstore.RecordTTLInSec", fmt.Errorf("%w, should be at least 1", ErrInvalidValue))
+	}
+	if peerstoreConfig.MaxPeers == 0 {
+		cv.addError("Peerstore.MaxPeers", fmt.Errorf("%w, should be at least 1", ErrInvalidValue))
+	}
+	if peerstoreConfig.GCIntervalInSec == 0 {
+		cv.addError("Peerstore.GCIntervalInSec", fmt.Errorf("%w, should be at least 1", ErrInvalidValue))
+	}
+	if peerstoreConfig.WarmStartMaxPeers > 0 && peerstoreConfig.WarmStartTimeoutInSec == 0 {
+		cv.addError("Peerstore.WarmStartTimeoutInSec", fmt.Errorf("%w, should be at least 1 when WarmStartMaxPeers is set",
+			ErrInvalidValue))
+	}
+}
+
+func (cv *configValidator) validatePeerScoring(scoringConfig PeerScoringConfig) {
+	if scoringConfig.DecayIntervalInSec == 0 {
+		cv.addError("PeerScoring.DecayIntervalInSec", fmt.Errorf("%w, should be at least 1", ErrInvalidValue))
+	}
+	if scoringConfig.InspectIntervalInSec == 0 {
+		cv.addError("PeerScoring.InspectIntervalInSec", fmt.Errorf("%w, should be at least 1", ErrInvalidValue))
+	}
+
+	topics := make(map[string]struct{})
+	for idx, topicConfig := range scoringConfig.Topics {
+		field := fmt.Sprintf("PeerScoring.Topics[%d].Name", idx)
+		if len(topicConfig.Name) == 0 {
+			cv.addError(field, fmt.Errorf("%w, empty topic name", ErrInvalidValue))
+			continue
+		}
+
+		_, found := topics[topicConfig.Name]
+		if found {
+			cv.addError(field, fmt.Errorf("%w, duplicated topic %s", ErrInvalidValue, topicConfig.Name))
+		}
+		topics[topicConfig.Name] = struct{}{}
+	}
+}
+
+// validateLargePayloads checks the sizes relations only, the minimum payload size depends on the maximum message size
+// and is checked by the messenger
+func (cv *configValidator) validateLargePayloads(payloadsConfig LargePayloadsConfig) {
+	if payloadsConfig.MaxPayloadSizeInBytes == 0 {
+		cv.addError("LargePayloads.MaxPayloadSizeInBytes", fmt.Errorf("%w, should be at least 1", ErrInvalidValue))
+	}
+	if payloadsConfig.MaxPendingSizeInBytes < payloadsConfig.MaxPayloadSizeInBytes {
+		cv.addError("LargePayloads.MaxPendingSizeInBytes", fmt.Errorf("%w, should be at least MaxPayloadSizeInBytes (%d)",
+			ErrInvalidValue, payloadsConfig.MaxPayloadSizeInBytes))
+	}
+	maxPendingSizePerPeer := payloadsConfig.MaxPendingSizePerPeerInBytes
+	if maxPendingSizePerPeer > 0 &&
+		(maxPendingSizePerPeer < payloadsConfig.MaxPayloadSizeInBytes || maxPendingSizePerPeer > payloadsConfig.MaxPendingSizeInBytes) {
+		cv.addError("LargePayloads.MaxPendingSizePerPeerInBytes", fmt.Errorf("%w, should be between %d and %d",
+			ErrInvalidValue, payloadsConfig.MaxPayloadSizeInBytes, payloadsConfig.MaxPendingSizeInBytes))
+	}
+	if payloadsConfig.TransferTimeoutInSec == 0 {
+		cv.addError("LargePayloads.TransferTimeoutInSec", fmt.Errorf("%w, should be at least 1", ErrInvalidValue))
+	}
+}
+
+func (cv *configValidator) validateInboundRateLimiter(limiterConfig InboundRateLimiterConfig) {
+	if limiterConfig.DefaultMessagesPerSecond > 0 && limiterConfig.DefaultBurst == 0 {
+		cv.addError("InboundRateLimiter.DefaultBurst", fmt.Errorf("%w, should be greater than 0 when "+
+			"DefaultMessagesPerSecond is set", ErrInvalidValue))
+	}
+	for idx, topicConfig := range limiterConfig.Topics {
+		field := fmt.Sprintf("InboundRateLimiter.Topics[%d]", idx)
+		if len(topicConfig.Name) == 0 {
+			cv.addError(field+".Name", fmt.Errorf("%w, empty topic name", ErrInvalidValue))
+		}
+		if topicConfig.MessagesPerSecond == 0 {
+			cv.addError(field+".MessagesPerSecond", fmt.Errorf("%w, should be greater than 0", ErrInvalidValue))
+		}
+		if topicConfig.Burst == 0 {
+			cv.addError(field+".Burst", fmt.Errorf("%w, should be greater than 0", ErrInvalidValue))
+		}
+	}
+	if limiterConfig.MaxViolations > 0 && limiterConfig.ViolationsWindowInSec == 0 {
+		cv.addError("InboundRateLimiter.ViolationsWindowInSec", fmt.Errorf("%w, should be greater than 0 when "+
+			"MaxViolations is set", ErrInvalidValue))
+	}
+	if limiterConfig.MaxViolations > 0 && limiterConfig.BanDurationInSec == 0 {
+		cv.addError("InboundRateLimiter.BanDurationInSec", fmt.Errorf("%w, should be greater than 0 when "+
+			"MaxViolations is set", ErrInvalidValue))
+	}
+}
+
+func (cv *configValidator) validatePeerDenial(denialConfig PeerDenialConfig) {
+	if denialConfig.MaxConnectionsPerIP > 0 && denialConfig.MaxConnectionsPerSubnet > 0 &&
+		denialConfig.MaxConnectionsPerIP > denialConfig.MaxConnectionsPerSubnet {
+		cv.addError("PeerDenial.MaxConnectionsPerIP", fmt.Errorf("%w, should not exceed MaxConnectionsPerSubnet (%d)",
+			ErrInvalidValue, denialConfig.MaxConnectionsPerSubnet))
+	}
+	if denialConfig.SubnetDenialThreshold > 0 && denialConfig.MaxTrackedPeers == 0 {
+		cv.addError("PeerDenial.MaxTrackedPeers", fmt.Errorf("%w, should be greater than 0 when SubnetDenialThreshold "+
+			"is set", ErrInvalidValue))
+	}
+}
+
+func (cv *configValidator) validateConnectionGater(gaterConfig ConnectionGaterConfig) {
+	cv.validateCIDRs("ConnectionGater.AllowCIDRs", gaterConfig.AllowCIDRs)
+	cv.validateCIDRs("ConnectionGater.DenyCIDRs", gaterConfig.DenyCIDRs)
+}
+
+func (cv *configValidator) validateResourceLimits(limitsConfig ResourceLimitsConfig) {
+	cv.validateScopeLimits("ResourceLimits.System", limitsConfig.System)
+	cv.validateScopeLimits("ResourceLimits.Transient", limitsConfig.Transient)
+	cv.validateScopeLimits("ResourceLimits.Peer", limitsConfig.Peer)
+	cv.validateScopeLimits("ResourceLimits.DirectSend", limitsConfig.DirectSend)
+	cv.validateScopeLimits("ResourceLimits.PubSub", limitsConfig.PubSub)
+	cv.validateScopeLimits("ResourceLimits.Kad", limitsConfig.Kad)
+	cv.validateScopeLimits("ResourceLimits.RequestResponse", limitsConfig.RequestResponse)
+}
+
+// validateScopeLimits checks that the inbound and the outbound limits do not exceed the total limit, when it is set
+func (cv *configValidator) validateScopeLimits(field string, limits ScopeLimitsConfig) {
+	cv.validateDirectionLimit(field+".StreamsInbound", limits.StreamsInbound, "Streams", limits.Streams)
+	cv.validateDirectionLimit(field+".StreamsOutbound", limits.StreamsOutbound, "Streams", limits.Streams)
+	cv.validateDirectionLimit(field+".ConnsInbound", limits.ConnsInbound, "Conns", limits.Conns)
+	cv.validateDirectionLimit(field+".ConnsOutbound", limits.ConnsOutbound, "Conns", limits.Conns)
+}
+
+func (cv *configValidator) validateDirectionLimit(field string, limit uint32, totalField string, total uint32) {
+	if total > 0 && limit > total {
+		cv.addError(field, fmt.Errorf("%w, should not exceed %s (%d)", ErrInvalidValue, totalField, total))
+	}
+}
+
+func (cv *configValidator) validateCIDRs(field string, cidrs []string) {
+	for idx, cidr := range cidrs {
+		_, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			cv.addError(fmt.Sprintf("%s[%d]", field, idx),
+				fmt.Errorf("%w, `%s` is not a valid CIDR: %s", ErrInvalidValue, cidr, err.Error()))
+		}
+	}
+}
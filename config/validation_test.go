@@ -0,0 +1,460 @@
+package config_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createValidP2PConfig() config.P2PConfig {
+	return config.P2PConfig{
+		Node: config.NodeConfig{
+			Port: "37373-38383",
+			Transports: config.TransportConfig{
+				TCP: config.TCPProtocolConfig{
+					ListenAddress: "/ip4/0.0.0.0/tcp/%d",
+				},
+				QUICAddress: "/ip4/0.0.0.0/udp/%d/quic-v1",
+			},
+		},
+		KadDhtPeerDiscovery: config.KadDhtPeerDiscoveryConfig{
+			Enabled:                          true,
+			Type:                             "optimized",
+			RefreshIntervalInSec:             10,
+			ProtocolID:                       "/drt/kad/1.0.0",
+			BucketSize:                       100,
+			RoutingTableRefreshIntervalInSec: 300,
+		},
+		Sharding: config.ShardingConfig{
+			TargetPeerCount:         36,
+			MaxIntraShardValidators: 7,
+			MaxCrossShardValidators: 15,
+			MaxIntraShardObservers:  2,
+			MaxCrossShardObservers:  3,
+			MaxSeeders:              2,
+			Type:                    p2p.ListsSharder,
+		},
+	}
+}
+
+func requireFieldError(t *testing.T, err error, field string, expectedErr error) {
+	var validationErr *config.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+
+	for _, fieldErr := range validationErr.FieldErrors {
+		if fieldErr.Field == field {
+			assert.True(t, errors.Is(fieldErr, expectedErr), "unexpected error for %s: %s", field, fieldErr.Error())
+			return
+		}
+	}
+
+	assert.Fail(t, "missing field error", "field %s, error %s", field, err.Error())
+}
+
+func TestP2PConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid config should work", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, createValidP2PConfig().Validate())
+
+		cfg := createValidP2PConfig()
+		cfg.Node.Port = "0"
+		cfg.KadDhtPeerDiscovery = config.KadDhtPeerDiscoveryConfig{}
+		cfg.Sharding = config.ShardingConfig{Type: p2p.NilListSharder}
+		assert.Nil(t, cfg.Validate())
+
+		cfg.Sharding = config.ShardingConfig{Type: p2p.OneListSharder, TargetPeerCount: 3}
+		assert.Nil(t, cfg.Validate())
+	})
+	t.Run("invalid ports should error", func(t *testing.T) {
+		t.Parallel()
+
+		testData := map[string]error{
+			"-1":          p2p.ErrInvalidPortValue,
+			"65536":       p2p.ErrInvalidPortValue,
+			"1-2-3":       p2p.ErrInvalidPortsRangeString,
+			"a-2000":      p2p.ErrInvalidStartingPortValue,
+			"2000-b":      p2p.ErrInvalidEndingPortValue,
+			"1000-2000":   p2p.ErrInvalidValue,
+			"2000-70000":  p2p.ErrInvalidEndingPortValue,
+			"20000-10000": p2p.ErrEndPortIsSmallerThanStartPort,
+		}
+		for port, expectedErr := range testData {
+			cfg := createValidP2PConfig()
+			cfg.Node.Port = port
+
+			requireFieldError(t, cfg.Validate(), "Node.Port", expectedErr)
+		}
+	})
+	t.Run("invalid transports should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createValidP2PConfig()
+		cfg.Node.Transports = config.TransportConfig{}
+		requireFieldError(t, cfg.Validate(), "Node.Transports", p2p.ErrNoTransportsDefined)
+
+		cfg.Node.Transports = config.TransportConfig{
+			TCP: config.TCPProtocolConfig{
+				ListenAddress: "/ip4/0.0.0.0/tcp/%d/%d",
+			},
+			QUICAddress:         "/ip4/0.0.0.0/udp/quic-v1",
+			WebSocketAddress:    "/ip4/0.0.0.0/tcp/ws",
+			WebTransportAddress: "/ip4/0.0.0.0/udp/quic-v1/webtransport",
+		}
+		err := cfg.Validate()
+		requireFieldError(t, err, "Node.Transports.TCP.ListenAddress", p2p.ErrInvalidTCPAddress)
+		requireFieldError(t, err, "Node.Transports.QUICAddress", p2p.ErrInvalidQUICAddress)
+		requireFieldError(t, err, "Node.Transports.WebSocketAddress", p2p.ErrInvalidWSAddress)
+		requireFieldError(t, err, "Node.Transports.WebTransportAddress", p2p.ErrInvalidWebTransportAddress)
+	})
+	t.Run("invalid announced addresses should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createValidP2PConfig()
+		cfg.Node.AnnouncedAddresses = config.AnnouncedAddressesConfig{
+			Addresses: []string{"/ip4/1.2.3.4/tcp/%d", "/ip4/1.2.3.4/tcp/%d/%d", "invalid address"},
+			DenyCIDRs: []string{"10.0.0.0/8", "10.0.0.0"},
+		}
+		err := cfg.Validate()
+		requireFieldError(t, err, "Node.AnnouncedAddresses.Addresses[1]", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "Node.AnnouncedAddresses.Addresses[2]", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "Node.AnnouncedAddresses.DenyCIDRs[1]", p2p.ErrInvalidValue)
+
+		var validationErr *config.ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, 3, len(validationErr.FieldErrors))
+	})
+	t.Run("invalid kad dht discovery should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createValidP2PConfig()
+		cfg.KadDhtPeerDiscovery.Type = "unknown"
+		cfg.KadDhtPeerDiscovery.RefreshIntervalInSec = 0
+		cfg.KadDhtPeerDiscovery.RoutingTableRefreshIntervalInSec = 0
+		err := cfg.Validate()
+		requireFieldError(t, err, "KadDhtPeerDiscovery.Type", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "KadDhtPeerDiscovery.RefreshIntervalInSec", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "KadDhtPeerDiscovery.RoutingTableRefreshIntervalInSec", p2p.ErrInvalidValue)
+
+		for _, protocolID := range []string{"", "drt/kad/1.0.0", "/drt/kad 1.0.0"} {
+			cfg = createValidP2PConfig()
+			cfg.KadDhtPeerDiscovery.ProtocolID = protocolID
+			requireFieldError(t, cfg.Validate(), "KadDhtPeerDiscovery.ProtocolID", p2p.ErrInvalidProtocolID)
+		}
+
+		cfg.KadDhtPeerDiscovery.Enabled = false
+		assert.Nil(t, cfg.Validate())
+	})
+	t.Run("invalid mDNS discovery should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createValidP2PConfig()
+		cfg.MDNSDiscovery.Enabled = true
+		err := cfg.Validate()
+		requireFieldError(t, err, "MDNSDiscovery.Enabled", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "MDNSDiscovery.ServiceName", p2p.ErrInvalidValue)
+
+		cfg.KadDhtPeerDiscovery.Enabled = false
+		cfg.MDNSDiscovery.ServiceName = "drt devnet"
+		requireFieldError(t, cfg.Validate(), "MDNSDiscovery.ServiceName", p2p.ErrInvalidValue)
+
+		cfg.MDNSDiscovery.ServiceName = "drt-devnet"
+		assert.Nil(t, cfg.Validate())
+	})
+	t.Run("invalid rendezvous should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createValidP2PConfig()
+		cfg.KadDhtPeerDiscovery.Type = "legacy"
+		cfg.KadDhtPeerDiscovery.Rendezvous.Enabled = true
+		cfg.Sharding.Type = p2p.OneListSharder
+		err := cfg.Validate()
+		requireFieldError(t, err, "KadDhtPeerDiscovery.Rendezvous.Enabled", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "KadDhtPeerDiscovery.Rendezvous.NamespacePrefix", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "KadDhtPeerDiscovery.Rendezvous.MaxPeersPerQuery", p2p.ErrInvalidValue)
+
+		var validationErr *config.ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, 4, len(validationErr.FieldErrors))
+
+		cfg = createValidP2PConfig()
+		cfg.KadDhtPeerDiscovery.Rendezvous = config.RendezvousConfig{
+			Enabled:          true,
+			NamespacePrefix:  "drt/",
+			MaxPeersPerQuery: 20,
+		}
+		requireFieldError(t, cfg.Validate(), "KadDhtPeerDiscovery.Rendezvous.NamespacePrefix", p2p.ErrInvalidValue)
+
+		cfg.KadDhtPeerDiscovery.Rendezvous.NamespacePrefix = "drt"
+		assert.Nil(t, cfg.Validate())
+	})
+	t.Run("invalid sharding should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createValidP2PConfig()
+		cfg.Sharding.Type = "unknown"
+		requireFieldError(t, cfg.Validate(), "Sharding.Type", p2p.ErrInvalidValue)
+
+		cfg = createValidP2PConfig()
+		cfg.Sharding.Type = p2p.OneListSharder
+		cfg.Sharding.TargetPeerCount = 2
+		requireFieldError(t, cfg.Validate(), "Sharding.TargetPeerCount", p2p.ErrInvalidValue)
+
+		cfg = createValidP2PConfig()
+		cfg.Sharding.MaxIntraShardValidators = 0
+		cfg.Sharding.MaxCrossShardValidators = 0
+		cfg.Sharding.MaxIntraShardObservers = 0
+		cfg.Sharding.MaxCrossShardObservers = 0
+		err := cfg.Validate()
+		requireFieldError(t, err, "Sharding.MaxIntraShardValidators", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "Sharding.MaxCrossShardValidators", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "Sharding.MaxIntraShardObservers", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "Sharding.MaxCrossShardObservers", p2p.ErrInvalidValue)
+
+		cfg = createValidP2PConfig()
+		cfg.Sharding.MaxSeeders = 10
+		requireFieldError(t, cfg.Validate(), "Sharding.TargetPeerCount", p2p.ErrInvalidValue)
+	})
+	t.Run("invalid latency should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createValidP2PConfig()
+		cfg.Sharding.Type = p2p.LatencyListsSharder
+		cfg.Sharding.Latency = config.LatencyConfig{
+			PingIntervalInSec:   30,
+			RandomPeersFraction: 0.2,
+		}
+		assert.Nil(t, cfg.Validate())
+
+		cfg.Sharding.Latency.PingIntervalInSec = 0
+		cfg.Sharding.Latency.RandomPeersFraction = 1.5
+		err := cfg.Validate()
+		requireFieldError(t, err, "Sharding.Latency.PingIntervalInSec", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "Sharding.Latency.RandomPeersFraction", p2p.ErrInvalidValue)
+
+		cfg.Sharding.Latency.PingIntervalInSec = 9
+		cfg.Sharding.Latency.RandomPeersFraction = 0.2
+		requireFieldError(t, cfg.Validate(), "Sharding.Latency.PingIntervalInSec", p2p.ErrInvalidValue)
+
+		cfg.Sharding.Latency.RandomPeersFraction = math.NaN()
+		requireFieldError(t, cfg.Validate(), "Sharding.Latency.RandomPeersFraction", p2p.ErrInvalidValue)
+	})
+	t.Run("invalid network diversity should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createValidP2PConfig()
+		cfg.Sharding.Type = p2p.DiversityListsSharder
+		cfg.Sharding.NetworkDiversity.MaxPeersPerPrefix = 2
+		assert.Nil(t, cfg.Validate())
+
+		cfg.Sharding.MaxIntraShardValidators = 0
+		cfg.Sharding.NetworkDiversity.IPv4PrefixLength = 33
+		cfg.Sharding.NetworkDiversity.IPv6PrefixLength = 129
+		cfg.Sharding.NetworkDiversity.MaxPeersPerASN = 1
+		err := cfg.Validate()
+		requireFieldError(t, err, "Sharding.MaxIntraShardValidators", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "Sharding.NetworkDiversity.IPv4PrefixLength", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "Sharding.NetworkDiversity.IPv6PrefixLength", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "Sharding.NetworkDiversity.ASNPrefixFilePath", p2p.ErrInvalidValue)
+	})
+	t.Run("invalid relay should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createValidP2PConfig()
+		cfg.Relay.EnableHolePunching = true
+		cfg.Relay.Service.Enabled = true
+		cfg.Relay.Client.Enabled = true
+		err := cfg.Validate()
+		requireFieldError(t, err, "Relay.EnableHolePunching", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "Relay.Service.Enabled", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "Relay.Client.Enabled", p2p.ErrInvalidValue)
+
+		cfg = createValidP2PConfig()
+		cfg.Relay.Enabled = true
+		cfg.Relay.Client.Enabled = true
+		requireFieldError(t, cfg.Validate(), "Relay.Client.StaticRelays", p2p.ErrInvalidValue)
+
+		cfg.Relay.Client.StaticRelays = []string{"invalid address"}
+		requireFieldError(t, cfg.Validate(), "Relay.Client.StaticRelays[0]", p2p.ErrInvalidValue)
+
+		cfg.Relay.Client.StaticRelays = []string{"/ip4/10.0.0.1/tcp/37373/p2p/16Uiu2HAm6yvbp1oZ6zjnWsn9FdRqBSaQkbhELyaThuq48ybdorrr"}
+		cfg.Relay.Client.NumRelays = 2
+		requireFieldError(t, cfg.Validate(), "Relay.Client.NumRelays", p2p.ErrInvalidValue)
+
+		cfg.Relay.Client.NumRelays = 1
+		cfg.Relay.Service.Enabled = true
+		cfg.Relay.EnableHolePunching = true
+		assert.Nil(t, cfg.Validate())
+	})
+	t.Run("invalid peerstore should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createValidP2PConfig()
+		cfg.Peerstore.Enabled = true
+		cfg.Peerstore.DatabasePath = " "
+		cfg.Peerstore.WarmStartMaxPeers = 10
+		err := cfg.Validate()
+		requireFieldError(t, err, "Peerstore.DatabasePath", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "Peerstore.RecordTTLInSec", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "Peerstore.MaxPeers", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "Peerstore.GCIntervalInSec", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "Peerstore.WarmStartTimeoutInSec", p2p.ErrInvalidValue)
+
+		cfg.Peerstore = config.PeerstoreConfig{
+			Enabled:               true,
+			DatabasePath:          "db/peerstore",
+			RecordTTLInSec:        86400,
+			MaxPeers:              1000,
+			GCIntervalInSec:       600,
+			WarmStartMaxPeers:     10,
+			WarmStartTimeoutInSec: 5,
+		}
+		assert.Nil(t, cfg.Validate())
+	})
+	t.Run("invalid connection gater CIDRs should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createValidP2PConfig()
+		cfg.ConnectionGater = config.ConnectionGaterConfig{
+			AllowCIDRs: []string{"10.1.2.0/24", "10.1.3.0"},
+			DenyCIDRs:  []string{"10.0.0.0/33"},
+		}
+		err := cfg.Validate()
+		requireFieldError(t, err, "ConnectionGater.AllowCIDRs[1]", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "ConnectionGater.DenyCIDRs[0]", p2p.ErrInvalidValue)
+
+		cfg.ConnectionGater = config.ConnectionGaterConfig{
+			AllowCIDRs: []string{"10.1.2.0/24"},
+			DenyCIDRs:  []string{"10.0.0.0/8", "fd00::/8"},
+		}
+		assert.Nil(t, cfg.Validate())
+	})
+	t.Run("direction resource limits over the total limit should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createValidP2PConfig()
+		cfg.ResourceLimits.System = config.ScopeLimitsConfig{
+			Streams:        100,
+			StreamsInbound: 101,
+			ConnsOutbound:  1000,
+		}
+		cfg.ResourceLimits.DirectSend = config.ScopeLimitsConfig{
+			Streams:         10,
+			StreamsOutbound: 11,
+		}
+		err := cfg.Validate()
+		requireFieldError(t, err, "ResourceLimits.System.StreamsInbound", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "ResourceLimits.DirectSend.StreamsOutbound", p2p.ErrInvalidValue)
+
+		cfg.ResourceLimits.System.StreamsInbound = 100
+		cfg.ResourceLimits.DirectSend.StreamsOutbound = 10
+		assert.Nil(t, cfg.Validate())
+	})
+	t.Run("invalid peer scoring should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createValidP2PConfig()
+		cfg.PeerScoring = config.PeerScoringConfig{
+			DecayIntervalInSec: 0,
+			Topics:             []config.TopicScoringConfig{{Name: "topic"}, {Name: "topic"}, {}},
+		}
+		assert.Nil(t, cfg.Validate())
+
+		cfg.PeerScoring.Enabled = true
+		err := cfg.Validate()
+		requireFieldError(t, err, "PeerScoring.DecayIntervalInSec", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "PeerScoring.InspectIntervalInSec", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "PeerScoring.Topics[1].Name", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "PeerScoring.Topics[2].Name", p2p.ErrInvalidValue)
+	})
+	t.Run("invalid large payloads should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createValidP2PConfig()
+		cfg.LargePayloads = config.LargePayloadsConfig{
+			Enabled:                      true,
+			MaxPayloadSizeInBytes:        100,
+			MaxPendingSizeInBytes:        99,
+			MaxPendingSizePerPeerInBytes: 10,
+		}
+		err := cfg.Validate()
+		requireFieldError(t, err, "LargePayloads.MaxPendingSizeInBytes", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "LargePayloads.MaxPendingSizePerPeerInBytes", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "LargePayloads.TransferTimeoutInSec", p2p.ErrInvalidValue)
+
+		cfg.LargePayloads.MaxPayloadSizeInBytes = 0
+		requireFieldError(t, cfg.Validate(), "LargePayloads.MaxPayloadSizeInBytes", p2p.ErrInvalidValue)
+
+		cfg.LargePayloads = config.LargePayloadsConfig{
+			Enabled:               true,
+			MaxPayloadSizeInBytes: 100,
+			MaxPendingSizeInBytes: 200,
+			TransferTimeoutInSec:  1,
+		}
+		assert.Nil(t, cfg.Validate())
+	})
+	t.Run("invalid inbound rate limiter should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createValidP2PConfig()
+		cfg.InboundRateLimiter = config.InboundRateLimiterConfig{
+			Enabled:                  true,
+			DefaultMessagesPerSecond: 10,
+			Topics:                   []config.TopicRateLimitConfig{{MessagesPerSecond: 1}},
+			MaxViolations:            3,
+		}
+		err := cfg.Validate()
+		requireFieldError(t, err, "InboundRateLimiter.DefaultBurst", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "InboundRateLimiter.Topics[0].Name", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "InboundRateLimiter.Topics[0].Burst", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "InboundRateLimiter.ViolationsWindowInSec", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "InboundRateLimiter.BanDurationInSec", p2p.ErrInvalidValue)
+
+		cfg.InboundRateLimiter.Enabled = false
+		assert.Nil(t, cfg.Validate())
+	})
+	t.Run("invalid peer denial should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createValidP2PConfig()
+		cfg.PeerDenial = config.PeerDenialConfig{
+			MaxConnectionsPerIP:     3,
+			MaxConnectionsPerSubnet: 2,
+			SubnetDenialThreshold:   1,
+		}
+		err := cfg.Validate()
+		requireFieldError(t, err, "PeerDenial.MaxConnectionsPerIP", p2p.ErrInvalidValue)
+		requireFieldError(t, err, "PeerDenial.MaxTrackedPeers", p2p.ErrInvalidValue)
+
+		cfg.PeerDenial.MaxConnectionsPerIP = 2
+		cfg.PeerDenial.MaxTrackedPeers = 10
+		assert.Nil(t, cfg.Validate())
+	})
+	t.Run("should report all the problems at once", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createValidP2PConfig()
+		cfg.Node.Port = "-1"
+		cfg.Node.Transports = config.TransportConfig{}
+		cfg.KadDhtPeerDiscovery.ProtocolID = ""
+		cfg.Sharding.Type = ""
+
+		err := cfg.Validate()
+		assert.True(t, errors.Is(err, p2p.ErrInvalidConfig))
+
+		var validationErr *config.ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, 4, len(validationErr.FieldErrors))
+		for _, field := range []string{"Node.Port", "Node.Transports", "KadDhtPeerDiscovery.ProtocolID", "Sharding.Type"} {
+			assert.True(t, strings.Contains(err.Error(), field))
+		}
+	})
+}
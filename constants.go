@@ -23,6 +23,12 @@ const (
 	OneListSharder = "OneListSharder"
 	// NilListSharder is the variant that will not do connection trimming
 	NilListSharder = "NilListSharder"
+	// DiversityListsSharder is the variant that uses lists and prefers keeping, in each list, peers from distinct
+	// networks
+	DiversityListsSharder = "DiversityListsSharder"
+	// LatencyListsSharder is the variant that uses lists and prefers keeping, in each list, the peers with the lowest
+	// latency
+	LatencyListsSharder = "LatencyListsSharder"
 
 	// ConnectionWatcherTypePrint - new connection found will be printed in the log file
 	ConnectionWatcherTypePrint = "print"
@@ -0,0 +1,657 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: chunkMessage.proto
+
+package data
+
+import (
+	bytes "bytes"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	reflect "reflect"
+	strings "strings"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type ChunkMessage struct {
+	TransferID uint64 `protobuf:"varint,1,opt,name=TransferID,proto3" json:"TransferID,omitempty"`
+	Topic      string `protobuf:"bytes,2,opt,name=Topic,proto3" json:"Topic,omitempty"`
+	ChunkIndex uint32 `protobuf:"varint,3,opt,name=ChunkIndex,proto3" json:"ChunkIndex,omitempty"`
+	NumChunks  uint32 `protobuf:"varint,4,opt,name=NumChunks,proto3" json:"NumChunks,omitempty"`
+	TotalSize  uint64 `protobuf:"varint,5,opt,name=TotalSize,proto3" json:"TotalSize,omitempty"`
+	TotalHash  []byte `protobuf:"bytes,6,opt,name=TotalHash,proto3" json:"TotalHash,omitempty"`
+	Payload    []byte `protobuf:"bytes,7,opt,name=Payload,proto3" json:"Payload,omitempty"`
+}
+
+func (m *ChunkMessage) Reset()      { *m = ChunkMessage{} }
+func (*ChunkMessage) ProtoMessage() {}
+func (*ChunkMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96a456c557a8407a, []int{0}
+}
+func (m *ChunkMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChunkMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *ChunkMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChunkMessage.Merge(m, src)
+}
+func (m *ChunkMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChunkMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChunkMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChunkMessage proto.InternalMessageInfo
+
+func (m *ChunkMessage) GetTransferID() uint64 {
+	if m != nil {
+		return m.TransferID
+	}
+	return 0
+}
+
+func (m *ChunkMessage) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *ChunkMessage) GetChunkIndex() uint32 {
+	if m != nil {
+		return m.ChunkIndex
+	}
+	return 0
+}
+
+func (m *ChunkMessage) GetNumChunks() uint32 {
+	if m != nil {
+		return m.NumChunks
+	}
+	return 0
+}
+
+func (m *ChunkMessage) GetTotalSize() uint64 {
+	if m != nil {
+		return m.TotalSize
+	}
+	return 0
+}
+
+func (m *ChunkMessage) GetTotalHash() []byte {
+	if m != nil {
+		return m.TotalHash
+	}
+	return nil
+}
+
+func (m *ChunkMessage) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ChunkMessage)(nil), "proto.ChunkMessage")
+}
+
+func init() { proto.RegisterFile("chunkMessage.proto", fileDescriptor_96a456c557a8407a) }
+
+var fileDescriptor_96a456c557a8407a = []byte{
+	// 277 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x90, 0xbb, 0x4e, 0xc3, 0x30,
+	0x14, 0x40, 0x7d, 0xa1, 0x0f, 0xd5, 0x2a, 0x8b, 0xc5, 0x60, 0x21, 0x74, 0x15, 0x31, 0x65, 0xa1,
+	0x1d, 0xd8, 0x19, 0x80, 0x81, 0x0e, 0x20, 0x14, 0x32, 0xb1, 0x39, 0x8f, 0x26, 0x11, 0x6d, 0x5c,
+	0xe5, 0x21, 0x01, 0x13, 0x9f, 0xc0, 0x67, 0xf0, 0x29, 0x8c, 0x19, 0x33, 0x12, 0x67, 0x61, 0xec,
+	0x27, 0xa0, 0xde, 0x0a, 0x92, 0xc9, 0x3e, 0xe7, 0xe8, 0x5e, 0x4b, 0xe6, 0xc2, 0x8f, 0xcb, 0xf4,
+	0xf9, 0x2e, 0xcc, 0x73, 0x15, 0x85, 0xb3, 0x4d, 0xa6, 0x0b, 0x2d, 0x86, 0x74, 0x9c, 0x9c, 0x47,
+	0x49, 0x11, 0x97, 0xde, 0xcc, 0xd7, 0xeb, 0x79, 0xa4, 0x23, 0x3d, 0x27, 0xed, 0x95, 0x4b, 0x22,
+	0x02, 0xba, 0xed, 0xa7, 0xce, 0x6a, 0xe0, 0xd3, 0xeb, 0xde, 0x32, 0x81, 0x9c, 0xbb, 0x99, 0x4a,
+	0xf3, 0x65, 0x98, 0x2d, 0x6e, 0x24, 0x58, 0x60, 0x0f, 0x9c, 0x9e, 0x11, 0xc7, 0x7c, 0xe8, 0xea,
+	0x4d, 0xe2, 0xcb, 0x03, 0x0b, 0xec, 0x89, 0xb3, 0x87, 0xdd, 0x14, 0x6d, 0x59, 0xa4, 0x41, 0xf8,
+	0x22, 0x0f, 0x2d, 0xb0, 0x8f, 0x9c, 0x9e, 0x11, 0xa7, 0x7c, 0x72, 0x5f, 0xae, 0x49, 0xe4, 0x72,
+	0x40, 0xb9, 0x13, 0xbb, 0xea, 0xea, 0x42, 0xad, 0x1e, 0x93, 0xb7, 0x50, 0x0e, 0xe9, 0xc9, 0x4e,
+	0xfc, 0xd7, 0x5b, 0x95, 0xc7, 0x72, 0x64, 0x81, 0x3d, 0x75, 0x3a, 0x21, 0x24, 0x1f, 0x3f, 0xa8,
+	0xd7, 0x95, 0x56, 0x81, 0x1c, 0x53, 0xfb, 0xc3, 0xab, 0xcb, 0xaa, 0x41, 0x56, 0x37, 0xc8, 0xb6,
+	0x0d, 0xc2, 0xbb, 0x41, 0xf8, 0x34, 0x08, 0x5f, 0x06, 0xa1, 0x32, 0x08, 0xb5, 0x41, 0xf8, 0x36,
+	0x08, 0x3f, 0x06, 0xd9, 0xd6, 0x20, 0x7c, 0xb4, 0xc8, 0xaa, 0x16, 0x59, 0xdd, 0x22, 0x7b, 0x1a,
+	0x04, 0xaa, 0x50, 0xde, 0x88, 0x7e, 0xe8, 0xe2, 0x37, 0x00, 0x00, 0xff, 0xff, 0xd0, 0xa1, 0x63,
+	0x56, 0x6d, 0x01, 0x00, 0x00,
+}
+
+func (this *ChunkMessage) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ChunkMessage)
+	if !ok {
+		that2, ok := that.(ChunkMessage)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.TransferID != that1.TransferID {
+		return false
+	}
+	if this.Topic != that1.Topic {
+		return false
+	}
+	if this.ChunkIndex != that1.ChunkIndex {
+		return false
+	}
+	if this.NumChunks != that1.NumChunks {
+		return false
+	}
+	if this.TotalSize != that1.TotalSize {
+		return false
+	}
+	if !bytes.Equal(this.TotalHash, that1.TotalHash) {
+		return false
+	}
+	if !bytes.Equal(this.Payload, that1.Payload) {
+		return false
+	}
+	return true
+}
+func (this *ChunkMessage) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 11)
+	s = append(s, "&data.ChunkMessage{")
+	s = append(s, "TransferID: "+fmt.Sprintf("%#v", this.TransferID)+",\n")
+	s = append(s, "Topic: "+fmt.Sprintf("%#v", this.Topic)+",\n")
+	s = append(s, "ChunkIndex: "+fmt.Sprintf("%#v", this.ChunkIndex)+",\n")
+	s = append(s, "NumChunks: "+fmt.Sprintf("%#v", this.NumChunks)+",\n")
+	s = append(s, "TotalSize: "+fmt.Sprintf("%#v", this.TotalSize)+",\n")
+	s = append(s, "TotalHash: "+fmt.Sprintf("%#v", this.TotalHash)+",\n")
+	s = append(s, "Payload: "+fmt.Sprintf("%#v", this.Payload)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringChunkMessage(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *ChunkMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChunkMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChunkMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintChunkMessage(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.TotalHash) > 0 {
+		i -= len(m.TotalHash)
+		copy(dAtA[i:], m.TotalHash)
+		i = encodeVarintChunkMessage(dAtA, i, uint64(len(m.TotalHash)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.TotalSize != 0 {
+		i = encodeVarintChunkMessage(dAtA, i, uint64(m.TotalSize))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.NumChunks != 0 {
+		i = encodeVarintChunkMessage(dAtA, i, uint64(m.NumChunks))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.ChunkIndex != 0 {
+		i = encodeVarintChunkMessage(dAtA, i, uint64(m.ChunkIndex))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Topic) > 0 {
+		i -= len(m.Topic)
+		copy(dAtA[i:], m.Topic)
+		i = encodeVarintChunkMessage(dAtA, i, uint64(len(m.Topic)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.TransferID != 0 {
+		i = encodeVarintChunkMessage(dAtA, i, uint64(m.TransferID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintChunkMessage(dAtA []byte, offset int, v uint64) int {
+	offset -= sovChunkMessage(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ChunkMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TransferID != 0 {
+		n += 1 + sovChunkMessage(uint64(m.TransferID))
+	}
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovChunkMessage(uint64(l))
+	}
+	if m.ChunkIndex != 0 {
+		n += 1 + sovChunkMessage(uint64(m.ChunkIndex))
+	}
+	if m.NumChunks != 0 {
+		n += 1 + sovChunkMessage(uint64(m.NumChunks))
+	}
+	if m.TotalSize != 0 {
+		n += 1 + sovChunkMessage(uint64(m.TotalSize))
+	}
+	l = len(m.TotalHash)
+	if l > 0 {
+		n += 1 + l + sovChunkMessage(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovChunkMessage(uint64(l))
+	}
+	return n
+}
+
+func sovChunkMessage(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozChunkMessage(x uint64) (n int) {
+	return sovChunkMessage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *ChunkMessage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ChunkMessage{`,
+		`TransferID:` + fmt.Sprintf("%v", this.TransferID) + `,`,
+		`Topic:` + fmt.Sprintf("%v", this.Topic) + `,`,
+		`ChunkIndex:` + fmt.Sprintf("%v", this.ChunkIndex) + `,`,
+		`NumChunks:` + fmt.Sprintf("%v", this.NumChunks) + `,`,
+		`TotalSize:` + fmt.Sprintf("%v", this.TotalSize) + `,`,
+		`TotalHash:` + fmt.Sprintf("%v", this.TotalHash) + `,`,
+		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringChunkMessage(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *ChunkMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowChunkMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChunkMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChunkMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransferID", wireType)
+			}
+			m.TransferID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChunkMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TransferID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChunkMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChunkMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChunkMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkIndex", wireType)
+			}
+			m.ChunkIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChunkMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChunkIndex |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumChunks", wireType)
+			}
+			m.NumChunks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChunkMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumChunks |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalSize", wireType)
+			}
+			m.TotalSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChunkMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChunkMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthChunkMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChunkMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TotalHash = append(m.TotalHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.TotalHash == nil {
+				m.TotalHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChunkMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthChunkMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChunkMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipChunkMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthChunkMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipChunkMessage(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowChunkMessage
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowChunkMessage
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowChunkMessage
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthChunkMessage
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupChunkMessage
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthChunkMessage
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthChunkMessage        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowChunkMessage          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupChunkMessage = fmt.Errorf("proto: unexpected end of group")
+)
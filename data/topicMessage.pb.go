@@ -32,6 +32,7 @@ type TopicMessage struct {
 	Timestamp      int64  `protobuf:"varint,3,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
 	Pk             []byte `protobuf:"bytes,4,opt,name=Pk,proto3" json:"Pk,omitempty"`
 	SignatureOnPid []byte `protobuf:"bytes,5,opt,name=SignatureOnPid,proto3" json:"SignatureOnPid,omitempty"`
+	Compression    uint32 `protobuf:"varint,6,opt,name=Compression,proto3" json:"Compression,omitempty"`
 }
 
 func (m *TopicMessage) Reset()      { *m = TopicMessage{} }
@@ -97,6 +98,13 @@ func (m *TopicMessage) GetSignatureOnPid() []byte {
 	return nil
 }
 
+func (m *TopicMessage) GetCompression() uint32 {
+	if m != nil {
+		return m.Compression
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*TopicMessage)(nil), "proto.TopicMessage")
 }
@@ -104,23 +112,24 @@ func init() {
 func init() { proto.RegisterFile("topicMessage.proto", fileDescriptor_131cdede10b420b6) }
 
 var fileDescriptor_131cdede10b420b6 = []byte{
-	// 255 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x2a, 0xc9, 0x2f, 0xc8,
-	0x4c, 0xf6, 0x4d, 0x2d, 0x2e, 0x4e, 0x4c, 0x4f, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62,
-	0x05, 0x53, 0x52, 0xba, 0xe9, 0x99, 0x25, 0x19, 0xa5, 0x49, 0x7a, 0xc9, 0xf9, 0xb9, 0xfa, 0xe9,
-	0xf9, 0xe9, 0xf9, 0xfa, 0x60, 0xe1, 0xa4, 0xd2, 0x34, 0x30, 0x0f, 0xcc, 0x01, 0xb3, 0x20, 0xba,
-	0x94, 0x66, 0x30, 0x72, 0xf1, 0x84, 0x20, 0x19, 0x26, 0x24, 0xc1, 0xc5, 0x1e, 0x96, 0x5a, 0x54,
-	0x9c, 0x99, 0x9f, 0x27, 0xc1, 0xa8, 0xc0, 0xa8, 0xc1, 0x1b, 0x04, 0xe3, 0x82, 0x64, 0x02, 0x12,
-	0x2b, 0x73, 0xf2, 0x13, 0x53, 0x24, 0x98, 0x14, 0x18, 0x35, 0x78, 0x82, 0x60, 0x5c, 0x21, 0x19,
-	0x2e, 0xce, 0x90, 0xcc, 0xdc, 0xd4, 0xe2, 0x92, 0xc4, 0xdc, 0x02, 0x09, 0x66, 0x05, 0x46, 0x0d,
-	0xe6, 0x20, 0x84, 0x80, 0x10, 0x1f, 0x17, 0x53, 0x40, 0xb6, 0x04, 0x0b, 0x58, 0x0b, 0x53, 0x40,
-	0xb6, 0x90, 0x1a, 0x17, 0x5f, 0x70, 0x66, 0x7a, 0x5e, 0x62, 0x49, 0x69, 0x51, 0xaa, 0x7f, 0x5e,
-	0x40, 0x66, 0x8a, 0x04, 0x2b, 0x58, 0x0e, 0x4d, 0xd4, 0xc9, 0xee, 0xc2, 0x43, 0x39, 0x86, 0x1b,
-	0x0f, 0xe5, 0x18, 0x3e, 0x3c, 0x94, 0x63, 0x6c, 0x78, 0x24, 0xc7, 0xb8, 0xe2, 0x91, 0x1c, 0xe3,
-	0x89, 0x47, 0x72, 0x8c, 0x17, 0x1e, 0xc9, 0x31, 0xde, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c,
-	0xe3, 0x8b, 0x47, 0x72, 0x0c, 0x1f, 0x1e, 0xc9, 0x31, 0x4e, 0x78, 0x2c, 0xc7, 0x70, 0xe1, 0xb1,
-	0x1c, 0xc3, 0x8d, 0xc7, 0x72, 0x0c, 0x51, 0x2c, 0x29, 0x89, 0x25, 0x89, 0x49, 0x6c, 0x60, 0x1f,
-	0x1a, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0xa0, 0x51, 0x72, 0x2b, 0x2d, 0x01, 0x00, 0x00,
+	// 272 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x90, 0x3f, 0x4e, 0xc3, 0x30,
+	0x18, 0xc5, 0xfd, 0xf5, 0x1f, 0xc2, 0x94, 0x0e, 0x9e, 0x2c, 0x84, 0x3e, 0x45, 0x0c, 0x28, 0x0b,
+	0xed, 0xc0, 0xce, 0x00, 0x33, 0x22, 0x0a, 0x15, 0x03, 0x9b, 0xd3, 0x98, 0x60, 0x95, 0xc4, 0x51,
+	0xec, 0x0c, 0x6c, 0x1c, 0x81, 0x63, 0x70, 0x06, 0x4e, 0xc0, 0x98, 0x31, 0x23, 0x71, 0x16, 0xc6,
+	0x1e, 0x01, 0x61, 0x54, 0x51, 0x31, 0xd9, 0xbf, 0xdf, 0xd3, 0xb3, 0x9e, 0x4c, 0x99, 0xd5, 0xa5,
+	0x5a, 0x5d, 0x4b, 0x63, 0x44, 0x26, 0xe7, 0x65, 0xa5, 0xad, 0x66, 0x63, 0x7f, 0x1c, 0x9d, 0x65,
+	0xca, 0x3e, 0xd6, 0xc9, 0x7c, 0xa5, 0xf3, 0x45, 0xa6, 0x33, 0xbd, 0xf0, 0x3a, 0xa9, 0x1f, 0x3c,
+	0x79, 0xf0, 0xb7, 0xdf, 0xd6, 0xc9, 0x3b, 0xd0, 0xe9, 0x72, 0xe7, 0x31, 0xc6, 0xe9, 0xde, 0x9d,
+	0xac, 0x8c, 0xd2, 0x05, 0x87, 0x00, 0xc2, 0xc3, 0x78, 0x8b, 0x3f, 0x49, 0x24, 0x9e, 0x9f, 0xb4,
+	0x48, 0xf9, 0x20, 0x80, 0x70, 0x1a, 0x6f, 0x91, 0x1d, 0xd3, 0xfd, 0xa5, 0xca, 0xa5, 0xb1, 0x22,
+	0x2f, 0xf9, 0x30, 0x80, 0x70, 0x18, 0xff, 0x09, 0x36, 0xa3, 0x83, 0x68, 0xcd, 0x47, 0xbe, 0x32,
+	0x88, 0xd6, 0xec, 0x94, 0xce, 0x6e, 0x55, 0x56, 0x08, 0x5b, 0x57, 0xf2, 0xa6, 0x88, 0x54, 0xca,
+	0xc7, 0x3e, 0xfb, 0x67, 0x59, 0x40, 0x0f, 0xae, 0x74, 0x5e, 0x56, 0xd2, 0xf8, 0x35, 0x13, 0xbf,
+	0x66, 0x57, 0x5d, 0x5e, 0x34, 0x1d, 0x92, 0xb6, 0x43, 0xb2, 0xe9, 0x10, 0x5e, 0x1c, 0xc2, 0x9b,
+	0x43, 0xf8, 0x70, 0x08, 0x8d, 0x43, 0x68, 0x1d, 0xc2, 0xa7, 0x43, 0xf8, 0x72, 0x48, 0x36, 0x0e,
+	0xe1, 0xb5, 0x47, 0xd2, 0xf4, 0x48, 0xda, 0x1e, 0xc9, 0xfd, 0x28, 0x15, 0x56, 0x24, 0x13, 0xff,
+	0x07, 0xe7, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x78, 0xca, 0xa0, 0xb7, 0x4f, 0x01, 0x00, 0x00,
 }
 
 func (this *TopicMessage) Equal(that interface{}) bool {
@@ -157,19 +166,23 @@ func (this *TopicMessage) Equal(that interface{}) bool {
 	if !bytes.Equal(this.SignatureOnPid, that1.SignatureOnPid) {
 		return false
 	}
+	if this.Compression != that1.Compression {
+		return false
+	}
 	return true
 }
 func (this *TopicMessage) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 9)
+	s := make([]string, 0, 10)
 	s = append(s, "&data.TopicMessage{")
 	s = append(s, "Version: "+fmt.Sprintf("%#v", this.Version)+",\n")
 	s = append(s, "Payload: "+fmt.Sprintf("%#v", this.Payload)+",\n")
 	s = append(s, "Timestamp: "+fmt.Sprintf("%#v", this.Timestamp)+",\n")
 	s = append(s, "Pk: "+fmt.Sprintf("%#v", this.Pk)+",\n")
 	s = append(s, "SignatureOnPid: "+fmt.Sprintf("%#v", this.SignatureOnPid)+",\n")
+	s = append(s, "Compression: "+fmt.Sprintf("%#v", this.Compression)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -201,6 +214,11 @@ func (m *TopicMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Compression != 0 {
+		i = encodeVarintTopicMessage(dAtA, i, uint64(m.Compression))
+		i--
+		dAtA[i] = 0x30
+	}
 	if len(m.SignatureOnPid) > 0 {
 		i -= len(m.SignatureOnPid)
 		copy(dAtA[i:], m.SignatureOnPid)
@@ -270,6 +288,9 @@ func (m *TopicMessage) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTopicMessage(uint64(l))
 	}
+	if m.Compression != 0 {
+		n += 1 + sovTopicMessage(uint64(m.Compression))
+	}
 	return n
 }
 
@@ -289,6 +310,7 @@ func (this *TopicMessage) String() string {
 		`Timestamp:` + fmt.Sprintf("%v", this.Timestamp) + `,`,
 		`Pk:` + fmt.Sprintf("%v", this.Pk) + `,`,
 		`SignatureOnPid:` + fmt.Sprintf("%v", this.SignatureOnPid) + `,`,
+		`Compression:` + fmt.Sprintf("%v", this.Compression) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -470,6 +492,25 @@ func (m *TopicMessage) Unmarshal(dAtA []byte) error {
 				m.SignatureOnPid = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			m.Compression = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTopicMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Compression |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTopicMessage(dAtA[iNdEx:])
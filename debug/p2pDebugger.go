@@ -28,6 +28,12 @@ type metric struct {
 	outgoingRejectedSize uint64
 	outgoingNum          uint32
 	outgoingRejectedNum  uint32
+
+	uncompressedSize uint64
+	compressedSize   uint64
+
+	rateLimitedSize uint64
+	rateLimitedNum  uint32
 }
 
 func (m *metric) divideValues(divideValue float32) {
@@ -40,6 +46,12 @@ func (m *metric) divideValues(divideValue float32) {
 	m.outgoingNum = uint32(float32(m.outgoingNum) / divideValue)
 	m.outgoingRejectedSize = uint64(float32(m.outgoingRejectedSize) / divideValue)
 	m.outgoingRejectedNum = uint32(float32(m.outgoingRejectedNum) / divideValue)
+
+	m.uncompressedSize = uint64(float32(m.uncompressedSize) / divideValue)
+	m.compressedSize = uint64(float32(m.compressedSize) / divideValue)
+
+	m.rateLimitedSize = uint64(float32(m.rateLimitedSize) / divideValue)
+	m.rateLimitedNum = uint32(float32(m.rateLimitedNum) / divideValue)
 }
 
 func (m *metric) stringify() []string {
@@ -49,6 +61,8 @@ func (m *metric) stringify() []string {
 		fmt.Sprintf("%d / %s/s", m.incomingRejectedNum, core.ConvertBytes(m.incomingRejectedSize)),
 		fmt.Sprintf("%d / %s/s", m.outgoingNum, core.ConvertBytes(m.outgoingSize)),
 		fmt.Sprintf("%d / %s/s", m.outgoingRejectedNum, core.ConvertBytes(m.outgoingRejectedSize)),
+		fmt.Sprintf("%s/s / %s/s", core.ConvertBytes(m.uncompressedSize), core.ConvertBytes(m.compressedSize)),
+		fmt.Sprintf("%d / %s/s", m.rateLimitedNum, core.ConvertBytes(m.rateLimitedSize)),
 	}
 }
 
@@ -122,6 +136,34 @@ func (pd *p2pDebugger) AddOutgoingMessage(topic string, size uint64, isRejected
 	}
 }
 
+// AddCompressedMessage adds the sizes of a compressed message, before and after compression, in metrics structs
+func (pd *p2pDebugger) AddCompressedMessage(topic string, uncompressedSize uint64, compressedSize uint64) {
+	if !pd.shouldProcessDataFn() {
+		return
+	}
+
+	pd.mut.Lock()
+	defer pd.mut.Unlock()
+
+	m := pd.getMetric(topic)
+	m.uncompressedSize += uncompressedSize
+	m.compressedSize += compressedSize
+}
+
+// AddRateLimitedMessage adds a new incoming message dropped by the rate limiter in metrics structs
+func (pd *p2pDebugger) AddRateLimitedMessage(topic string, size uint64) {
+	if !pd.shouldProcessDataFn() {
+		return
+	}
+
+	pd.mut.Lock()
+	defer pd.mut.Unlock()
+
+	m := pd.getMetric(topic)
+	m.rateLimitedNum++
+	m.rateLimitedSize += size
+}
+
 func (pd *p2pDebugger) getMetric(topic string) *metric {
 	m, ok := pd.data[topic]
 	if !ok {
@@ -160,6 +202,8 @@ func (pd *p2pDebugger) statsToString(divideSeconds float32) string {
 		"Incoming rejected (num / size)",
 		"Outgoing (num / size)",
 		"Outgoing rejected (num / size)",
+		"Compression (uncompressed / compressed)",
+		"Rate limited (num / size)",
 	}
 
 	pd.mut.Lock()
@@ -181,6 +225,10 @@ func (pd *p2pDebugger) statsToString(divideSeconds float32) string {
 		total.outgoingNum += m.outgoingNum
 		total.outgoingRejectedSize += m.outgoingRejectedSize
 		total.outgoingRejectedNum += m.outgoingRejectedNum
+		total.uncompressedSize += m.uncompressedSize
+		total.compressedSize += m.compressedSize
+		total.rateLimitedSize += m.rateLimitedSize
+		total.rateLimitedNum += m.rateLimitedNum
 	}
 
 	sort.Slice(metrics, func(i, j int) bool {
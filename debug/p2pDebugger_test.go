@@ -160,6 +160,51 @@ func TestP2pDebugger_AddOutgoingMessage(t *testing.T) {
 	assert.Equal(t, expectedMetric, m)
 }
 
+//------- AddCompressedMessage
+
+func TestP2pDebugger_AddCompressedMessage(t *testing.T) {
+	t.Parallel()
+
+	pd := newTestP2PDebugger(
+		"",
+		shouldCompute,
+		mockPrintFn,
+	)
+
+	topic := "topic"
+	pd.AddCompressedMessage(topic, 1000, 100)
+	pd.AddCompressedMessage(topic, 500, 200)
+
+	m := pd.GetClonedMetric(topic)
+	require.NotNil(t, m)
+	assert.Equal(t, uint64(1500), m.uncompressedSize)
+	assert.Equal(t, uint64(300), m.compressedSize)
+	assert.Equal(t, uint32(0), m.incomingNum)
+	assert.Equal(t, uint32(0), m.outgoingNum)
+}
+
+//------- AddRateLimitedMessage
+
+func TestP2pDebugger_AddRateLimitedMessage(t *testing.T) {
+	t.Parallel()
+
+	pd := newTestP2PDebugger(
+		"",
+		shouldCompute,
+		mockPrintFn,
+	)
+
+	topic := "topic"
+	pd.AddRateLimitedMessage(topic, 100)
+	pd.AddRateLimitedMessage(topic, 200)
+
+	m := pd.GetClonedMetric(topic)
+	require.NotNil(t, m)
+	assert.Equal(t, uint32(2), m.rateLimitedNum)
+	assert.Equal(t, uint64(300), m.rateLimitedSize)
+	assert.Equal(t, uint32(0), m.incomingNum)
+}
+
 //------- continuouslyPrintStatistics
 
 func TestP2pDebugger_continuouslyPrintStatisticsShouldNotPrint(t *testing.T) {
@@ -2,6 +2,8 @@ package p2p
 
 import (
 	"errors"
+
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
 )
 
 // ErrNilContext signals that a nil context was provided
@@ -22,12 +24,19 @@ var ErrChannelCanNotBeDeleted = errors.New("channel can not be deleted")
 // ErrChannelCanNotBeReAdded signals that a channel can not be re added as it is the default channel
 var ErrChannelCanNotBeReAdded = errors.New("channel can not be re added")
 
+// ErrChannelRemoved signals that a queued message was dropped because its channel was removed
+var ErrChannelRemoved = errors.New("channel removed")
+
 // ErrNilMessage signals that a nil message has been received
 var ErrNilMessage = errors.New("nil message")
 
 // ErrAlreadySeenMessage signals that the message has already been seen
 var ErrAlreadySeenMessage = errors.New("already seen this message")
 
+// ErrMessageIgnored signals that the message should be ignored without penalizing the peer that sent it.
+// Message processors can return (or wrap) this error when a message is not invalid but should not be propagated
+var ErrMessageIgnored = errors.New("message ignored")
+
 // ErrMessageTooNew signals that a message has a timestamp that is in the future relative to self
 var ErrMessageTooNew = errors.New("message is too new")
 
@@ -65,22 +74,22 @@ var ErrInvalidDurationProvided = errors.New("invalid time.Duration provided")
 var ErrTooManyGoroutines = errors.New(" number of goroutines exceeded")
 
 // ErrInvalidValue signals that an invalid value has been provided
-var ErrInvalidValue = errors.New("invalid value")
+var ErrInvalidValue = config.ErrInvalidValue
 
 // ErrInvalidPortValue signals that an invalid port value has been provided
-var ErrInvalidPortValue = errors.New("invalid port value")
+var ErrInvalidPortValue = config.ErrInvalidPortValue
 
 // ErrInvalidPortsRangeString signals that an invalid ports range string has been provided
-var ErrInvalidPortsRangeString = errors.New("invalid ports range string")
+var ErrInvalidPortsRangeString = config.ErrInvalidPortsRangeString
 
 // ErrInvalidStartingPortValue signals that an invalid starting port value has been provided
-var ErrInvalidStartingPortValue = errors.New("invalid starting port value")
+var ErrInvalidStartingPortValue = config.ErrInvalidStartingPortValue
 
 // ErrInvalidEndingPortValue signals that an invalid ending port value has been provided
-var ErrInvalidEndingPortValue = errors.New("invalid ending port value")
+var ErrInvalidEndingPortValue = config.ErrInvalidEndingPortValue
 
 // ErrEndPortIsSmallerThanStartPort signals that the ending port value is smaller than the starting port value
-var ErrEndPortIsSmallerThanStartPort = errors.New("ending port value is smaller than the starting port value")
+var ErrEndPortIsSmallerThanStartPort = config.ErrEndPortIsSmallerThanStartPort
 
 // ErrNoFreePortInRange signals that no free port was found from provided range
 var ErrNoFreePortInRange = errors.New("no free port in range")
@@ -117,6 +126,12 @@ var ErrUnsupportedFields = errors.New("unsupported fields")
 // ErrUnsupportedMessageVersion signals that an unsupported message version was detected
 var ErrUnsupportedMessageVersion = errors.New("unsupported message version")
 
+// ErrUnsupportedCompressionCodec signals that an unsupported compression codec was provided or detected
+var ErrUnsupportedCompressionCodec = errors.New("unsupported compression codec")
+
+// ErrDecompressionFailed signals that the payload of a received message could not be decompressed
+var ErrDecompressionFailed = errors.New("decompression failed")
+
 // ErrNilSyncTimer signals that a nil sync timer was provided
 var ErrNilSyncTimer = errors.New("nil sync timer")
 
@@ -160,16 +175,96 @@ var ErrNilP2PSigner = errors.New("nil p2p signer")
 var ErrNilPeerTopicNotifier = errors.New("nil peer topic notifier")
 
 // ErrInvalidTCPAddress signals that an invalid TCP address was used
-var ErrInvalidTCPAddress = errors.New("invalid TCP address")
+var ErrInvalidTCPAddress = config.ErrInvalidTCPAddress
 
 // ErrInvalidQUICAddress signals that an invalid QUIC address was used
-var ErrInvalidQUICAddress = errors.New("invalid QUIC address")
+var ErrInvalidQUICAddress = config.ErrInvalidQUICAddress
 
 // ErrInvalidWSAddress signals that an invalid WebSocket address was used
-var ErrInvalidWSAddress = errors.New("invalid WebSocket address")
+var ErrInvalidWSAddress = config.ErrInvalidWSAddress
 
 // ErrInvalidWebTransportAddress signals that an invalid WebTransport address was used
-var ErrInvalidWebTransportAddress = errors.New("invalid WebTransport address")
+var ErrInvalidWebTransportAddress = config.ErrInvalidWebTransportAddress
 
 // ErrNoTransportsDefined signals that no transports were defined
-var ErrNoTransportsDefined = errors.New("no transports defined")
+var ErrNoTransportsDefined = config.ErrNoTransportsDefined
+
+// ErrNilRequestResponder signals that a nil request responder has been provided
+var ErrNilRequestResponder = errors.New("nil request responder")
+
+// ErrRequestResponderAlreadyDefined signals that a request responder was already defined on the provided topic
+var ErrRequestResponderAlreadyDefined = errors.New("request responder already defined")
+
+// ErrRequestTimeout signals that the response for a request was not received in time
+var ErrRequestTimeout = errors.New("request timeout")
+
+// ErrRequestFailed signals that the remote peer could not respond to the request
+var ErrRequestFailed = errors.New("request failed on the remote peer")
+
+// ErrInvalidResponse signals that an invalid response has been received
+var ErrInvalidResponse = errors.New("invalid response")
+
+// ErrNilDebugger signals that a nil debugger has been provided
+var ErrNilDebugger = errors.New("nil debugger")
+
+// ErrNilInboundRateLimitChecker signals that a nil inbound rate limit checker has been provided
+var ErrNilInboundRateLimitChecker = errors.New("nil inbound rate limit checker")
+
+// ErrInvalidChunk signals that an invalid chunk of a large payload has been received
+var ErrInvalidChunk = errors.New("invalid chunk")
+
+// ErrLargePayloadsMemoryLimitReached signals that a new large payload transfer can not be accepted as the
+// maximum memory allocated for the pending transfers was reached
+var ErrLargePayloadsMemoryLimitReached = errors.New("large payloads memory limit reached")
+
+// ErrLargePayloadsPeerLimitReached signals that a new large payload transfer can not be accepted as the peer
+// already has too many pending transfers or too many pending bytes
+var ErrLargePayloadsPeerLimitReached = errors.New("large payloads peer limit reached")
+
+// ErrRateLimitExceeded signals that a peer sent more messages than allowed on a topic
+var ErrRateLimitExceeded = errors.New("rate limit exceeded")
+
+// ErrMessengerClosed signals that the messenger was closed
+var ErrMessengerClosed = errors.New("messenger closed")
+
+// ErrUnknownTopic signals that the topic was not created
+var ErrUnknownTopic = errors.New("unknown topic")
+
+// ErrSendQueueFull signals that the message was dropped because the send queue of the channel is full
+var ErrSendQueueFull = errors.New("send queue full")
+
+// ErrMessageCreationFailed signals that the message could not be created from the provided payload
+var ErrMessageCreationFailed = errors.New("message creation failed")
+
+// ErrNilEventsNotifier signals that a nil events notifier has been provided
+var ErrNilEventsNotifier = errors.New("nil events notifier")
+
+// ErrEventsSubscriptionNotFound signals that the events subscription does not exist
+var ErrEventsSubscriptionNotFound = errors.New("events subscription not found")
+
+// ErrNonReloadableConfigField signals that a configuration field that can not be changed at runtime was modified
+var ErrNonReloadableConfigField = errors.New("configuration field can not be changed at runtime")
+
+// ErrInvalidProtocolID signals that an invalid protocol ID has been provided
+var ErrInvalidProtocolID = config.ErrInvalidProtocolID
+
+// ErrInvalidConfig signals that the configuration is not valid
+var ErrInvalidConfig = config.ErrInvalidConfig
+
+// ErrNilRelayedConnectionsChecker signals that a nil relayed connections checker has been provided
+var ErrNilRelayedConnectionsChecker = errors.New("nil relayed connections checker")
+
+// ErrNilPeersIPResolver signals that a nil peers IP resolver has been provided
+var ErrNilPeersIPResolver = errors.New("nil peers IP resolver")
+
+// ErrNilPeersLatencyProvider signals that a nil peers latency provider has been provided
+var ErrNilPeersLatencyProvider = errors.New("nil peers latency provider")
+
+// ErrNilRoutingDiscovery signals that a nil routing discovery has been provided
+var ErrNilRoutingDiscovery = errors.New("nil routing discovery")
+
+// ErrNilPersister signals that a nil persister has been provided
+var ErrNilPersister = errors.New("nil persister")
+
+// ErrNilPeerstore signals that a nil peerstore has been provided
+var ErrNilPeerstore = errors.New("nil peerstore")
@@ -0,0 +1,75 @@
+package p2p
+
+import (
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+)
+
+// EventType defines the kind of network event delivered to the subscribers
+type EventType uint32
+
+const (
+	// PeerConnectedEvent signals that the first connection with a peer was opened
+	PeerConnectedEvent EventType = iota
+	// PeerDisconnectedEvent signals that the last connection with a peer was closed
+	PeerDisconnectedEvent
+	// PeerJoinedTopicEvent signals that a peer joined one of the topics created by the messenger
+	PeerJoinedTopicEvent
+	// PeerLeftTopicEvent signals that a peer left one of the topics created by the messenger
+	PeerLeftTopicEvent
+	// PeerDeniedEvent signals that the connection with a denied peer was closed
+	PeerDeniedEvent
+	// PeerEvictedEvent signals that the connection with a peer was closed by the sharder
+	PeerEvictedEvent
+	// ReconnectTriggeredEvent signals that the node tries to reconnect to the network
+	ReconnectTriggeredEvent
+	// ReachabilityChangedEvent signals that AutoNAT detected a change of the node's reachability
+	ReachabilityChangedEvent
+)
+
+// String returns the human-readable form of the event type
+func (et EventType) String() string {
+	switch et {
+	case PeerConnectedEvent:
+		return "peer connected"
+	case PeerDisconnectedEvent:
+		return "peer disconnected"
+	case PeerJoinedTopicEvent:
+		return "peer joined topic"
+	case PeerLeftTopicEvent:
+		return "peer left topic"
+	case PeerDeniedEvent:
+		return "peer denied"
+	case PeerEvictedEvent:
+		return "peer evicted"
+	case ReconnectTriggeredEvent:
+		return "reconnect triggered"
+	case ReachabilityChangedEvent:
+		return "reachability changed"
+	default:
+		return "unknown"
+	}
+}
+
+// Event holds the information about a network event. The Peer field is empty for the ReconnectTriggeredEvent
+// and the ReachabilityChangedEvent, the Topic field is set only for the topic membership events and the
+// Reachability field is set only for the ReachabilityChangedEvent
+type Event struct {
+	Type         EventType
+	Peer         core.PeerID
+	Topic        string
+	Reachability Reachability
+	Timestamp    time.Time
+}
+
+// EventFilter selects the events delivered to a subscriber
+type EventFilter struct {
+	// Types contains the accepted event types. Empty means all the event types
+	Types []EventType
+	// Topics contains the accepted topics for the topic membership events. Empty means all the topics
+	Topics []string
+	// BufferSize is the number of undelivered events kept for the subscriber. The events are dropped when the buffer
+	// is full so the subscriber can not block the messenger. 0 means the default value
+	BufferSize int
+}
@@ -4,20 +4,32 @@ import (
 	"context"
 	"encoding/hex"
 	"io"
+	"net"
 	"time"
 
 	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
 )
 
 // MessageProcessor is the interface used to describe what a receive message processor should do
 
 // All implementations that will be called from Messenger implementation will need to satisfy this interface
-// If the function returns a non nil value, the received message will not be propagated to its connected peers
+// If the function returns a non nil value, the received message will not be propagated to its connected peers.
+// Returning an error that wraps ErrMessageIgnored (or ErrAlreadySeenMessage) will only drop the message, any other
+// error will reject it and penalize the peer that sent it
 type MessageProcessor interface {
 	ProcessReceivedMessage(message MessageP2P, fromConnectedPeer core.PeerID) error
 	IsInterfaceNil() bool
 }
 
+// RequestResponder is the interface used to describe what a component that answers requests should do
+// The returned buffer will be sent back to the requesting peer. If the function returns a non nil error, the
+// requesting peer will receive the error instead of a response
+type RequestResponder interface {
+	ProcessRequest(topic string, request []byte, fromConnectedPeer core.PeerID) ([]byte, error)
+	IsInterfaceNil() bool
+}
+
 // PeerDiscoverer defines the behaviour of a peer discovery mechanism
 type PeerDiscoverer interface {
 	Bootstrap() error
@@ -35,6 +47,12 @@ type Reconnecter interface {
 type Messenger interface {
 	io.Closer
 
+	// CloseWithTimeout stops accepting new broadcasts, publishes the messages
+	// still queued in the LoadBalancer for at most the provided duration and
+	// then closes the Messenger. The messages not published until the timeout
+	// are discarded.
+	CloseWithTimeout(timeout time.Duration) (DrainStats, error)
+
 	// ID is the Messenger's unique peer identifier across the network (a
 	// string). It is derived from the public key of the P2P credentials.
 	ID() core.PeerID
@@ -43,9 +61,31 @@ type Messenger interface {
 	Peers() []core.PeerID
 
 	// Addresses is the list of addresses that the Messenger is currently bound
-	// to and listening to.
+	// to and listening to, as announced to the other peers.
 	Addresses() []string
 
+	// Reachability returns whether the Messenger can be dialed by the other
+	// peers, as detected by AutoNAT. The changes are delivered as
+	// ReachabilityChangedEvent events.
+	Reachability() Reachability
+
+	// SeedersStatus returns, for each configured seeder, whether the Messenger
+	// is connected to it, the connection attempts counters, the last error and
+	// the time of the next attempt if the seeder is backing off.
+	SeedersStatus() []SeederStatus
+
+	// ExplainEviction returns, for each connected peer, the sharder's
+	// category, the computed distance, the category's quota and whether the
+	// peer would be kept or evicted on the next connections trimming, and why.
+	// Nothing is evicted. It returns an empty slice if the sharder is not able
+	// to explain its decisions.
+	ExplainEviction() []PeerEvictionExplanation
+
+	// ResourceUsage returns the resources currently used in the system and
+	// transient scopes of the libp2p resource manager and by each of the
+	// limited protocols: the direct send, the pubsub and the kad DHT ones.
+	ResourceUsage() ResourceUsage
+
 	// ConnectToPeer explicitly connect to a specific peer with a known address (note that the
 	// address contains the peer ID). This function is usually not called
 	// manually, because any underlying implementation of the Messenger interface
@@ -83,6 +123,13 @@ type Messenger interface {
 	// will use a default channel).
 	CreateTopic(name string, createChannelForTopic bool) error
 
+	// CreateTopicWithOptions defines a new topic for sending messages using the provided options
+	CreateTopicWithOptions(name string, options TopicOptions) error
+
+	// OutgoingChannelsStats returns the queue depth and the dropped messages
+	// counter for each send channel of the LoadBalancer.
+	OutgoingChannelsStats() []ChannelStats
+
 	// HasTopic returns true if the Messenger has declared interest in a topic
 	// and it is listening to messages referencing it.
 	HasTopic(name string) bool
@@ -104,13 +151,28 @@ type Messenger interface {
 
 	// BroadcastOnChannelBlocking asynchronously waits until it can send a
 	// message on the channel, but once it is able to, it synchronously sends the
-	// message, blocking until sending is completed.
+	// message, blocking until sending is completed. It also waits while the
+	// channel queue is full, so the message is never dropped, regardless of
+	// the channel drop policy.
 	BroadcastOnChannelBlocking(channel string, topic string, buff []byte) error
 
 	// BroadcastOnChannel asynchronously sends a message on a given topic
 	// through a specified channel.
 	BroadcastOnChannel(channel string, topic string, buff []byte)
 
+	// BroadcastContext queues a message to be sent on a given topic through a
+	// specified channel without blocking. It returns an error if the topic is
+	// unknown, the payload is invalid, the messenger is closed or the channel
+	// queue is full. A queued message is dropped if the context is done
+	// before the message is published.
+	BroadcastContext(ctx context.Context, channel string, topic string, buff []byte) error
+
+	// BroadcastContextWithResult is the same as BroadcastContext but it also
+	// calls the provided handler with the result of the publishing, after the
+	// message has been queued. The handler is called from the messenger's send
+	// go routine so it should not block.
+	BroadcastContextWithResult(ctx context.Context, channel string, topic string, buff []byte, resultHandler func(err error)) error
+
 	// BroadcastUsingPrivateKey tries to send a byte buffer onto a topic using the topic name as channel
 	BroadcastUsingPrivateKey(topic string, buff []byte, pid core.PeerID, skBytes []byte)
 
@@ -123,6 +185,18 @@ type Messenger interface {
 	// peer, but reuses a connection and a stream if possible.
 	SendToConnectedPeer(topic string, buff []byte, peerID core.PeerID) error
 
+	// SendRequest sends a request to a connected peer and blocks until the
+	// response arrives, the provided context is done or the request times out.
+	SendRequest(ctx context.Context, topic string, buff []byte, peerID core.PeerID) ([]byte, error)
+
+	// RegisterRequestResponder sets the RequestResponder that will answer
+	// the requests received on the specified topic.
+	RegisterRequestResponder(topic string, responder RequestResponder) error
+
+	// UnregisterRequestResponder removes the RequestResponder set for the
+	// given topic.
+	UnregisterRequestResponder(topic string) error
+
 	IsConnectedToTheNetwork() bool
 	ThresholdMinConnectedPeers() int
 	SetThresholdMinConnectedPeers(minConnectedPeers int) error
@@ -137,6 +211,23 @@ type Messenger interface {
 	SignUsingPrivateKey(skBytes []byte, payload []byte) ([]byte, error)
 	AddPeerTopicNotifier(notifier PeerTopicNotifier) error
 
+	// SubscribeEvents returns a channel on which the network events matching
+	// the provided filter are delivered. The events are dropped if the
+	// subscriber does not keep up. The channel is closed on unsubscribe or
+	// when the Messenger is closed.
+	SubscribeEvents(filter EventFilter) <-chan Event
+
+	// UnsubscribeEvents stops the delivery of the events on the provided
+	// channel and closes it.
+	UnsubscribeEvents(events <-chan Event) error
+
+	// ApplyConfig validates the provided configuration and applies, without
+	// a restart, the sharding limits, the minimum connected peers threshold
+	// and the peers discovery refresh interval. The connections that do not
+	// fit in the new limits are closed. It errors if any other field differs
+	// from the configuration currently in use.
+	ApplyConfig(p2pConfig config.P2PConfig) error
+
 	// IsInterfaceNil returns true if there is no value under the interface
 	IsInterfaceNil() bool
 }
@@ -198,6 +289,24 @@ type PeerShardResolver interface {
 	IsInterfaceNil() bool
 }
 
+// RelayedConnectionsChecker is able to tell if a peer is connected only through circuit relays
+type RelayedConnectionsChecker interface {
+	IsRelayed(pid core.PeerID) bool
+	IsInterfaceNil() bool
+}
+
+// PeersIPResolver is able to tell the IP a connected peer is connected from
+type PeersIPResolver interface {
+	GetPeerIP(pid core.PeerID) (net.IP, bool)
+	IsInterfaceNil() bool
+}
+
+// PeersLatencyProvider is able to tell the latency measured with a connected peer
+type PeersLatencyProvider interface {
+	GetPeerLatency(pid core.PeerID) (time.Duration, bool)
+	IsInterfaceNil() bool
+}
+
 // ConnectedPeersInfo represents the DTO structure used to output the metrics for connected peers
 type ConnectedPeersInfo struct {
 	SelfShardID              uint32
@@ -216,6 +325,48 @@ type ConnectedPeersInfo struct {
 	NumCrossShardValidators  int
 	NumCrossShardObservers   int
 	NumFullHistoryObservers  int
+	// RelayedPeers contains the peers connected only through circuit relays. These peers are also part of the
+	// lists above
+	RelayedPeers    []string
+	NumRelayedPeers int
+}
+
+// DrainStats represents the DTO structure used to output the result of draining the outgoing queues on close
+type DrainStats struct {
+	NumFlushed   int
+	NumDiscarded int
+}
+
+// SeederStatus represents the DTO structure used to output the connection health of a seeder
+type SeederStatus struct {
+	Address             string
+	IsConnected         bool
+	IsBackingOff        bool
+	NumSuccesses        uint64
+	NumFailures         uint64
+	ConsecutiveFailures uint32
+	LastError           string
+	LastAttempt         time.Time
+	LastSuccess         time.Time
+	NextAttempt         time.Time
+}
+
+// ResourceScopeUsage represents the DTO structure used to output the resources used in a resource manager scope
+type ResourceScopeUsage struct {
+	NumStreamsInbound  int
+	NumStreamsOutbound int
+	NumConnsInbound    int
+	NumConnsOutbound   int
+	NumFD              int
+	Memory             int64
+}
+
+// ResourceUsage represents the DTO structure used to output the current usage of the libp2p resource manager. The
+// Protocols map is keyed by the protocol ID
+type ResourceUsage struct {
+	System    ResourceScopeUsage
+	Transient ResourceScopeUsage
+	Protocols map[string]ResourceScopeUsage
 }
 
 // NetworkShardingCollector defines the updating methods used by the network sharding component
@@ -274,6 +425,16 @@ type PeerDenialEvaluator interface {
 	IsInterfaceNil() bool
 }
 
+// IPDenialEvaluator extends the PeerDenialEvaluator with the denial of IP addresses and of their subnets, so the
+// peers rotating their IDs from the same host can be kept away
+type IPDenialEvaluator interface {
+	PeerDenialEvaluator
+	IsIPDenied(ip net.IP) bool
+	IsConnectionDenied(pid core.PeerID, ip net.IP) bool
+	UpsertIP(ip net.IP, duration time.Duration) error
+	UpsertSubnet(ip net.IP, duration time.Duration) error
+}
+
 // ConnectionMonitorWrapper uses a connection monitor but checks if the peer is blacklisted or not
 // TODO this should be removed after merging of the PeerShardResolver and BlacklistHandler
 type ConnectionMonitorWrapper interface {
@@ -287,6 +448,8 @@ type ConnectionMonitorWrapper interface {
 type Debugger interface {
 	AddIncomingMessage(topic string, size uint64, isRejected bool)
 	AddOutgoingMessage(topic string, size uint64, isRejected bool)
+	AddCompressedMessage(topic string, uncompressedSize uint64, compressedSize uint64)
+	AddRateLimitedMessage(topic string, size uint64)
 	Close() error
 	IsInterfaceNil() bool
 }
@@ -313,6 +476,18 @@ type PeersRatingHandler interface {
 	IsInterfaceNil() bool
 }
 
+// MeshScoresHandler represent an entity able to use the gossipsub mesh scores of the connected peers. A
+// PeersRatingHandler can optionally implement it in order to receive the scores
+type MeshScoresHandler interface {
+	UpdateMeshScores(scores map[core.PeerID]float64)
+}
+
+// EventsNotifier represents an entity able to dispatch the network events to the subscribers
+type EventsNotifier interface {
+	NotifyEvent(event Event)
+	IsInterfaceNil() bool
+}
+
 // PeerTopicNotifier represent an entity able to handle new notifications on a new peer on a topic
 type PeerTopicNotifier interface {
 	NewPeerFound(pid core.PeerID, topic string)
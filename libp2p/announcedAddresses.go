@@ -0,0 +1,109 @@
+package libp2p
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	basichost "github.com/libp2p/go-libp2p/p2p/host/basic"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// createAddressesFactory creates the function used by the host to compute the advertised addresses out of the
+// listen addresses. If the port is 0, the %d markup of the announced addresses is replaced with the port chosen by the
+// operating system for the first listen address
+func createAddressesFactory(announcedConfig config.AnnouncedAddressesConfig, port int) (basichost.AddrsFactory, error) {
+	announcedAddresses, err := parseAnnouncedAddresses(announcedConfig.Addresses, port)
+	if err != nil {
+		return nil, err
+	}
+
+	deniedRanges := multiaddr.NewFilters()
+	for _, cidr := range announcedConfig.DenyCIDRs {
+		_, ipNet, errParse := net.ParseCIDR(cidr)
+		if errParse != nil {
+			return nil, fmt.Errorf("%w for denied CIDR %s: %s", p2p.ErrInvalidValue, cidr, errParse.Error())
+		}
+
+		deniedRanges.AddFilter(*ipNet, multiaddr.ActionDeny)
+	}
+
+	isAnnounceable := func(addr multiaddr.Multiaddr) bool {
+		if announcedConfig.DenyPrivateAddresses && manet.IsPrivateAddr(addr) {
+			return false
+		}
+
+		return !deniedRanges.AddrBlocked(addr)
+	}
+
+	return func(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
+		if port == 0 && len(announcedAddresses) > 0 {
+			addrs = resolveAnnouncedAddresses(announcedConfig.Addresses, addrs)
+		} else if len(announcedAddresses) > 0 {
+			addrs = announcedAddresses
+		}
+
+		return multiaddr.FilterAddrs(addrs, isAnnounceable)
+	}, nil
+}
+
+func parseAnnouncedAddresses(addresses []string, port int) ([]multiaddr.Multiaddr, error) {
+	announcedAddresses := make([]multiaddr.Multiaddr, 0, len(addresses))
+	for _, address := range addresses {
+		addr, err := parseAnnouncedAddress(address, port)
+		if err != nil {
+			return nil, fmt.Errorf("%w for announced address %s: %s", p2p.ErrInvalidValue, address, err.Error())
+		}
+
+		announcedAddresses = append(announcedAddresses, addr)
+	}
+
+	return announcedAddresses, nil
+}
+
+func parseAnnouncedAddress(address string, port int) (multiaddr.Multiaddr, error) {
+	if strictCheckStringForIntMarkup(address) {
+		address = fmt.Sprintf(address, port)
+	}
+
+	return multiaddr.NewMultiaddr(address)
+}
+
+// resolveAnnouncedAddresses replaces the %d markup with the port of the first listen address
+func resolveAnnouncedAddresses(addresses []string, listenAddrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
+	port := 0
+	for _, addr := range listenAddrs {
+		port = getAddressPort(addr)
+		if port != 0 {
+			break
+		}
+	}
+
+	resolved := make([]multiaddr.Multiaddr, 0, len(addresses))
+	for _, address := range addresses {
+		// the addresses were already checked when the factory was created
+		addr, _ := parseAnnouncedAddress(address, port)
+		resolved = append(resolved, addr)
+	}
+
+	return resolved
+}
+
+func getAddressPort(addr multiaddr.Multiaddr) int {
+	for _, protocol := range []int{multiaddr.P_TCP, multiaddr.P_UDP} {
+		value, err := addr.ValueForProtocol(protocol)
+		if err != nil {
+			continue
+		}
+
+		port, err := strconv.Atoi(value)
+		if err == nil {
+			return port
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,91 @@
+package libp2p_test
+
+import (
+	"errors"
+	"testing"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createListenAddresses() []multiaddr.Multiaddr {
+	return []multiaddr.Multiaddr{
+		multiaddr.StringCast("/ip4/127.0.0.1/tcp/37373"),
+		multiaddr.StringCast("/ip4/172.17.0.2/tcp/37373"),
+		multiaddr.StringCast("/ip4/35.1.2.3/tcp/37373"),
+	}
+}
+
+func TestCreateAddressesFactory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid announced address should error", func(t *testing.T) {
+		t.Parallel()
+
+		factory, err := libp2p.CreateAddressesFactory(config.AnnouncedAddressesConfig{
+			Addresses: []string{"invalid address"},
+		}, 37373)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Nil(t, factory)
+	})
+	t.Run("invalid denied CIDR should error", func(t *testing.T) {
+		t.Parallel()
+
+		factory, err := libp2p.CreateAddressesFactory(config.AnnouncedAddressesConfig{
+			DenyCIDRs: []string{"35.0.0.0"},
+		}, 37373)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Nil(t, factory)
+	})
+	t.Run("empty config should announce the listen addresses", func(t *testing.T) {
+		t.Parallel()
+
+		factory, err := libp2p.CreateAddressesFactory(config.AnnouncedAddressesConfig{}, 37373)
+		require.Nil(t, err)
+		assert.Equal(t, createListenAddresses(), factory(createListenAddresses()))
+	})
+	t.Run("announced addresses should replace the listen addresses", func(t *testing.T) {
+		t.Parallel()
+
+		factory, err := libp2p.CreateAddressesFactory(config.AnnouncedAddressesConfig{
+			Addresses: []string{"/ip4/35.4.5.6/tcp/%d", "/dns4/seeder.example.com/tcp/10000"},
+		}, 37373)
+		require.Nil(t, err)
+
+		expected := []multiaddr.Multiaddr{
+			multiaddr.StringCast("/ip4/35.4.5.6/tcp/37373"),
+			multiaddr.StringCast("/dns4/seeder.example.com/tcp/10000"),
+		}
+		assert.Equal(t, expected, factory(createListenAddresses()))
+	})
+	t.Run("random port should be resolved from the listen addresses", func(t *testing.T) {
+		t.Parallel()
+
+		factory, err := libp2p.CreateAddressesFactory(config.AnnouncedAddressesConfig{
+			Addresses: []string{"/ip4/35.4.5.6/tcp/%d"},
+		}, 0)
+		require.Nil(t, err)
+
+		expected := []multiaddr.Multiaddr{multiaddr.StringCast("/ip4/35.4.5.6/tcp/37373")}
+		assert.Equal(t, expected, factory(createListenAddresses()))
+	})
+	t.Run("filters should remove the denied addresses", func(t *testing.T) {
+		t.Parallel()
+
+		factory, err := libp2p.CreateAddressesFactory(config.AnnouncedAddressesConfig{
+			DenyPrivateAddresses: true,
+		}, 37373)
+		require.Nil(t, err)
+		assert.Equal(t, []multiaddr.Multiaddr{multiaddr.StringCast("/ip4/35.1.2.3/tcp/37373")}, factory(createListenAddresses()))
+
+		factory, err = libp2p.CreateAddressesFactory(config.AnnouncedAddressesConfig{
+			DenyCIDRs: []string{"35.0.0.0/8", "172.16.0.0/12"},
+		}, 37373)
+		require.Nil(t, err)
+		assert.Equal(t, []multiaddr.Multiaddr{multiaddr.StringCast("/ip4/127.0.0.1/tcp/37373")}, factory(createListenAddresses()))
+	})
+}
@@ -0,0 +1,289 @@
+package libp2p
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	"github.com/TerraDharitri/drt-go-chain-p2p/data"
+)
+
+const (
+	// largePayloadChunkTopic is the topic used on the direct send protocol for the chunks of a large payload
+	largePayloadChunkTopic            = "largePayloadChunk"
+	chunkHeaderSize                   = 1 << 10 // 1 kB
+	defaultMaxPendingTransfersPerPeer = 8
+)
+
+var maxChunkPayloadSize = maxSendBuffSize - chunkHeaderSize
+
+type pendingTransfer struct {
+	from         core.PeerID
+	topic        string
+	numChunks    uint32
+	totalSize    uint64
+	totalHash    []byte
+	chunks       map[uint32][]byte
+	receivedSize uint64
+	startTime    time.Time
+}
+
+type peerPendingTransfers struct {
+	numTransfers uint32
+	pendingSize  uint64
+}
+
+type chunksAssembler struct {
+	mut                        sync.Mutex
+	transfers                  map[string]*pendingTransfer
+	peersTransfers             map[core.PeerID]*peerPendingTransfers
+	pendingSize                uint64
+	maxPayloadSize             uint64
+	maxPendingSize             uint64
+	maxPendingSizePerPeer      uint64
+	maxPendingTransfersPerPeer uint32
+	timeout                    time.Duration
+	getTimeHandler             func() time.Time
+}
+
+// newChunksAssembler creates a component able to reassemble the large payloads received in chunks
+func newChunksAssembler(cfg config.LargePayloadsConfig) (*chunksAssembler, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MaxPayloadSizeInBytes <= uint64(maxSendBuffSize) {
+		return nil, fmt.Errorf("%w for the maximum large payload size, minimum %d, got %d",
+			p2p.ErrInvalidValue, maxSendBuffSize+1, cfg.MaxPayloadSizeInBytes)
+	}
+
+	maxPendingSizePerPeer := cfg.MaxPendingSizePerPeerInBytes
+	if maxPendingSizePerPeer == 0 {
+		maxPendingSizePerPeer = cfg.MaxPayloadSizeInBytes
+	}
+	maxPendingTransfersPerPeer := cfg.MaxPendingTransfersPerPeer
+	if maxPendingTransfersPerPeer == 0 {
+		maxPendingTransfersPerPeer = defaultMaxPendingTransfersPerPeer
+	}
+
+	return &chunksAssembler{
+		transfers:                  make(map[string]*pendingTransfer),
+		peersTransfers:             make(map[core.PeerID]*peerPendingTransfers),
+		maxPayloadSize:             cfg.MaxPayloadSizeInBytes,
+		maxPendingSize:             cfg.MaxPendingSizeInBytes,
+		maxPendingSizePerPeer:      maxPendingSizePerPeer,
+		maxPendingTransfersPerPeer: maxPendingTransfersPerPeer,
+		timeout:                    time.Duration(cfg.TransferTimeoutInSec) * time.Second,
+		getTimeHandler:             time.Now,
+	}, nil
+}
+
+// splitInChunks splits the provided buffer in chunks that can be sent as regular direct messages
+func splitInChunks(transferID uint64, topic string, buff []byte, chunkSize int) []*data.ChunkMessage {
+	totalHash := sha256.Sum256(buff)
+	numChunks := (len(buff) + chunkSize - 1) / chunkSize
+
+	chunks := make([]*data.ChunkMessage, 0, numChunks)
+	for i := 0; i < numChunks; i++ {
+		end := (i + 1) * chunkSize
+		if end > len(buff) {
+			end = len(buff)
+		}
+
+		chunks = append(chunks, &data.ChunkMessage{
+			TransferID: transferID,
+			Topic:      topic,
+			ChunkIndex: uint32(i),
+			NumChunks:  uint32(numChunks),
+			TotalSize:  uint64(len(buff)),
+			TotalHash:  totalHash[:],
+			Payload:    buff[i*chunkSize : end],
+		})
+	}
+
+	return chunks
+}
+
+// addChunk stores the provided chunk and returns the reassembled payload when all the chunks of a transfer
+// have been received. It returns nil, nil if the transfer is still in progress
+func (ca *chunksAssembler) addChunk(chunk *data.ChunkMessage, from core.PeerID) ([]byte, error) {
+	ca.mut.Lock()
+	defer ca.mut.Unlock()
+
+	ca.removeExpiredTransfers()
+
+	key := fmt.Sprintf("%s_%d", from, chunk.TransferID)
+	transfer, found := ca.transfers[key]
+	if !found {
+		var err error
+		transfer, err = ca.createTransfer(chunk, from)
+		if err != nil {
+			return nil, err
+		}
+
+		ca.addTransfer(key, transfer)
+	}
+
+	err := checkChunkAgainstTransfer(chunk, transfer)
+	if err != nil {
+		ca.removeTransfer(key)
+		return nil, err
+	}
+
+	_, isDuplicate := transfer.chunks[chunk.ChunkIndex]
+	if isDuplicate {
+		return nil, nil
+	}
+
+	transfer.chunks[chunk.ChunkIndex] = chunk.Payload
+	transfer.receivedSize += uint64(len(chunk.Payload))
+	if transfer.receivedSize > transfer.totalSize {
+		ca.removeTransfer(key)
+		return nil, fmt.Errorf("%w, received more bytes than declared for topic %s", p2p.ErrInvalidChunk, transfer.topic)
+	}
+	if uint32(len(transfer.chunks)) < transfer.numChunks {
+		return nil, nil
+	}
+
+	ca.removeTransfer(key)
+
+	return assemblePayload(transfer)
+}
+
+func (ca *chunksAssembler) createTransfer(chunk *data.ChunkMessage, from core.PeerID) (*pendingTransfer, error) {
+	if chunk.NumChunks == 0 || chunk.ChunkIndex >= chunk.NumChunks {
+		return nil, fmt.Errorf("%w, chunk index %d, num chunks %d", p2p.ErrInvalidChunk, chunk.ChunkIndex, chunk.NumChunks)
+	}
+	if chunk.TotalSize == 0 || chunk.TotalSize > ca.maxPayloadSize {
+		return nil, fmt.Errorf("%w, total size %d, maximum %d", p2p.ErrInvalidChunk, chunk.TotalSize, ca.maxPayloadSize)
+	}
+	if uint64(chunk.NumChunks) > chunk.TotalSize {
+		return nil, fmt.Errorf("%w, num chunks %d is larger than the total size %d", p2p.ErrInvalidChunk, chunk.NumChunks, chunk.TotalSize)
+	}
+	if len(chunk.TotalHash) != sha256.Size {
+		return nil, fmt.Errorf("%w, invalid total hash length %d", p2p.ErrInvalidChunk, len(chunk.TotalHash))
+	}
+	if ca.pendingSize+chunk.TotalSize > ca.maxPendingSize {
+		return nil, fmt.Errorf("%w, pending %d, new transfer %d, maximum %d",
+			p2p.ErrLargePayloadsMemoryLimitReached, ca.pendingSize, chunk.TotalSize, ca.maxPendingSize)
+	}
+	err := ca.checkPeerLimits(chunk, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pendingTransfer{
+		from:      from,
+		topic:     chunk.Topic,
+		numChunks: chunk.NumChunks,
+		totalSize: chunk.TotalSize,
+		totalHash: chunk.TotalHash,
+		chunks:    make(map[uint32][]byte),
+		startTime: ca.getTimeHandler(),
+	}, nil
+}
+
+func (ca *chunksAssembler) checkPeerLimits(chunk *data.ChunkMessage, from core.PeerID) error {
+	peerTransfers, found := ca.peersTransfers[from]
+	if !found {
+		return nil
+	}
+	if peerTransfers.numTransfers >= ca.maxPendingTransfersPerPeer {
+		return fmt.Errorf("%w, peer %s has %d pending transfers, maximum %d",
+			p2p.ErrLargePayloadsPeerLimitReached, from.Pretty(), peerTransfers.numTransfers, ca.maxPendingTransfersPerPeer)
+	}
+	if peerTransfers.pendingSize+chunk.TotalSize > ca.maxPendingSizePerPeer {
+		return fmt.Errorf("%w, peer %s pending %d, new transfer %d, maximum %d",
+			p2p.ErrLargePayloadsPeerLimitReached, from.Pretty(), peerTransfers.pendingSize, chunk.TotalSize, ca.maxPendingSizePerPeer)
+	}
+
+	return nil
+}
+
+func checkChunkAgainstTransfer(chunk *data.ChunkMessage, transfer *pendingTransfer) error {
+	isSameTransfer := chunk.Topic == transfer.topic &&
+		chunk.NumChunks == transfer.numChunks &&
+		chunk.TotalSize == transfer.totalSize &&
+		bytes.Equal(chunk.TotalHash, transfer.totalHash)
+	if !isSameTransfer {
+		return fmt.Errorf("%w, chunk does not match the transfer %d", p2p.ErrInvalidChunk, chunk.TransferID)
+	}
+	if chunk.ChunkIndex >= transfer.numChunks {
+		return fmt.Errorf("%w, chunk index %d, num chunks %d", p2p.ErrInvalidChunk, chunk.ChunkIndex, transfer.numChunks)
+	}
+	if len(chunk.Payload) == 0 {
+		return fmt.Errorf("%w, empty chunk payload", p2p.ErrInvalidChunk)
+	}
+
+	return nil
+}
+
+func assemblePayload(transfer *pendingTransfer) ([]byte, error) {
+	payload := make([]byte, 0, transfer.totalSize)
+	for i := uint32(0); i < transfer.numChunks; i++ {
+		payload = append(payload, transfer.chunks[i]...)
+	}
+
+	if uint64(len(payload)) != transfer.totalSize {
+		return nil, fmt.Errorf("%w, assembled size %d, declared size %d", p2p.ErrInvalidChunk, len(payload), transfer.totalSize)
+	}
+
+	hash := sha256.Sum256(payload)
+	if !bytes.Equal(hash[:], transfer.totalHash) {
+		return nil, fmt.Errorf("%w, hash mismatch for topic %s", p2p.ErrInvalidChunk, transfer.topic)
+	}
+
+	return payload, nil
+}
+
+func (ca *chunksAssembler) removeExpiredTransfers() {
+	now := ca.getTimeHandler()
+	for key, transfer := range ca.transfers {
+		if now.Sub(transfer.startTime) > ca.timeout {
+			log.Trace("large payload transfer timed out",
+				"topic", transfer.topic,
+				"received chunks", len(transfer.chunks),
+				"num chunks", transfer.numChunks,
+			)
+			ca.removeTransfer(key)
+		}
+	}
+}
+
+func (ca *chunksAssembler) addTransfer(key string, transfer *pendingTransfer) {
+	ca.transfers[key] = transfer
+	ca.pendingSize += transfer.totalSize
+
+	peerTransfers, found := ca.peersTransfers[transfer.from]
+	if !found {
+		peerTransfers = &peerPendingTransfers{}
+		ca.peersTransfers[transfer.from] = peerTransfers
+	}
+	peerTransfers.numTransfers++
+	peerTransfers.pendingSize += transfer.totalSize
+}
+
+func (ca *chunksAssembler) removeTransfer(key string) {
+	transfer, found := ca.transfers[key]
+	if !found {
+		return
+	}
+
+	ca.pendingSize -= transfer.totalSize
+	delete(ca.transfers, key)
+
+	peerTransfers, found := ca.peersTransfers[transfer.from]
+	if !found {
+		return
+	}
+	peerTransfers.numTransfers--
+	peerTransfers.pendingSize -= transfer.totalSize
+	if peerTransfers.numTransfers == 0 {
+		delete(ca.peersTransfers, transfer.from)
+	}
+}
@@ -0,0 +1,274 @@
+package libp2p_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chunkTopic     = "chunk topic"
+	testChunkSize  = 10
+	testTransferID = uint64(37)
+)
+
+var maxTestPayloadSize = uint64(libp2p.MaxSendBuffSize * 2)
+
+func TestNewChunksAssembler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("max payload size not exceeding the max send buffer size should error", func(t *testing.T) {
+		t.Parallel()
+
+		ca, err := libp2p.NewChunksAssembler(uint64(libp2p.MaxSendBuffSize), maxTestPayloadSize, time.Second)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Nil(t, ca)
+	})
+	t.Run("max pending size lower than the max payload size should error", func(t *testing.T) {
+		t.Parallel()
+
+		ca, err := libp2p.NewChunksAssembler(maxTestPayloadSize, maxTestPayloadSize-1, time.Second)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Nil(t, ca)
+	})
+	t.Run("invalid max pending size per peer should error", func(t *testing.T) {
+		t.Parallel()
+
+		ca, err := libp2p.NewChunksAssemblerWithPeerLimits(maxTestPayloadSize, maxTestPayloadSize*2, maxTestPayloadSize-1, 0, time.Second)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Nil(t, ca)
+
+		ca, err = libp2p.NewChunksAssemblerWithPeerLimits(maxTestPayloadSize, maxTestPayloadSize*2, maxTestPayloadSize*2+1, 0, time.Second)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Nil(t, ca)
+	})
+	t.Run("invalid timeout should error", func(t *testing.T) {
+		t.Parallel()
+
+		ca, err := libp2p.NewChunksAssembler(maxTestPayloadSize, maxTestPayloadSize, time.Millisecond)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Nil(t, ca)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		ca, err := libp2p.NewChunksAssembler(maxTestPayloadSize, maxTestPayloadSize, time.Second)
+		assert.Nil(t, err)
+		assert.NotNil(t, ca)
+	})
+}
+
+func TestSplitInChunks(t *testing.T) {
+	t.Parallel()
+
+	buff := []byte("a buffer that will be split in 4 chunks")
+	chunks := libp2p.SplitInChunks(testTransferID, chunkTopic, buff, 12)
+	require.Equal(t, 4, len(chunks))
+
+	reassembled := make([]byte, 0, len(buff))
+	for i, chunk := range chunks {
+		assert.Equal(t, testTransferID, chunk.TransferID)
+		assert.Equal(t, chunkTopic, chunk.Topic)
+		assert.Equal(t, uint32(i), chunk.ChunkIndex)
+		assert.Equal(t, uint32(4), chunk.NumChunks)
+		assert.Equal(t, uint64(len(buff)), chunk.TotalSize)
+		reassembled = append(reassembled, chunk.Payload...)
+	}
+	assert.Equal(t, buff, reassembled)
+	assert.Equal(t, 3, len(chunks[3].Payload))
+}
+
+func TestChunksAssembler_AddChunk(t *testing.T) {
+	t.Parallel()
+
+	buff := []byte("a buffer that will be sent in chunks")
+	from := core.PeerID("pid")
+
+	t.Run("chunks received out of order should reassemble", func(t *testing.T) {
+		t.Parallel()
+
+		ca, _ := libp2p.NewChunksAssembler(maxTestPayloadSize, maxTestPayloadSize, time.Second)
+		chunks := libp2p.SplitInChunks(testTransferID, chunkTopic, buff, testChunkSize)
+
+		for i := len(chunks) - 1; i > 0; i-- {
+			payload, err := ca.AddChunk(chunks[i], from)
+			assert.Nil(t, err)
+			assert.Nil(t, payload)
+		}
+		assert.Equal(t, 1, ca.NumPendingTransfers())
+		assert.Equal(t, uint64(len(buff)), ca.PendingSize())
+
+		payload, err := ca.AddChunk(chunks[0], from)
+		assert.Nil(t, err)
+		assert.Equal(t, buff, payload)
+		assert.Equal(t, 0, ca.NumPendingTransfers())
+		assert.Equal(t, uint64(0), ca.PendingSize())
+	})
+	t.Run("duplicated chunk should be ignored", func(t *testing.T) {
+		t.Parallel()
+
+		ca, _ := libp2p.NewChunksAssembler(maxTestPayloadSize, maxTestPayloadSize, time.Second)
+		chunks := libp2p.SplitInChunks(testTransferID, chunkTopic, buff, testChunkSize)
+
+		_, _ = ca.AddChunk(chunks[0], from)
+		payload, err := ca.AddChunk(chunks[0], from)
+		assert.Nil(t, err)
+		assert.Nil(t, payload)
+		assert.Equal(t, 1, ca.NumPendingTransfers())
+	})
+	t.Run("same transfer ID from different peers should not mix", func(t *testing.T) {
+		t.Parallel()
+
+		ca, _ := libp2p.NewChunksAssembler(maxTestPayloadSize, maxTestPayloadSize, time.Second)
+		chunks := libp2p.SplitInChunks(testTransferID, chunkTopic, buff, testChunkSize)
+
+		_, _ = ca.AddChunk(chunks[0], from)
+		_, _ = ca.AddChunk(chunks[1], "another pid")
+		assert.Equal(t, 2, ca.NumPendingTransfers())
+	})
+	t.Run("invalid first chunk should error", func(t *testing.T) {
+		t.Parallel()
+
+		ca, _ := libp2p.NewChunksAssembler(maxTestPayloadSize, maxTestPayloadSize, time.Second)
+
+		chunk := libp2p.SplitInChunks(testTransferID, chunkTopic, buff, testChunkSize)[0]
+		chunk.NumChunks = 0
+		_, err := ca.AddChunk(chunk, from)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidChunk))
+
+		chunk = libp2p.SplitInChunks(testTransferID, chunkTopic, buff, testChunkSize)[0]
+		chunk.ChunkIndex = chunk.NumChunks
+		_, err = ca.AddChunk(chunk, from)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidChunk))
+
+		chunk = libp2p.SplitInChunks(testTransferID, chunkTopic, buff, testChunkSize)[0]
+		chunk.TotalSize = maxTestPayloadSize + 1
+		_, err = ca.AddChunk(chunk, from)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidChunk))
+
+		chunk = libp2p.SplitInChunks(testTransferID, chunkTopic, buff, testChunkSize)[0]
+		chunk.TotalHash = []byte("hash")
+		_, err = ca.AddChunk(chunk, from)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidChunk))
+
+		assert.Equal(t, 0, ca.NumPendingTransfers())
+	})
+	t.Run("inconsistent chunk should error and drop the transfer", func(t *testing.T) {
+		t.Parallel()
+
+		ca, _ := libp2p.NewChunksAssembler(maxTestPayloadSize, maxTestPayloadSize, time.Second)
+		chunks := libp2p.SplitInChunks(testTransferID, chunkTopic, buff, testChunkSize)
+
+		_, _ = ca.AddChunk(chunks[0], from)
+		chunks[1].Topic = "another topic"
+		payload, err := ca.AddChunk(chunks[1], from)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidChunk))
+		assert.Nil(t, payload)
+		assert.Equal(t, 0, ca.NumPendingTransfers())
+		assert.Equal(t, uint64(0), ca.PendingSize())
+	})
+	t.Run("altered payload should error on hash mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		ca, _ := libp2p.NewChunksAssembler(maxTestPayloadSize, maxTestPayloadSize, time.Second)
+		chunks := libp2p.SplitInChunks(testTransferID, chunkTopic, buff, testChunkSize)
+		chunks[2].Payload = bytes.Repeat([]byte("x"), len(chunks[2].Payload))
+
+		var err error
+		for _, chunk := range chunks {
+			_, err = ca.AddChunk(chunk, from)
+		}
+		assert.True(t, errors.Is(err, p2p.ErrInvalidChunk))
+		assert.Equal(t, 0, ca.NumPendingTransfers())
+	})
+	t.Run("memory limit reached should error", func(t *testing.T) {
+		t.Parallel()
+
+		ca, _ := libp2p.NewChunksAssembler(maxTestPayloadSize, maxTestPayloadSize, time.Second)
+		largeBuff := make([]byte, maxTestPayloadSize)
+		largeChunks := libp2p.SplitInChunks(testTransferID, chunkTopic, largeBuff, libp2p.MaxChunkPayloadSize)
+
+		_, err := ca.AddChunk(largeChunks[0], from)
+		assert.Nil(t, err)
+
+		chunks := libp2p.SplitInChunks(testTransferID+1, chunkTopic, buff, testChunkSize)
+		_, err = ca.AddChunk(chunks[0], from)
+		assert.True(t, errors.Is(err, p2p.ErrLargePayloadsMemoryLimitReached))
+		assert.Equal(t, 1, ca.NumPendingTransfers())
+	})
+	t.Run("peer pending transfers limit reached should error", func(t *testing.T) {
+		t.Parallel()
+
+		ca, _ := libp2p.NewChunksAssemblerWithPeerLimits(maxTestPayloadSize, maxTestPayloadSize, 0, 2, time.Second)
+		for i := uint64(0); i < 2; i++ {
+			chunks := libp2p.SplitInChunks(testTransferID+i, chunkTopic, buff, testChunkSize)
+			_, err := ca.AddChunk(chunks[0], from)
+			assert.Nil(t, err)
+		}
+
+		chunks := libp2p.SplitInChunks(testTransferID+2, chunkTopic, buff, testChunkSize)
+		_, err := ca.AddChunk(chunks[0], from)
+		assert.True(t, errors.Is(err, p2p.ErrLargePayloadsPeerLimitReached))
+		assert.Equal(t, 2, ca.NumPendingTransfers())
+
+		// other peers are not affected
+		_, err = ca.AddChunk(chunks[0], "another pid")
+		assert.Nil(t, err)
+		assert.Equal(t, 3, ca.NumPendingTransfers())
+
+		// a completed transfer frees a slot for its peer
+		firstChunks := libp2p.SplitInChunks(testTransferID, chunkTopic, buff, testChunkSize)
+		for _, chunk := range firstChunks[1:] {
+			_, _ = ca.AddChunk(chunk, from)
+		}
+		_, err = ca.AddChunk(chunks[0], from)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, ca.NumPendingTransfers())
+	})
+	t.Run("peer pending size limit reached should error", func(t *testing.T) {
+		t.Parallel()
+
+		ca, _ := libp2p.NewChunksAssemblerWithPeerLimits(maxTestPayloadSize, maxTestPayloadSize*2, 0, 0, time.Second)
+		largeBuff := make([]byte, maxTestPayloadSize)
+		largeChunks := libp2p.SplitInChunks(testTransferID, chunkTopic, largeBuff, libp2p.MaxChunkPayloadSize)
+
+		_, err := ca.AddChunk(largeChunks[0], from)
+		assert.Nil(t, err)
+
+		chunks := libp2p.SplitInChunks(testTransferID+1, chunkTopic, buff, testChunkSize)
+		_, err = ca.AddChunk(chunks[0], from)
+		assert.True(t, errors.Is(err, p2p.ErrLargePayloadsPeerLimitReached))
+		assert.Equal(t, 1, ca.NumPendingTransfers())
+		assert.Equal(t, maxTestPayloadSize, ca.PendingSize())
+
+		_, err = ca.AddChunk(chunks[0], "another pid")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, ca.NumPendingTransfers())
+	})
+	t.Run("expired transfers should be removed", func(t *testing.T) {
+		t.Parallel()
+
+		ca, _ := libp2p.NewChunksAssembler(maxTestPayloadSize, maxTestPayloadSize, time.Second)
+		currentTime := time.Now()
+		ca.SetTimeHandler(func() time.Time {
+			return currentTime
+		})
+		chunks := libp2p.SplitInChunks(testTransferID, chunkTopic, buff, testChunkSize)
+
+		_, _ = ca.AddChunk(chunks[0], from)
+		assert.Equal(t, 1, ca.NumPendingTransfers())
+
+		currentTime = currentTime.Add(time.Second * 2)
+		otherChunks := libp2p.SplitInChunks(testTransferID+1, chunkTopic, buff, testChunkSize)
+		_, _ = ca.AddChunk(otherChunks[0], from)
+		assert.Equal(t, 1, ca.NumPendingTransfers())
+		assert.Equal(t, uint64(len(buff)), ca.PendingSize())
+	})
+}
@@ -0,0 +1,82 @@
+package libp2p
+
+import (
+	"fmt"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// maxDecompressedPayloadSize protects the node against decompression bombs
+var maxDecompressedPayloadSize = maxSendBuffSize
+
+// the encoder and decoder are safe for concurrent use when working with EncodeAll and DecodeAll
+var zstdEncoder, _ = zstd.NewWriter(nil)
+var zstdDecoder, _ = zstd.NewReader(nil,
+	zstd.WithDecoderConcurrency(0),
+	zstd.WithDecoderMaxMemory(uint64(maxDecompressedPayloadSize)),
+)
+
+func checkCompressionCodec(codec p2p.CompressionCodec) error {
+	switch codec {
+	case p2p.NoCompression, p2p.SnappyCompression, p2p.ZstdCompression:
+		return nil
+	default:
+		return fmt.Errorf("%w, codec %d", p2p.ErrUnsupportedCompressionCodec, codec)
+	}
+}
+
+func compressPayload(codec p2p.CompressionCodec, buff []byte) ([]byte, error) {
+	switch codec {
+	case p2p.NoCompression:
+		return buff, nil
+	case p2p.SnappyCompression:
+		return snappy.Encode(nil, buff), nil
+	case p2p.ZstdCompression:
+		return zstdEncoder.EncodeAll(buff, nil), nil
+	default:
+		return nil, fmt.Errorf("%w, codec %d", p2p.ErrUnsupportedCompressionCodec, codec)
+	}
+}
+
+// decompressPayload decompresses the provided buffer. The decompressed size can not exceed maxDecompressedPayloadSize
+func decompressPayload(codec p2p.CompressionCodec, buff []byte) ([]byte, error) {
+	switch codec {
+	case p2p.NoCompression:
+		return buff, nil
+	case p2p.SnappyCompression:
+		return decompressSnappy(buff)
+	case p2p.ZstdCompression:
+		return decompressZstd(buff)
+	default:
+		return nil, fmt.Errorf("%w, codec %d", p2p.ErrUnsupportedCompressionCodec, codec)
+	}
+}
+
+func decompressSnappy(buff []byte) ([]byte, error) {
+	decodedLen, err := snappy.DecodedLen(buff)
+	if err != nil {
+		return nil, fmt.Errorf("%w, codec %s, error: %s", p2p.ErrDecompressionFailed, p2p.SnappyCompression, err.Error())
+	}
+	if decodedLen > maxDecompressedPayloadSize {
+		return nil, fmt.Errorf("%w, codec %s, decompressed size %d, maximum %d",
+			p2p.ErrDecompressionFailed, p2p.SnappyCompression, decodedLen, maxDecompressedPayloadSize)
+	}
+
+	decoded, err := snappy.Decode(nil, buff)
+	if err != nil {
+		return nil, fmt.Errorf("%w, codec %s, error: %s", p2p.ErrDecompressionFailed, p2p.SnappyCompression, err.Error())
+	}
+
+	return decoded, nil
+}
+
+func decompressZstd(buff []byte) ([]byte, error) {
+	decoded, err := zstdDecoder.DecodeAll(buff, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w, codec %s, error: %s", p2p.ErrDecompressionFailed, p2p.ZstdCompression, err.Error())
+	}
+
+	return decoded, nil
+}
@@ -0,0 +1,57 @@
+package libp2p
+
+import (
+	"fmt"
+	"reflect"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+)
+
+// reloadableConfigFields holds the paths of the P2PConfig fields that can be changed while the messenger is running
+var reloadableConfigFields = map[string]struct{}{
+	"Node.ThresholdMinConnectedPeers":                        {},
+	"KadDhtPeerDiscovery.RefreshIntervalInSec":               {},
+	"Sharding.TargetPeerCount":                               {},
+	"Sharding.MaxIntraShardValidators":                       {},
+	"Sharding.MaxCrossShardValidators":                       {},
+	"Sharding.MaxIntraShardObservers":                        {},
+	"Sharding.MaxCrossShardObservers":                        {},
+	"Sharding.MaxSeeders":                                    {},
+	"Sharding.AdditionalConnections.MaxFullHistoryObservers": {},
+}
+
+// checkNonReloadableConfigFields errors if any field that can not be changed at runtime differs between the two configs
+func checkNonReloadableConfigFields(current config.P2PConfig, provided config.P2PConfig) error {
+	field, changed := findChangedNonReloadableField("", reflect.ValueOf(current), reflect.ValueOf(provided))
+	if changed {
+		return fmt.Errorf("%w: %s", p2p.ErrNonReloadableConfigField, field)
+	}
+
+	return nil
+}
+
+func findChangedNonReloadableField(path string, current reflect.Value, provided reflect.Value) (string, bool) {
+	if current.Kind() != reflect.Struct {
+		return path, !reflect.DeepEqual(current.Interface(), provided.Interface())
+	}
+
+	for i := 0; i < current.NumField(); i++ {
+		fieldPath := current.Type().Field(i).Name
+		if len(path) > 0 {
+			fieldPath = path + "." + fieldPath
+		}
+
+		_, isReloadable := reloadableConfigFields[fieldPath]
+		if isReloadable {
+			continue
+		}
+
+		field, changed := findChangedNonReloadableField(fieldPath, current.Field(i), provided.Field(i))
+		if changed {
+			return field, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,191 @@
+package libp2p
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/disabled"
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+var _ connmgr.ConnectionGater = (*connectionGater)(nil)
+
+// connectionGater refuses the dials and the connections to the denied peers and to the denied IP ranges before the
+// security handshake is done. The addresses without an IP, like the unresolved DNS ones, are not checked against the IP
+// rules. For the relayed addresses, the configured IP ranges are checked against the relay's IP. When the peer denial
+// evaluator is also able to deny IPs, the IP denials are checked as well, for the direct addresses only, so a relay is
+// not tracked nor denied for the peers reached through it
+type connectionGater struct {
+	mutPeerDenialEvaluator sync.RWMutex
+	peerDenialEvaluator    p2p.PeerDenialEvaluator
+	allowedRanges          []*net.IPNet
+	deniedRanges           []*net.IPNet
+}
+
+func newConnectionGater(gaterConfig config.ConnectionGaterConfig) (*connectionGater, error) {
+	allowedRanges, err := parseCIDRs(gaterConfig.AllowCIDRs)
+	if err != nil {
+		return nil, fmt.Errorf("%w for the connection gater allowed CIDRs", err)
+	}
+	deniedRanges, err := parseCIDRs(gaterConfig.DenyCIDRs)
+	if err != nil {
+		return nil, fmt.Errorf("%w for the connection gater denied CIDRs", err)
+	}
+
+	return &connectionGater{
+		peerDenialEvaluator: &disabled.PeerDenialEvaluator{},
+		allowedRanges:       allowedRanges,
+		deniedRanges:        deniedRanges,
+	}, nil
+}
+
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	ipNets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("%w, CIDR %s: %s", p2p.ErrInvalidValue, cidr, err.Error())
+		}
+
+		ipNets = append(ipNets, ipNet)
+	}
+
+	return ipNets, nil
+}
+
+// SetPeerDenialEvaluator sets the handler that tells if a peer is denied
+func (gater *connectionGater) SetPeerDenialEvaluator(handler p2p.PeerDenialEvaluator) error {
+	if check.IfNil(handler) {
+		return p2p.ErrNilPeerDenialEvaluator
+	}
+
+	gater.mutPeerDenialEvaluator.Lock()
+	gater.peerDenialEvaluator = handler
+	gater.mutPeerDenialEvaluator.Unlock()
+
+	return nil
+}
+
+func (gater *connectionGater) getPeerDenialEvaluator() p2p.PeerDenialEvaluator {
+	gater.mutPeerDenialEvaluator.RLock()
+	defer gater.mutPeerDenialEvaluator.RUnlock()
+
+	return gater.peerDenialEvaluator
+}
+
+func (gater *connectionGater) isPeerDenied(pid peer.ID) bool {
+	return gater.getPeerDenialEvaluator().IsDenied(core.PeerID(pid))
+}
+
+// isSecuredConnectionDenied checks the peer together with the IP it connects from, if the evaluator can deny IPs
+func (gater *connectionGater) isSecuredConnectionDenied(pid peer.ID, address multiaddr.Multiaddr) bool {
+	peerDenialEvaluator := gater.getPeerDenialEvaluator()
+	ipDenialEvaluator, ok := peerDenialEvaluator.(p2p.IPDenialEvaluator)
+	if !ok || address == nil || isRelayedAddress(address) {
+		return peerDenialEvaluator.IsDenied(core.PeerID(pid))
+	}
+
+	ip, err := manet.ToIP(address)
+	if err != nil {
+		return peerDenialEvaluator.IsDenied(core.PeerID(pid))
+	}
+
+	return ipDenialEvaluator.IsConnectionDenied(core.PeerID(pid), ip)
+}
+
+func (gater *connectionGater) isAddressDenied(address multiaddr.Multiaddr) bool {
+	if address == nil {
+		return false
+	}
+
+	ipDenialEvaluator, isIPDenialEvaluator := gater.getPeerDenialEvaluator().(p2p.IPDenialEvaluator)
+	isIPDenialEvaluator = isIPDenialEvaluator && !isRelayedAddress(address)
+	if len(gater.deniedRanges) == 0 && !isIPDenialEvaluator {
+		return false
+	}
+
+	ip, err := manet.ToIP(address)
+	if err != nil {
+		return false
+	}
+	if isIPDenialEvaluator && ipDenialEvaluator.IsIPDenied(ip) {
+		return true
+	}
+
+	return !containsIP(gater.allowedRanges, ip) && containsIP(gater.deniedRanges, ip)
+}
+
+func containsIP(ipNets []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range ipNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// InterceptPeerDial returns false if the peer is denied
+func (gater *connectionGater) InterceptPeerDial(pid peer.ID) bool {
+	if gater.isPeerDenied(pid) {
+		log.Trace("connectionGater: refused dialing a denied peer", "pid", pid.String())
+		return false
+	}
+
+	return true
+}
+
+// InterceptAddrDial returns false if the address is denied
+func (gater *connectionGater) InterceptAddrDial(pid peer.ID, address multiaddr.Multiaddr) bool {
+	if gater.isAddressDenied(address) {
+		log.Trace("connectionGater: refused dialing a denied address", "pid", pid.String(), "address", address.String())
+		return false
+	}
+
+	return true
+}
+
+// InterceptAccept returns false if the remote address of the inbound connection is denied
+func (gater *connectionGater) InterceptAccept(addrs network.ConnMultiaddrs) bool {
+	remoteAddress := addrs.RemoteMultiaddr()
+	if gater.isAddressDenied(remoteAddress) {
+		log.Trace("connectionGater: refused accepting a connection from a denied address",
+			"address", remoteAddress.String())
+		return false
+	}
+
+	return true
+}
+
+// InterceptSecured returns false if the authenticated peer is denied or its address is denied
+func (gater *connectionGater) InterceptSecured(direction network.Direction, pid peer.ID, addrs network.ConnMultiaddrs) bool {
+	if gater.isSecuredConnectionDenied(pid, addrs.RemoteMultiaddr()) {
+		log.Trace("connectionGater: refused a secured connection with a denied peer",
+			"pid", pid.String(), "direction", direction.String())
+		return false
+	}
+
+	remoteAddress := addrs.RemoteMultiaddr()
+	if gater.isAddressDenied(remoteAddress) {
+		log.Trace("connectionGater: refused a secured connection with a denied address",
+			"pid", pid.String(), "direction", direction.String(), "address", remoteAddress.String())
+		return false
+	}
+
+	return true
+}
+
+// InterceptUpgraded accepts all the upgraded connections as the checks were done when the connection was secured
+func (gater *connectionGater) InterceptUpgraded(_ network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
@@ -0,0 +1,182 @@
+package libp2p_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/denialEvaluator"
+	"github.com/TerraDharitri/drt-go-chain-p2p/mock"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+)
+
+func createConnWithRemoteAddress(address string) *mock.ConnStub {
+	return &mock.ConnStub{
+		RemoteMultiaddrCalled: func() multiaddr.Multiaddr {
+			return multiaddr.StringCast(address)
+		},
+	}
+}
+
+func TestNewConnectionGater(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid allowed CIDR should error", func(t *testing.T) {
+		t.Parallel()
+
+		gater, err := libp2p.NewConnectionGater(config.ConnectionGaterConfig{
+			AllowCIDRs: []string{"10.0.0.0"},
+		})
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Nil(t, gater)
+	})
+	t.Run("invalid denied CIDR should error", func(t *testing.T) {
+		t.Parallel()
+
+		gater, err := libp2p.NewConnectionGater(config.ConnectionGaterConfig{
+			DenyCIDRs: []string{"10.0.0.0/33"},
+		})
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Nil(t, gater)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		gater, err := libp2p.NewConnectionGater(config.ConnectionGaterConfig{
+			AllowCIDRs: []string{"10.1.2.0/24"},
+			DenyCIDRs:  []string{"10.0.0.0/8"},
+		})
+		assert.Nil(t, err)
+		assert.NotNil(t, gater)
+	})
+}
+
+func TestConnectionGater_SetPeerDenialEvaluator(t *testing.T) {
+	t.Parallel()
+
+	gater, _ := libp2p.NewConnectionGater(config.ConnectionGaterConfig{})
+
+	err := gater.SetPeerDenialEvaluator(nil)
+	assert.Equal(t, p2p.ErrNilPeerDenialEvaluator, err)
+
+	err = gater.SetPeerDenialEvaluator(&mock.PeerDenialEvaluatorStub{})
+	assert.Nil(t, err)
+}
+
+func TestConnectionGater_ShouldRefuseTheDeniedPeers(t *testing.T) {
+	t.Parallel()
+
+	deniedPid := peer.ID("denied")
+	allowedPid := peer.ID("allowed")
+	gater, _ := libp2p.NewConnectionGater(config.ConnectionGaterConfig{})
+
+	// no denial evaluator set, all peers are accepted
+	assert.True(t, gater.InterceptPeerDial(deniedPid))
+
+	_ = gater.SetPeerDenialEvaluator(&mock.PeerDenialEvaluatorStub{
+		IsDeniedCalled: func(pid core.PeerID) bool {
+			return pid == core.PeerID(deniedPid)
+		},
+	})
+	conn := createConnWithRemoteAddress("/ip4/10.0.0.1/tcp/37373")
+
+	assert.False(t, gater.InterceptPeerDial(deniedPid))
+	assert.False(t, gater.InterceptSecured(network.DirInbound, deniedPid, conn))
+	assert.False(t, gater.InterceptSecured(network.DirOutbound, deniedPid, conn))
+	assert.True(t, gater.InterceptPeerDial(allowedPid))
+	assert.True(t, gater.InterceptSecured(network.DirInbound, allowedPid, conn))
+
+	allowed, _ := gater.InterceptUpgraded(conn)
+	assert.True(t, allowed)
+}
+
+func TestConnectionGater_ShouldRefuseTheDeniedIPRanges(t *testing.T) {
+	t.Parallel()
+
+	pid := peer.ID("pid")
+	gater, _ := libp2p.NewConnectionGater(config.ConnectionGaterConfig{
+		AllowCIDRs: []string{"10.1.2.0/24"},
+		DenyCIDRs:  []string{"10.0.0.0/8", "fd00::/8"},
+	})
+
+	deniedAddresses := []string{
+		"/ip4/10.0.0.1/tcp/37373",
+		"/ip4/10.1.3.1/udp/37373/quic-v1",
+		"/ip6/fd00::1/tcp/37373",
+	}
+	for _, address := range deniedAddresses {
+		conn := createConnWithRemoteAddress(address)
+		assert.False(t, gater.InterceptAddrDial(pid, multiaddr.StringCast(address)), address)
+		assert.False(t, gater.InterceptAccept(conn), address)
+		assert.False(t, gater.InterceptSecured(network.DirInbound, pid, conn), address)
+	}
+
+	allowedAddresses := []string{
+		// the allowed range is an exception to the denied one
+		"/ip4/10.1.2.1/tcp/37373",
+		"/ip4/192.168.0.1/tcp/37373",
+		"/ip6/2001:db8::1/tcp/37373",
+		"/dns4/example.com/tcp/37373",
+	}
+	for _, address := range allowedAddresses {
+		conn := createConnWithRemoteAddress(address)
+		assert.True(t, gater.InterceptAddrDial(pid, multiaddr.StringCast(address)), address)
+		assert.True(t, gater.InterceptAccept(conn), address)
+		assert.True(t, gater.InterceptSecured(network.DirInbound, pid, conn), address)
+	}
+}
+
+func TestConnectionGater_ShouldRefuseTheIPsDeniedByTheEvaluator(t *testing.T) {
+	t.Parallel()
+
+	gater, _ := libp2p.NewConnectionGater(config.ConnectionGaterConfig{})
+	evaluator, _ := denialEvaluator.NewSubnetDenialEvaluator(config.PeerDenialConfig{MaxTrackedPeers: 10})
+	_ = gater.SetPeerDenialEvaluator(evaluator)
+	_ = evaluator.UpsertSubnet(net.ParseIP("10.0.0.1"), time.Minute)
+
+	deniedConn := createConnWithRemoteAddress("/ip4/10.0.0.2/tcp/37373")
+	assert.False(t, gater.InterceptAddrDial("pid", deniedConn.RemoteMultiaddr()))
+	assert.False(t, gater.InterceptAccept(deniedConn))
+	assert.False(t, gater.InterceptSecured(network.DirInbound, "pid", deniedConn))
+
+	allowedConn := createConnWithRemoteAddress("/ip4/10.0.1.2/tcp/37373")
+	assert.True(t, gater.InterceptAccept(allowedConn))
+	assert.True(t, gater.InterceptSecured(network.DirInbound, "pid", allowedConn))
+
+	// denying the peer denies the IP it was last seen connecting from
+	_ = evaluator.UpsertPeerID("pid", time.Minute)
+	assert.False(t, gater.InterceptAccept(allowedConn))
+	assert.False(t, gater.InterceptSecured(network.DirInbound, "other pid", allowedConn))
+}
+
+func TestConnectionGater_RelayedConnectionsShouldNotDenyTheRelayIP(t *testing.T) {
+	t.Parallel()
+
+	gater, _ := libp2p.NewConnectionGater(config.ConnectionGaterConfig{})
+	evaluator, _ := denialEvaluator.NewSubnetDenialEvaluator(config.PeerDenialConfig{MaxTrackedPeers: 10})
+	_ = gater.SetPeerDenialEvaluator(evaluator)
+
+	relayedConn := createConnWithRemoteAddress(relayedThroughRelay5)
+	assert.True(t, gater.InterceptSecured(network.DirInbound, "relayed pid", relayedConn))
+
+	// the relayed peer's denial should not be extended to the relay's IP
+	_ = evaluator.UpsertPeerID("relayed pid", time.Minute)
+	assert.False(t, gater.InterceptSecured(network.DirInbound, "relayed pid", relayedConn))
+	assert.True(t, gater.InterceptSecured(network.DirInbound, "other relayed pid", relayedConn))
+	directConn := createConnWithRemoteAddress("/ip4/10.0.5.5/tcp/37373")
+	assert.True(t, gater.InterceptAccept(directConn))
+
+	// a denied relay IP should not refuse the peers reached through the relay
+	_ = evaluator.UpsertIP(net.ParseIP("10.0.5.5"), time.Minute)
+	assert.False(t, gater.InterceptAccept(directConn))
+	assert.True(t, gater.InterceptAddrDial("other relayed pid", relayedConn.RemoteMultiaddr()))
+	assert.True(t, gater.InterceptSecured(network.DirInbound, "other relayed pid", relayedConn))
+}
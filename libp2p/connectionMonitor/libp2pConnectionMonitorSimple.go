@@ -2,6 +2,7 @@ package connectionMonitor
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/TerraDharitri/drt-go-chain-core/core"
@@ -9,6 +10,7 @@ import (
 	logger "github.com/TerraDharitri/drt-go-chain-logger"
 	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
 	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 )
 
@@ -20,11 +22,13 @@ var log = logger.GetOrCreate("p2p/libp2p/connectionmonitor")
 type libp2pConnectionMonitorSimple struct {
 	chDoReconnect              chan struct{}
 	reconnecter                p2p.Reconnecter
+	mutThreshold               sync.RWMutex
 	thresholdMinConnectedPeers int
 	sharder                    Sharder
 	preferredPeersHolder       p2p.PreferredPeersHolderHandler
 	cancelFunc                 context.CancelFunc
 	connectionsWatcher         p2p.ConnectionsWatcher
+	eventsNotifier             p2p.EventsNotifier
 }
 
 // ArgsConnectionMonitorSimple is the DTO used in the NewLibp2pConnectionMonitorSimple constructor function
@@ -34,6 +38,7 @@ type ArgsConnectionMonitorSimple struct {
 	Sharder                    Sharder
 	PreferredPeersHolder       p2p.PreferredPeersHolderHandler
 	ConnectionsWatcher         p2p.ConnectionsWatcher
+	EventsNotifier             p2p.EventsNotifier
 }
 
 // NewLibp2pConnectionMonitorSimple creates a new connection monitor (version 2 that is more streamlined and does not care
@@ -51,6 +56,9 @@ func NewLibp2pConnectionMonitorSimple(args ArgsConnectionMonitorSimple) (*libp2p
 	if check.IfNil(args.ConnectionsWatcher) {
 		return nil, p2p.ErrNilConnectionsWatcher
 	}
+	if check.IfNil(args.EventsNotifier) {
+		return nil, p2p.ErrNilEventsNotifier
+	}
 
 	ctx, cancelFunc := context.WithCancel(context.Background())
 
@@ -62,6 +70,7 @@ func NewLibp2pConnectionMonitorSimple(args ArgsConnectionMonitorSimple) (*libp2p
 		cancelFunc:                 cancelFunc,
 		preferredPeersHolder:       args.PreferredPeersHolder,
 		connectionsWatcher:         args.ConnectionsWatcher,
+		eventsNotifier:             args.EventsNotifier,
 	}
 
 	go cm.doReconnection(ctx)
@@ -92,9 +101,26 @@ func (lcms *libp2pConnectionMonitorSimple) Connected(netw network.Network, conn
 	lcms.connectionsWatcher.NewKnownConnection(peerId, connectionStr)
 	lcms.preferredPeersHolder.PutConnectionAddress(peerId, connectionStr)
 
+	lcms.trimConnections(netw, allPeers)
+}
+
+// TrimConnections closes the connections with the peers that do not fit anymore in the sharder's limits
+func (lcms *libp2pConnectionMonitorSimple) TrimConnections(netw network.Network) {
+	if check.IfNilReflect(netw) {
+		return
+	}
+
+	lcms.trimConnections(netw, netw.Peers())
+}
+
+func (lcms *libp2pConnectionMonitorSimple) trimConnections(netw network.Network, allPeers []peer.ID) {
 	evicted := lcms.sharder.ComputeEvictionList(allPeers)
 	for _, pid := range evicted {
 		_ = netw.ClosePeer(pid)
+		lcms.eventsNotifier.NotifyEvent(p2p.Event{
+			Type: p2p.PeerEvictedEvent,
+			Peer: core.PeerID(pid),
+		})
 	}
 }
 
@@ -124,6 +150,9 @@ func (lcms *libp2pConnectionMonitorSimple) doReconnection(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		}
+		lcms.eventsNotifier.NotifyEvent(p2p.Event{
+			Type: p2p.ReconnectTriggeredEvent,
+		})
 		lcms.reconnecter.ReconnectToNetwork(ctx)
 
 		select {
@@ -136,7 +165,7 @@ func (lcms *libp2pConnectionMonitorSimple) doReconnection(ctx context.Context) {
 
 // IsConnectedToTheNetwork returns true if the number of connected peer is at least equal with thresholdMinConnectedPeers
 func (lcms *libp2pConnectionMonitorSimple) IsConnectedToTheNetwork(netw network.Network) bool {
-	return len(netw.Peers()) >= lcms.thresholdMinConnectedPeers
+	return len(netw.Peers()) >= lcms.ThresholdMinConnectedPeers()
 }
 
 // SetThresholdMinConnectedPeers sets the minimum connected peers number when the node is considered connected on the network
@@ -144,12 +173,18 @@ func (lcms *libp2pConnectionMonitorSimple) SetThresholdMinConnectedPeers(thresho
 	if check.IfNilReflect(netw) {
 		return
 	}
+	lcms.mutThreshold.Lock()
 	lcms.thresholdMinConnectedPeers = thresholdMinConnectedPeers
+	lcms.mutThreshold.Unlock()
+
 	lcms.doReconnectionIfNeeded(netw)
 }
 
 // ThresholdMinConnectedPeers returns the minimum connected peers number when the node is considered connected on the network
 func (lcms *libp2pConnectionMonitorSimple) ThresholdMinConnectedPeers() int {
+	lcms.mutThreshold.RLock()
+	defer lcms.mutThreshold.RUnlock()
+
 	return lcms.thresholdMinConnectedPeers
 }
 
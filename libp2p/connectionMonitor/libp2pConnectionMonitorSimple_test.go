@@ -25,6 +25,7 @@ func createMockArgsConnectionMonitorSimple() connectionMonitor.ArgsConnectionMon
 		Sharder:                    &mock.KadSharderStub{},
 		PreferredPeersHolder:       &mock.PeersHolderStub{},
 		ConnectionsWatcher:         &mock.ConnectionsWatcherStub{},
+		EventsNotifier:             &mock.EventsNotifierStub{},
 	}
 }
 
@@ -71,6 +72,16 @@ func TestNewLibp2pConnectionMonitorSimple(t *testing.T) {
 		assert.Equal(t, p2p.ErrNilConnectionsWatcher, err)
 		assert.True(t, check.IfNil(lcms))
 	})
+	t.Run("nil events notifier should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsConnectionMonitorSimple()
+		args.EventsNotifier = nil
+		lcms, err := connectionMonitor.NewLibp2pConnectionMonitorSimple(args)
+
+		assert.Equal(t, p2p.ErrNilEventsNotifier, err)
+		assert.True(t, check.IfNil(lcms))
+	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
@@ -102,6 +113,12 @@ func TestNewLibp2pConnectionMonitorSimple_OnDisconnectedUnderThresholdShouldCall
 
 	args := createMockArgsConnectionMonitorSimple()
 	args.Reconnecter = rs
+	chEvents := make(chan p2p.Event, 1)
+	args.EventsNotifier = &mock.EventsNotifierStub{
+		NotifyEventCalled: func(event p2p.Event) {
+			chEvents <- event
+		},
+	}
 	lcms, _ := connectionMonitor.NewLibp2pConnectionMonitorSimple(args)
 	time.Sleep(durationStartGoRoutine)
 	lcms.Disconnected(&ns, nil)
@@ -111,6 +128,7 @@ func TestNewLibp2pConnectionMonitorSimple_OnDisconnectedUnderThresholdShouldCall
 	case <-time.After(durationTimeoutWaiting):
 		assert.Fail(t, "timeout waiting to call reconnect")
 	}
+	assert.Equal(t, p2p.ReconnectTriggeredEvent, (<-chEvents).Type)
 }
 
 func TestLibp2pConnectionMonitorSimple_ConnectedWithSharderShouldCallEvictAndClosePeer(t *testing.T) {
@@ -138,6 +156,12 @@ func TestLibp2pConnectionMonitorSimple_ConnectedWithSharderShouldCallEvictAndClo
 			putConnectionAddressCalled = true
 		},
 	}
+	var notifiedEvent p2p.Event
+	args.EventsNotifier = &mock.EventsNotifierStub{
+		NotifyEventCalled: func(event p2p.Event) {
+			notifiedEvent = event
+		},
+	}
 	lcms, _ := connectionMonitor.NewLibp2pConnectionMonitorSimple(args)
 
 	lcms.Connected(
@@ -161,6 +185,59 @@ func TestLibp2pConnectionMonitorSimple_ConnectedWithSharderShouldCallEvictAndClo
 	assert.Equal(t, 1, numComputeWasCalled)
 	assert.True(t, knownConnectionCalled)
 	assert.True(t, putConnectionAddressCalled)
+	assert.Equal(t, p2p.Event{Type: p2p.PeerEvictedEvent, Peer: core.PeerID(evictedPid[0])}, notifiedEvent)
+}
+
+func TestLibp2pConnectionMonitorSimple_TrimConnections(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil network should not panic", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsConnectionMonitorSimple()
+		args.Sharder = &mock.KadSharderStub{
+			ComputeEvictListCalled: func(pidList []peer.ID) []peer.ID {
+				assert.Fail(t, "should have not called ComputeEvictionList")
+				return nil
+			},
+		}
+		lcms, _ := connectionMonitor.NewLibp2pConnectionMonitorSimple(args)
+
+		lcms.TrimConnections(nil)
+	})
+	t.Run("should close the evicted peers", func(t *testing.T) {
+		t.Parallel()
+
+		connectedPeers := []peer.ID{"pid1", "pid2", "pid3"}
+		args := createMockArgsConnectionMonitorSimple()
+		args.Sharder = &mock.KadSharderStub{
+			ComputeEvictListCalled: func(pidList []peer.ID) []peer.ID {
+				assert.Equal(t, connectedPeers, pidList)
+				return pidList[2:]
+			},
+		}
+		notifiedEvents := make([]p2p.Event, 0)
+		args.EventsNotifier = &mock.EventsNotifierStub{
+			NotifyEventCalled: func(event p2p.Event) {
+				notifiedEvents = append(notifiedEvents, event)
+			},
+		}
+		lcms, _ := connectionMonitor.NewLibp2pConnectionMonitorSimple(args)
+
+		closedPeers := make([]peer.ID, 0)
+		lcms.TrimConnections(&mock.NetworkStub{
+			ClosePeerCall: func(id peer.ID) error {
+				closedPeers = append(closedPeers, id)
+				return nil
+			},
+			PeersCall: func() []peer.ID {
+				return connectedPeers
+			},
+		})
+
+		assert.Equal(t, []peer.ID{"pid3"}, closedPeers)
+		assert.Equal(t, []p2p.Event{{Type: p2p.PeerEvictedEvent, Peer: "pid3"}}, notifiedEvents)
+	})
 }
 
 func TestNewLibp2pConnectionMonitorSimple_DisconnectedShouldRemovePeerFromPreferredPeers(t *testing.T) {
@@ -1,36 +1,57 @@
 package libp2p
 
 import (
+	"net"
 	"sync"
 
 	"github.com/TerraDharitri/drt-go-chain-core/core"
 	"github.com/TerraDharitri/drt-go-chain-core/core/check"
 	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/denialEvaluator"
 	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
 )
 
 var _ ConnectionMonitor = (*connectionMonitorWrapper)(nil)
 
 // connectionMonitorWrapper is a wrapper over ConnectionMonitor that satisfies the Notifiee interface
 // and is able to be notified by the current running host (connection status changes)
-// it handles black list peers
+// it handles black list peers and the caps on the number of connections from the same IP or subnet
 type connectionMonitorWrapper struct {
 	ConnectionMonitor
-	network             network.Network
-	mutPeerBlackList    sync.RWMutex
-	peerDenialEvaluator p2p.PeerDenialEvaluator
+	network                 network.Network
+	mutPeerBlackList        sync.RWMutex
+	peerDenialEvaluator     p2p.PeerDenialEvaluator
+	eventsNotifier          p2p.EventsNotifier
+	preferredPeersHolder    p2p.PreferredPeersHolderHandler
+	maxConnectionsPerIP     uint32
+	maxConnectionsPerSubnet uint32
+	// announcedPeers holds the peers notified as connected so the denied peers, never announced, will not be notified
+	// as disconnected either
+	mutAnnouncedPeers sync.Mutex
+	announcedPeers    map[peer.ID]struct{}
 }
 
 func newConnectionMonitorWrapper(
 	network network.Network,
 	connMonitor ConnectionMonitor,
 	peerDenialEvaluator p2p.PeerDenialEvaluator,
+	eventsNotifier p2p.EventsNotifier,
+	preferredPeersHolder p2p.PreferredPeersHolderHandler,
+	peerDenialConfig config.PeerDenialConfig,
 ) *connectionMonitorWrapper {
 	return &connectionMonitorWrapper{
-		ConnectionMonitor:   connMonitor,
-		network:             network,
-		peerDenialEvaluator: peerDenialEvaluator,
+		ConnectionMonitor:       connMonitor,
+		network:                 network,
+		peerDenialEvaluator:     peerDenialEvaluator,
+		eventsNotifier:          eventsNotifier,
+		preferredPeersHolder:    preferredPeersHolder,
+		maxConnectionsPerIP:     peerDenialConfig.MaxConnectionsPerIP,
+		maxConnectionsPerSubnet: peerDenialConfig.MaxConnectionsPerSubnet,
+		announcedPeers:          make(map[peer.ID]struct{}),
 	}
 }
 
@@ -51,23 +72,161 @@ func (cmw *connectionMonitorWrapper) Connected(netw network.Network, conn networ
 	cmw.mutPeerBlackList.RUnlock()
 
 	pid := conn.RemotePeer()
-	if peerBlackList.IsDenied(core.PeerID(pid)) {
+	ip, hasIP := directRemoteIP(conn)
+	if isConnectionDenied(peerBlackList, pid, ip, hasIP) {
 		log.Trace("dropping connection to blacklisted peer",
 			"pid", pid.String(),
 		)
 		_ = conn.Close()
+		cmw.notifyEvent(p2p.PeerDeniedEvent, pid)
 
 		return
 	}
 
+	if hasIP && cmw.isOverConnectionsCap(netw, conn, ip) {
+		_ = conn.Close()
+		cmw.notifyEvent(p2p.PeerDeniedEvent, pid)
+
+		return
+	}
+
+	// a peer can have more than one connection, only the first one is notified
+	if cmw.announceConnected(pid) {
+		cmw.notifyEvent(p2p.PeerConnectedEvent, pid)
+	}
+
 	cmw.ConnectionMonitor.Connected(netw, conn)
 }
 
+// announceConnected returns true if the peer was not already announced as connected
+func (cmw *connectionMonitorWrapper) announceConnected(pid peer.ID) bool {
+	cmw.mutAnnouncedPeers.Lock()
+	defer cmw.mutAnnouncedPeers.Unlock()
+
+	_, isAnnounced := cmw.announcedPeers[pid]
+	cmw.announcedPeers[pid] = struct{}{}
+
+	return !isAnnounced
+}
+
+// announceDisconnected returns true if the peer was announced as connected and it has no connection left
+func (cmw *connectionMonitorWrapper) announceDisconnected(netw network.Network, pid peer.ID) bool {
+	cmw.mutAnnouncedPeers.Lock()
+	defer cmw.mutAnnouncedPeers.Unlock()
+
+	_, isAnnounced := cmw.announcedPeers[pid]
+	if !isAnnounced || len(netw.ConnsToPeer(pid)) > 0 {
+		return false
+	}
+	delete(cmw.announcedPeers, pid)
+
+	return true
+}
+
+func remoteIP(conn network.Conn) (net.IP, bool) {
+	remoteAddress := conn.RemoteMultiaddr()
+	if remoteAddress == nil {
+		return nil, false
+	}
+
+	ip, err := manet.ToIP(remoteAddress)
+	if err != nil {
+		return nil, false
+	}
+
+	return ip, true
+}
+
+// directRemoteIP returns the remote IP of a direct connection. The IP of a relayed connection is the relay's IP, so it
+// is not returned as it would attribute the relay's IP to every peer reached through it
+func directRemoteIP(conn network.Conn) (net.IP, bool) {
+	if isRelayedConnection(conn) {
+		return nil, false
+	}
+
+	return remoteIP(conn)
+}
+
+func isConnectionDenied(peerBlackList p2p.PeerDenialEvaluator, pid peer.ID, ip net.IP, hasIP bool) bool {
+	ipDenialEvaluator, ok := peerBlackList.(p2p.IPDenialEvaluator)
+	if ok && hasIP {
+		return ipDenialEvaluator.IsConnectionDenied(core.PeerID(pid), ip)
+	}
+
+	return peerBlackList.IsDenied(core.PeerID(pid))
+}
+
+// isOverConnectionsCap returns true if there are too many other connections from the same IP or from the same subnet.
+// The preferred peers and the relayed connections are neither capped nor counted
+func (cmw *connectionMonitorWrapper) isOverConnectionsCap(netw network.Network, conn network.Conn, ip net.IP) bool {
+	if cmw.maxConnectionsPerIP == 0 && cmw.maxConnectionsPerSubnet == 0 {
+		return false
+	}
+
+	pid := conn.RemotePeer()
+	if cmw.preferredPeersHolder.Contains(core.PeerID(pid)) {
+		return false
+	}
+
+	subnet := denialEvaluator.SubnetKey(ip)
+	numConnectionsFromIP := uint32(0)
+	numConnectionsFromSubnet := uint32(0)
+	for _, existingConn := range netw.Conns() {
+		if existingConn == conn || cmw.preferredPeersHolder.Contains(core.PeerID(existingConn.RemotePeer())) {
+			continue
+		}
+
+		existingIP, hasIP := directRemoteIP(existingConn)
+		if !hasIP {
+			continue
+		}
+		if existingIP.Equal(ip) {
+			numConnectionsFromIP++
+		}
+		if denialEvaluator.SubnetKey(existingIP) == subnet {
+			numConnectionsFromSubnet++
+		}
+	}
+
+	if cmw.maxConnectionsPerIP > 0 && numConnectionsFromIP >= cmw.maxConnectionsPerIP {
+		log.Trace("dropping connection, too many connections from the same IP",
+			"pid", pid.String(),
+			"ip", ip.String(),
+			"num connections", numConnectionsFromIP,
+		)
+		return true
+	}
+	if cmw.maxConnectionsPerSubnet > 0 && numConnectionsFromSubnet >= cmw.maxConnectionsPerSubnet {
+		log.Trace("dropping connection, too many connections from the same subnet",
+			"pid", pid.String(),
+			"subnet", subnet,
+			"num connections", numConnectionsFromSubnet,
+		)
+		return true
+	}
+
+	return false
+}
+
 // Disconnected is called when a connection closed
 func (cmw *connectionMonitorWrapper) Disconnected(netw network.Network, conn network.Conn) {
+	if !check.IfNilReflect(netw) && !check.IfNilReflect(conn) {
+		pid := conn.RemotePeer()
+		if cmw.announceDisconnected(netw, pid) {
+			cmw.notifyEvent(p2p.PeerDisconnectedEvent, pid)
+		}
+	}
+
 	cmw.ConnectionMonitor.Disconnected(netw, conn)
 }
 
+func (cmw *connectionMonitorWrapper) notifyEvent(eventType p2p.EventType, pid peer.ID) {
+	cmw.eventsNotifier.NotifyEvent(p2p.Event{
+		Type: eventType,
+		Peer: core.PeerID(pid),
+	})
+}
+
 // CheckConnectionsBlocking does a peer sweep, calling Close on those peers that are black listed
 func (cmw *connectionMonitorWrapper) CheckConnectionsBlocking() {
 	peers := cmw.network.Peers()
@@ -81,6 +240,7 @@ func (cmw *connectionMonitorWrapper) CheckConnectionsBlocking() {
 				"pid", pid.String(),
 			)
 			_ = cmw.network.ClosePeer(pid)
+			cmw.notifyEvent(p2p.PeerDeniedEvent, pid)
 		}
 	}
 }
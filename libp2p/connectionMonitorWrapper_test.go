@@ -2,12 +2,17 @@ package libp2p_test
 
 import (
 	"bytes"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/TerraDharitri/drt-go-chain-core/core"
 	"github.com/TerraDharitri/drt-go-chain-core/core/check"
 	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
 	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/denialEvaluator"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/disabled"
 	"github.com/TerraDharitri/drt-go-chain-p2p/mock"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -15,6 +20,8 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+const relayedThroughRelay5 = "/ip4/10.0.5.5/tcp/37373/p2p/16Uiu2HAm6yvbp1oZ6zjnWsn9FdRqBSaQkbhELyaThuq48ybdorrr/p2p-circuit"
+
 func createStubConn() *mock.ConnStub {
 	return &mock.ConnStub{
 		RemotePeerCalled: func() peer.ID {
@@ -30,6 +37,7 @@ func TestNewConnectionMonitorWrapper_ShouldWork(t *testing.T) {
 		&mock.NetworkStub{},
 		&mock.ConnectionMonitorStub{},
 		&mock.PeerDenialEvaluatorStub{},
+		&mock.EventsNotifierStub{},
 	)
 
 	assert.False(t, check.IfNil(cmw))
@@ -56,6 +64,7 @@ func TestConnectionMonitorNotifier_ConnectedBlackListedShouldCallClose(t *testin
 				return true
 			},
 		},
+		&mock.EventsNotifierStub{},
 	)
 
 	cmw.Connected(networkInstance, conn)
@@ -81,6 +90,7 @@ func TestConnectionMonitorNotifier_ConnectedNotBlackListedShouldCallConnected(t
 				return false
 			},
 		},
+		&mock.EventsNotifierStub{},
 	)
 
 	cmw.Connected(networkInstance, conn)
@@ -88,6 +98,170 @@ func TestConnectionMonitorNotifier_ConnectedNotBlackListedShouldCallConnected(t
 	assert.True(t, peerConnectedCalled)
 }
 
+func createStubConnFromAddress(pid peer.ID, address string) *mock.ConnStub {
+	return &mock.ConnStub{
+		RemotePeerCalled: func() peer.ID {
+			return pid
+		},
+		RemoteMultiaddrCalled: func() multiaddr.Multiaddr {
+			return multiaddr.StringCast(address)
+		},
+		CloseCalled: func() error {
+			return nil
+		},
+	}
+}
+
+func TestConnectionMonitorNotifier_ConnectedFromDeniedIPShouldCallClose(t *testing.T) {
+	t.Parallel()
+
+	evaluator, _ := denialEvaluator.NewSubnetDenialEvaluator(config.PeerDenialConfig{MaxTrackedPeers: 10})
+	_ = evaluator.UpsertIP(net.ParseIP("10.0.0.1"), time.Minute)
+
+	peerCloseCalled := false
+	conn := createStubConnFromAddress("pid", "/ip4/10.0.0.1/tcp/37373")
+	conn.CloseCalled = func() error {
+		peerCloseCalled = true
+
+		return nil
+	}
+	networkInstance := &mock.NetworkStub{}
+	cmw := libp2p.NewConnectionMonitorWrapper(
+		networkInstance,
+		&mock.ConnectionMonitorStub{
+			ConnectedCalled: func(netw network.Network, conn network.Conn) {
+				assert.Fail(t, "should have not called Connected")
+			},
+		},
+		evaluator,
+		&mock.EventsNotifierStub{},
+	)
+
+	cmw.Connected(networkInstance, conn)
+
+	assert.True(t, peerCloseCalled)
+	assert.True(t, evaluator.IsDenied("pid"))
+}
+
+func TestConnectionMonitorNotifier_ConnectedOverTheCapsShouldCallClose(t *testing.T) {
+	t.Parallel()
+
+	preferredPeer := peer.ID("preferred")
+	existingConns := []network.Conn{
+		createStubConnFromAddress("pid1", "/ip4/10.0.0.1/tcp/37373"),
+		createStubConnFromAddress("pid2", "/ip4/10.0.0.2/tcp/37373"),
+		createStubConnFromAddress(preferredPeer, "/ip4/10.0.0.3/tcp/37373"),
+		createStubConnFromAddress(preferredPeer, "/ip4/10.0.0.3/tcp/37374"),
+		createStubConnFromAddress("pid4", "/dns4/example.com/tcp/37373"),
+		createStubConnFromAddress("relayed pid1", relayedThroughRelay5),
+		createStubConnFromAddress("relayed pid2", relayedThroughRelay5),
+	}
+	networkInstance := &mock.NetworkStub{
+		ConnsCalled: func() []network.Conn {
+			return existingConns
+		},
+		ConnsToPeerCalled: func(p peer.ID) []network.Conn {
+			return nil
+		},
+	}
+	preferredPeersHolder := &mock.PeersHolderStub{
+		ContainsCalled: func(peerID core.PeerID) bool {
+			return peerID == core.PeerID(preferredPeer)
+		},
+	}
+
+	testConnected := func(cfg config.PeerDenialConfig, pid peer.ID, address string) bool {
+		isClosed := false
+		conn := createStubConnFromAddress(pid, address)
+		conn.CloseCalled = func() error {
+			isClosed = true
+			return nil
+		}
+		cmw := libp2p.NewConnectionMonitorWrapperWithCaps(
+			networkInstance,
+			&mock.ConnectionMonitorStub{},
+			&disabled.PeerDenialEvaluator{},
+			&mock.EventsNotifierStub{},
+			preferredPeersHolder,
+			cfg,
+		)
+		cmw.Connected(networkInstance, conn)
+
+		return isClosed
+	}
+
+	t.Run("caps disabled should not close", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, testConnected(config.PeerDenialConfig{}, "new pid", "/ip4/10.0.0.1/tcp/37375"))
+	})
+	t.Run("over the IP cap should close", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.PeerDenialConfig{MaxConnectionsPerIP: 1}
+		assert.True(t, testConnected(cfg, "new pid", "/ip4/10.0.0.1/tcp/37375"))
+		assert.False(t, testConnected(cfg, "new pid", "/ip4/10.0.0.4/tcp/37375"))
+	})
+	t.Run("over the subnet cap should close", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.PeerDenialConfig{MaxConnectionsPerSubnet: 2}
+		assert.True(t, testConnected(cfg, "new pid", "/ip4/10.0.0.4/tcp/37375"))
+		assert.False(t, testConnected(cfg, "new pid", "/ip4/10.0.1.4/tcp/37375"))
+	})
+	t.Run("preferred peers should not be capped nor counted", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.PeerDenialConfig{MaxConnectionsPerIP: 1}
+		assert.False(t, testConnected(cfg, preferredPeer, "/ip4/10.0.0.1/tcp/37375"))
+		assert.False(t, testConnected(cfg, "new pid", "/ip4/10.0.0.3/tcp/37375"))
+	})
+	t.Run("relayed connections should not be capped nor counted", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.PeerDenialConfig{MaxConnectionsPerIP: 1, MaxConnectionsPerSubnet: 2}
+		assert.False(t, testConnected(cfg, "new pid", relayedThroughRelay5))
+		assert.False(t, testConnected(cfg, "new pid", "/ip4/10.0.5.5/tcp/37375"))
+	})
+}
+
+func TestConnectionMonitorNotifier_ConnectedRelayedShouldNotTrackTheRelayIP(t *testing.T) {
+	t.Parallel()
+
+	evaluator, _ := denialEvaluator.NewSubnetDenialEvaluator(config.PeerDenialConfig{
+		MaxTrackedPeers:       10,
+		SubnetDenialThreshold: 1,
+	})
+	networkInstance := &mock.NetworkStub{
+		ConnsToPeerCalled: func(p peer.ID) []network.Conn {
+			return nil
+		},
+	}
+	cmw := libp2p.NewConnectionMonitorWrapper(
+		networkInstance,
+		&mock.ConnectionMonitorStub{},
+		evaluator,
+		&mock.EventsNotifierStub{},
+	)
+
+	cmw.Connected(networkInstance, createStubConnFromAddress("relayed pid", relayedThroughRelay5))
+	_ = evaluator.UpsertPeerID("relayed pid", time.Minute)
+
+	assert.True(t, evaluator.IsDenied("relayed pid"))
+	assert.False(t, evaluator.IsIPDenied(net.ParseIP("10.0.5.5")))
+	assert.False(t, evaluator.IsIPDenied(net.ParseIP("10.0.5.6")))
+
+	// the peer denial still applies when the peer connects again through the relay
+	peerCloseCalled := false
+	conn := createStubConnFromAddress("relayed pid", relayedThroughRelay5)
+	conn.CloseCalled = func() error {
+		peerCloseCalled = true
+		return nil
+	}
+	cmw.Connected(networkInstance, conn)
+	assert.True(t, peerCloseCalled)
+}
+
 // ------- Functions
 
 func TestConnectionMonitorNotifier_FunctionsShouldCallHandler(t *testing.T) {
@@ -110,6 +284,7 @@ func TestConnectionMonitorNotifier_FunctionsShouldCallHandler(t *testing.T) {
 			},
 		},
 		&mock.PeerDenialEvaluatorStub{},
+		&mock.EventsNotifierStub{},
 	)
 
 	cmw.Listen(nil, nil)
@@ -121,6 +296,49 @@ func TestConnectionMonitorNotifier_FunctionsShouldCallHandler(t *testing.T) {
 	assert.True(t, disconnectCalled)
 }
 
+func TestConnectionMonitorWrapper_EventsSequence(t *testing.T) {
+	t.Parallel()
+
+	deniedPeer := peer.ID("denied")
+	allowedPeer := peer.ID("allowed")
+	notifiedEvents := make([]p2p.Event, 0)
+	networkInstance := &mock.NetworkStub{
+		ConnsToPeerCalled: func(p peer.ID) []network.Conn {
+			return make([]network.Conn, 0)
+		},
+	}
+	cmw := libp2p.NewConnectionMonitorWrapper(
+		networkInstance,
+		&mock.ConnectionMonitorStub{},
+		&mock.PeerDenialEvaluatorStub{
+			IsDeniedCalled: func(pid core.PeerID) bool {
+				return pid == core.PeerID(deniedPeer)
+			},
+		},
+		&mock.EventsNotifierStub{
+			NotifyEventCalled: func(event p2p.Event) {
+				notifiedEvents = append(notifiedEvents, event)
+			},
+		},
+	)
+
+	deniedConn := createStubConnFromAddress(deniedPeer, "/ip4/10.0.0.1/tcp/37373")
+	allowedConn := createStubConnFromAddress(allowedPeer, "/ip4/10.0.0.2/tcp/37373")
+	cmw.Connected(networkInstance, deniedConn)
+	cmw.Disconnected(networkInstance, deniedConn)
+	cmw.Connected(networkInstance, allowedConn)
+	cmw.Connected(networkInstance, allowedConn)
+	cmw.Disconnected(networkInstance, allowedConn)
+	cmw.Disconnected(networkInstance, allowedConn)
+
+	expectedEvents := []p2p.Event{
+		{Type: p2p.PeerDeniedEvent, Peer: core.PeerID(deniedPeer)},
+		{Type: p2p.PeerConnectedEvent, Peer: core.PeerID(allowedPeer)},
+		{Type: p2p.PeerDisconnectedEvent, Peer: core.PeerID(allowedPeer)},
+	}
+	assert.Equal(t, expectedEvents, notifiedEvents)
+}
+
 // ------- SetBlackListHandler
 
 func TestConnectionMonitorWrapper_SetBlackListHandlerNilHandlerShouldErr(t *testing.T) {
@@ -130,6 +348,7 @@ func TestConnectionMonitorWrapper_SetBlackListHandlerNilHandlerShouldErr(t *test
 		&mock.NetworkStub{},
 		&mock.ConnectionMonitorStub{},
 		&mock.PeerDenialEvaluatorStub{},
+		&mock.EventsNotifierStub{},
 	)
 
 	err := cmw.SetPeerDenialEvaluator(nil)
@@ -144,6 +363,7 @@ func TestConnectionMonitorWrapper_SetBlackListHandlerShouldWork(t *testing.T) {
 		&mock.NetworkStub{},
 		&mock.ConnectionMonitorStub{},
 		&mock.PeerDenialEvaluatorStub{},
+		&mock.EventsNotifierStub{},
 	)
 	newPeerDenialEvaluator := &mock.PeerDenialEvaluatorStub{}
 
@@ -162,6 +382,7 @@ func TestConnectionMonitorWrapper_CheckConnectionsBlockingShouldWork(t *testing.
 	whiteListPeer := peer.ID("whitelisted")
 	blackListPeer := peer.ID("blacklisted")
 	closeCalled := 0
+	notifiedEvents := make([]p2p.Event, 0)
 	cmw := libp2p.NewConnectionMonitorWrapper(
 		&mock.NetworkStub{
 			PeersCall: func() []peer.ID {
@@ -183,8 +404,14 @@ func TestConnectionMonitorWrapper_CheckConnectionsBlockingShouldWork(t *testing.
 				return bytes.Equal(core.PeerID(blackListPeer).Bytes(), pid.Bytes())
 			},
 		},
+		&mock.EventsNotifierStub{
+			NotifyEventCalled: func(event p2p.Event) {
+				notifiedEvents = append(notifiedEvents, event)
+			},
+		},
 	)
 
 	cmw.CheckConnectionsBlocking()
 	assert.Equal(t, 1, closeCalled)
+	assert.Equal(t, []p2p.Event{{Type: p2p.PeerDeniedEvent, Peer: core.PeerID(blackListPeer)}}, notifiedEvents)
 }
@@ -0,0 +1,23 @@
+package denialEvaluator
+
+import (
+	"time"
+)
+
+const SweepInterval = sweepInterval
+
+// SetTimeHandler -
+func (evaluator *subnetDenialEvaluator) SetTimeHandler(handler func() time.Time) {
+	evaluator.mut.Lock()
+	evaluator.getTimeHandler = handler
+	evaluator.lastSweep = handler()
+	evaluator.mut.Unlock()
+}
+
+// NumDenials -
+func (evaluator *subnetDenialEvaluator) NumDenials() int {
+	evaluator.mut.Lock()
+	defer evaluator.mut.Unlock()
+
+	return len(evaluator.deniedPeers) + len(evaluator.deniedIPs) + len(evaluator.deniedSubnets)
+}
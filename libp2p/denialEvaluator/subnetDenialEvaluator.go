@@ -0,0 +1,246 @@
+package denialEvaluator
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	logger "github.com/TerraDharitri/drt-go-chain-logger"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	"github.com/TerraDharitri/drt-go-chain-storage/lrucache"
+	"github.com/TerraDharitri/drt-go-chain-storage/types"
+)
+
+var log = logger.GetOrCreate("p2p/libp2p/denialevaluator")
+
+var _ p2p.IPDenialEvaluator = (*subnetDenialEvaluator)(nil)
+
+const (
+	sweepInterval      = time.Minute
+	ipv4SubnetMaskSize = 24
+	ipv6SubnetMaskSize = 64
+)
+
+type subnetDenialEvaluator struct {
+	mut                   sync.Mutex
+	deniedPeers           map[string]time.Time
+	deniedIPs             map[string]time.Time
+	deniedSubnets         map[string]time.Time
+	peersIPs              types.Cacher
+	subnetDenialThreshold uint32
+	lastSweep             time.Time
+	getTimeHandler        func() time.Time
+}
+
+// NewSubnetDenialEvaluator creates a peer denial evaluator that also denies IPs and subnets. The denial of a peer ID
+// is extended to the last IP the peer was seen connecting from, so a host rotating its peer IDs stays denied. When
+// the number of denied IPs in the same subnet reaches the configured threshold, the whole subnet is denied
+func NewSubnetDenialEvaluator(cfg config.PeerDenialConfig) (*subnetDenialEvaluator, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MaxTrackedPeers == 0 {
+		return nil, fmt.Errorf("%w for MaxTrackedPeers, should be greater than 0", p2p.ErrInvalidValue)
+	}
+
+	peersIPs, err := lrucache.NewCache(int(cfg.MaxTrackedPeers))
+	if err != nil {
+		return nil, err
+	}
+
+	return &subnetDenialEvaluator{
+		deniedPeers:           make(map[string]time.Time),
+		deniedIPs:             make(map[string]time.Time),
+		deniedSubnets:         make(map[string]time.Time),
+		peersIPs:              peersIPs,
+		subnetDenialThreshold: cfg.SubnetDenialThreshold,
+		lastSweep:             time.Now(),
+		getTimeHandler:        time.Now,
+	}, nil
+}
+
+// SubnetKey returns the /24 subnet of an IPv4 address or the /64 subnet of an IPv6 address
+func SubnetKey(ip net.IP) string {
+	ipv4 := ip.To4()
+	if ipv4 != nil {
+		return fmt.Sprintf("%s/%d", ipv4.Mask(net.CIDRMask(ipv4SubnetMaskSize, 8*net.IPv4len)), ipv4SubnetMaskSize)
+	}
+
+	return fmt.Sprintf("%s/%d", ip.Mask(net.CIDRMask(ipv6SubnetMaskSize, 8*net.IPv6len)), ipv6SubnetMaskSize)
+}
+
+// IsDenied returns true if the peer ID is denied or if the last IP the peer was seen connecting from is denied
+func (evaluator *subnetDenialEvaluator) IsDenied(pid core.PeerID) bool {
+	evaluator.mut.Lock()
+	defer evaluator.mut.Unlock()
+
+	now := evaluator.getTimeHandler()
+	evaluator.sweepIfNeeded(now)
+
+	if isActive(evaluator.deniedPeers[string(pid)], now) {
+		return true
+	}
+
+	ip, found := evaluator.getPeerIP(pid)
+	if !found {
+		return false
+	}
+
+	return evaluator.isIPDenied(ip, now)
+}
+
+// IsIPDenied returns true if the IP or its subnet is denied
+func (evaluator *subnetDenialEvaluator) IsIPDenied(ip net.IP) bool {
+	if len(ip) == 0 {
+		return false
+	}
+
+	evaluator.mut.Lock()
+	defer evaluator.mut.Unlock()
+
+	now := evaluator.getTimeHandler()
+	evaluator.sweepIfNeeded(now)
+
+	return evaluator.isIPDenied(ip, now)
+}
+
+// IsConnectionDenied remembers the IP the peer connects from and returns true if the peer ID, the IP or its subnet
+// is denied
+func (evaluator *subnetDenialEvaluator) IsConnectionDenied(pid core.PeerID, ip net.IP) bool {
+	if len(ip) > 0 {
+		evaluator.peersIPs.Put([]byte(pid), ip, len(ip))
+	}
+
+	return evaluator.IsDenied(pid)
+}
+
+// UpsertPeerID denies the provided peer ID and the last IP the peer was seen connecting from for the provided
+// duration. A longer existing denial is kept
+func (evaluator *subnetDenialEvaluator) UpsertPeerID(pid core.PeerID, duration time.Duration) error {
+	if len(pid) == 0 {
+		return fmt.Errorf("%w, empty peer ID", p2p.ErrInvalidValue)
+	}
+
+	evaluator.mut.Lock()
+	defer evaluator.mut.Unlock()
+
+	expiry := evaluator.getTimeHandler().Add(duration)
+	upsertExpiry(evaluator.deniedPeers, string(pid), expiry)
+
+	ip, found := evaluator.getPeerIP(pid)
+	if found {
+		evaluator.upsertIP(ip, expiry)
+	}
+
+	return nil
+}
+
+// UpsertIP denies the provided IP for the provided duration. A longer existing denial is kept
+func (evaluator *subnetDenialEvaluator) UpsertIP(ip net.IP, duration time.Duration) error {
+	if len(ip) == 0 {
+		return fmt.Errorf("%w, empty IP", p2p.ErrInvalidValue)
+	}
+
+	evaluator.mut.Lock()
+	defer evaluator.mut.Unlock()
+
+	evaluator.upsertIP(ip, evaluator.getTimeHandler().Add(duration))
+
+	return nil
+}
+
+// UpsertSubnet denies the subnet of the provided IP for the provided duration. A longer existing denial is kept
+func (evaluator *subnetDenialEvaluator) UpsertSubnet(ip net.IP, duration time.Duration) error {
+	if len(ip) == 0 {
+		return fmt.Errorf("%w, empty IP", p2p.ErrInvalidValue)
+	}
+
+	evaluator.mut.Lock()
+	defer evaluator.mut.Unlock()
+
+	upsertExpiry(evaluator.deniedSubnets, SubnetKey(ip), evaluator.getTimeHandler().Add(duration))
+
+	return nil
+}
+
+func (evaluator *subnetDenialEvaluator) getPeerIP(pid core.PeerID) (net.IP, bool) {
+	value, found := evaluator.peersIPs.Get([]byte(pid))
+	if !found {
+		return nil, false
+	}
+
+	ip, ok := value.(net.IP)
+	return ip, ok
+}
+
+func (evaluator *subnetDenialEvaluator) isIPDenied(ip net.IP, now time.Time) bool {
+	return isActive(evaluator.deniedIPs[ip.String()], now) || isActive(evaluator.deniedSubnets[SubnetKey(ip)], now)
+}
+
+func (evaluator *subnetDenialEvaluator) upsertIP(ip net.IP, expiry time.Time) {
+	upsertExpiry(evaluator.deniedIPs, ip.String(), expiry)
+	if evaluator.subnetDenialThreshold == 0 {
+		return
+	}
+
+	subnet := SubnetKey(ip)
+	now := evaluator.getTimeHandler()
+	numDeniedIPs := uint32(0)
+	for deniedIP, deniedIPExpiry := range evaluator.deniedIPs {
+		parsedIP := net.ParseIP(deniedIP)
+		if isActive(deniedIPExpiry, now) && parsedIP != nil && SubnetKey(parsedIP) == subnet {
+			numDeniedIPs++
+		}
+	}
+	if numDeniedIPs < evaluator.subnetDenialThreshold {
+		return
+	}
+
+	log.Debug("too many denied IPs in the same subnet, denying the subnet",
+		"subnet", subnet,
+		"num denied IPs", numDeniedIPs,
+	)
+	upsertExpiry(evaluator.deniedSubnets, subnet, expiry)
+}
+
+func upsertExpiry(denials map[string]time.Time, key string, expiry time.Time) {
+	existingExpiry, found := denials[key]
+	if found && existingExpiry.After(expiry) {
+		return
+	}
+
+	denials[key] = expiry
+}
+
+func isActive(expiry time.Time, now time.Time) bool {
+	return now.Before(expiry)
+}
+
+// sweepIfNeeded removes the expired denials
+func (evaluator *subnetDenialEvaluator) sweepIfNeeded(now time.Time) {
+	if now.Sub(evaluator.lastSweep) < sweepInterval {
+		return
+	}
+	evaluator.lastSweep = now
+
+	sweepExpired(evaluator.deniedPeers, now)
+	sweepExpired(evaluator.deniedIPs, now)
+	sweepExpired(evaluator.deniedSubnets, now)
+}
+
+func sweepExpired(denials map[string]time.Time, now time.Time) {
+	for key, expiry := range denials {
+		if !isActive(expiry, now) {
+			delete(denials, key)
+		}
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (evaluator *subnetDenialEvaluator) IsInterfaceNil() bool {
+	return evaluator == nil
+}
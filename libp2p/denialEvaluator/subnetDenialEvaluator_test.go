@@ -0,0 +1,134 @@
+package denialEvaluator_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/denialEvaluator"
+	"github.com/stretchr/testify/assert"
+)
+
+func createMockConfig() config.PeerDenialConfig {
+	return config.PeerDenialConfig{
+		SubnetDenialThreshold: 3,
+		MaxTrackedPeers:       100,
+	}
+}
+
+func TestNewSubnetDenialEvaluator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zero MaxTrackedPeers should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createMockConfig()
+		cfg.MaxTrackedPeers = 0
+		evaluator, err := denialEvaluator.NewSubnetDenialEvaluator(cfg)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, check.IfNil(evaluator))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		evaluator, err := denialEvaluator.NewSubnetDenialEvaluator(createMockConfig())
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(evaluator))
+	})
+}
+
+func TestSubnetKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "10.1.2.0/24", denialEvaluator.SubnetKey(net.ParseIP("10.1.2.3")))
+	assert.Equal(t, "2001:db8:1:2::/64", denialEvaluator.SubnetKey(net.ParseIP("2001:db8:1:2:3:4:5:6")))
+}
+
+func TestSubnetDenialEvaluator_UpsertShouldErrOnEmptyValues(t *testing.T) {
+	t.Parallel()
+
+	evaluator, _ := denialEvaluator.NewSubnetDenialEvaluator(createMockConfig())
+
+	assert.True(t, errors.Is(evaluator.UpsertPeerID("", time.Minute), p2p.ErrInvalidValue))
+	assert.True(t, errors.Is(evaluator.UpsertIP(nil, time.Minute), p2p.ErrInvalidValue))
+	assert.True(t, errors.Is(evaluator.UpsertSubnet(nil, time.Minute), p2p.ErrInvalidValue))
+	assert.False(t, evaluator.IsIPDenied(nil))
+}
+
+func TestSubnetDenialEvaluator_DeniedPeerShouldDenyItsLastIP(t *testing.T) {
+	t.Parallel()
+
+	evaluator, _ := denialEvaluator.NewSubnetDenialEvaluator(createMockConfig())
+	deniedIP := net.ParseIP("10.0.0.1")
+	otherIP := net.ParseIP("10.0.0.2")
+
+	assert.False(t, evaluator.IsConnectionDenied("pid", deniedIP))
+	assert.Nil(t, evaluator.UpsertPeerID("pid", time.Minute))
+
+	assert.True(t, evaluator.IsDenied("pid"))
+	assert.True(t, evaluator.IsIPDenied(deniedIP))
+	assert.False(t, evaluator.IsIPDenied(otherIP))
+
+	// a new peer ID from the same host is denied, a new peer ID from another host is not
+	assert.True(t, evaluator.IsConnectionDenied("rotated pid", deniedIP))
+	assert.True(t, evaluator.IsDenied("rotated pid"))
+	assert.False(t, evaluator.IsConnectionDenied("other pid", otherIP))
+}
+
+func TestSubnetDenialEvaluator_DenialsShouldExpire(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Unix(1000000, 0)
+	evaluator, _ := denialEvaluator.NewSubnetDenialEvaluator(createMockConfig())
+	evaluator.SetTimeHandler(func() time.Time {
+		return currentTime
+	})
+	ip := net.ParseIP("10.0.0.1")
+
+	assert.Nil(t, evaluator.UpsertPeerID("pid", time.Minute*2))
+	assert.Nil(t, evaluator.UpsertIP(ip, time.Minute))
+	// a shorter denial does not shorten the existing one
+	assert.Nil(t, evaluator.UpsertPeerID("pid", time.Second))
+
+	currentTime = currentTime.Add(time.Minute)
+	assert.True(t, evaluator.IsDenied("pid"))
+	assert.False(t, evaluator.IsIPDenied(ip))
+
+	currentTime = currentTime.Add(time.Minute)
+	assert.False(t, evaluator.IsDenied("pid"))
+
+	currentTime = currentTime.Add(denialEvaluator.SweepInterval)
+	assert.False(t, evaluator.IsDenied("pid"))
+	assert.Equal(t, 0, evaluator.NumDenials())
+}
+
+func TestSubnetDenialEvaluator_TooManyDeniedIPsShouldDenyTheSubnet(t *testing.T) {
+	t.Parallel()
+
+	evaluator, _ := denialEvaluator.NewSubnetDenialEvaluator(createMockConfig())
+
+	assert.Nil(t, evaluator.UpsertIP(net.ParseIP("10.0.0.1"), time.Minute))
+	assert.Nil(t, evaluator.UpsertIP(net.ParseIP("10.0.1.1"), time.Minute))
+	assert.Nil(t, evaluator.UpsertIP(net.ParseIP("10.0.0.2"), time.Minute))
+	assert.False(t, evaluator.IsIPDenied(net.ParseIP("10.0.0.3")))
+
+	assert.Nil(t, evaluator.UpsertIP(net.ParseIP("10.0.0.4"), time.Minute))
+	assert.True(t, evaluator.IsIPDenied(net.ParseIP("10.0.0.3")))
+	assert.True(t, evaluator.IsConnectionDenied(core.PeerID("pid"), net.ParseIP("10.0.0.200")))
+	assert.False(t, evaluator.IsIPDenied(net.ParseIP("10.0.1.2")))
+}
+
+func TestSubnetDenialEvaluator_UpsertSubnetShouldDenyTheWholeSubnet(t *testing.T) {
+	t.Parallel()
+
+	evaluator, _ := denialEvaluator.NewSubnetDenialEvaluator(createMockConfig())
+
+	assert.Nil(t, evaluator.UpsertSubnet(net.ParseIP("2001:db8::1"), time.Minute))
+	assert.True(t, evaluator.IsIPDenied(net.ParseIP("2001:db8::ffff:1")))
+	assert.False(t, evaluator.IsIPDenied(net.ParseIP("2001:db8:0:1::1")))
+}
@@ -0,0 +1,17 @@
+package disabled
+
+import "github.com/TerraDharitri/drt-go-chain-core/core"
+
+// PeerTopicRateLimiter is a disabled implementation of the inbound rate limiter that allows all messages
+type PeerTopicRateLimiter struct {
+}
+
+// Allow returns true and never asks for the peer to be denied
+func (limiter *PeerTopicRateLimiter) Allow(_ core.PeerID, _ string) (bool, bool) {
+	return true, false
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (limiter *PeerTopicRateLimiter) IsInterfaceNil() bool {
+	return limiter == nil
+}
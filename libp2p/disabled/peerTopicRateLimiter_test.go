@@ -0,0 +1,20 @@
+package disabled_test
+
+import (
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/disabled"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerTopicRateLimiter_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	limiter := &disabled.PeerTopicRateLimiter{}
+
+	assert.False(t, check.IfNil(limiter))
+	isAllowed, shouldDeny := limiter.Allow("", "")
+	assert.True(t, isAllowed)
+	assert.False(t, shouldDeny)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/TerraDharitri/drt-go-chain-core/core/check"
 	logger "github.com/TerraDharitri/drt-go-chain-logger"
 	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	kbucket "github.com/libp2p/go-libp2p-kbucket"
 	"github.com/libp2p/go-libp2p/core/protocol"
@@ -28,12 +29,14 @@ type ArgKadDht struct {
 	Host                        ConnectableHost
 	PeersRefreshInterval        time.Duration
 	SeedersReconnectionInterval time.Duration
+	SeedersMaxBackoff           time.Duration
 	ProtocolID                  string
 	InitialPeersList            []string
 	BucketSize                  uint32
 	RoutingTableRefresh         time.Duration
 	KddSharder                  p2p.Sharder
 	ConnectionWatcher           p2p.ConnectionsWatcher
+	Rendezvous                  config.RendezvousConfig
 }
 
 // ContinuousKadDhtDiscoverer is the kad-dht discovery type implementation
@@ -45,14 +48,16 @@ type ContinuousKadDhtDiscoverer struct {
 	kadDHT        *dht.IpfsDHT
 	refreshCancel context.CancelFunc
 
-	peersRefreshInterval time.Duration
-	protocolID           string
-	initialPeersList     []string
-	bucketSize           uint32
-	routingTableRefresh  time.Duration
-	hostConnManagement   *hostWithConnectionManagement
-	sharder              Sharder
-	connectionWatcher    p2p.ConnectionsWatcher
+	mutPeersRefreshInterval sync.RWMutex
+	peersRefreshInterval    time.Duration
+	protocolID              string
+	initialPeersList        []string
+	seedersTracker          *seedersTracker
+	bucketSize              uint32
+	routingTableRefresh     time.Duration
+	hostConnManagement      *hostWithConnectionManagement
+	sharder                 Sharder
+	connectionWatcher       p2p.ConnectionsWatcher
 }
 
 // NewContinuousKadDhtDiscoverer creates a new kad-dht discovery type implementation
@@ -72,6 +77,7 @@ func NewContinuousKadDhtDiscoverer(arg ArgKadDht) (*ContinuousKadDhtDiscoverer,
 		peersRefreshInterval: arg.PeersRefreshInterval,
 		protocolID:           arg.ProtocolID,
 		initialPeersList:     arg.InitialPeersList,
+		seedersTracker:       newSeedersTracker(arg.InitialPeersList, arg.SeedersMaxBackoff),
 		bucketSize:           arg.BucketSize,
 		routingTableRefresh:  arg.RoutingTableRefresh,
 		connectionWatcher:    arg.ConnectionWatcher,
@@ -95,8 +101,9 @@ func prepareArguments(arg ArgKadDht) (Sharder, error) {
 	if !ok {
 		return nil, fmt.Errorf("%w for sharder: expected discovery.Sharder type of interface", p2p.ErrWrongTypeAssertion)
 	}
-	if arg.PeersRefreshInterval < time.Second {
-		return nil, fmt.Errorf("%w, PeersRefreshInterval should have been at least 1 second", p2p.ErrInvalidValue)
+	err := checkPeersRefreshInterval(arg.PeersRefreshInterval)
+	if err != nil {
+		return nil, err
 	}
 	if arg.RoutingTableRefresh < time.Second {
 		return nil, fmt.Errorf("%w, RoutingTableRefresh should have been at least 1 second", p2p.ErrInvalidValue)
@@ -110,6 +117,14 @@ func prepareArguments(arg ArgKadDht) (Sharder, error) {
 	return sharder, nil
 }
 
+func checkPeersRefreshInterval(interval time.Duration) error {
+	if interval < time.Second {
+		return fmt.Errorf("%w, PeersRefreshInterval should have been at least 1 second", p2p.ErrInvalidValue)
+	}
+
+	return nil
+}
+
 // Bootstrap will start the bootstrapping new peers process
 func (ckdd *ContinuousKadDhtDiscoverer) Bootstrap() error {
 	ckdd.mutKadDht.Lock()
@@ -176,7 +191,7 @@ func (ckdd *ContinuousKadDhtDiscoverer) stopDHT() error {
 
 func (ckdd *ContinuousKadDhtDiscoverer) connectToInitialAndBootstrap(ctx context.Context) {
 	chanStartBootstrap := ckdd.connectToOnePeerFromInitialPeersList(
-		ckdd.peersRefreshInterval,
+		ckdd.getPeersRefreshInterval(),
 		ckdd.initialPeersList,
 	)
 
@@ -206,7 +221,7 @@ func (ckdd *ContinuousKadDhtDiscoverer) bootstrap(ctx context.Context) {
 		}
 
 		select {
-		case <-time.After(ckdd.peersRefreshInterval):
+		case <-time.After(ckdd.getPeersRefreshInterval()):
 		case <-ctx.Done():
 			log.Debug("closing the p2p bootstrapping process")
 			return
@@ -238,26 +253,37 @@ func (ckdd *ContinuousKadDhtDiscoverer) tryConnectToSeeder(
 ) {
 
 	startIndex := 0
+	numSkipped := 0
 
 	for {
 		initialPeer := initialPeersList[startIndex]
-		err := ckdd.host.ConnectToPeer(ckdd.context, initialPeer)
-		if err != nil {
+		startIndex++
+		startIndex = startIndex % len(initialPeersList)
+
+		if isIsolated(ckdd.host) || ckdd.seedersTracker.canAttempt(initialPeer) {
+			err := ckdd.host.ConnectToPeer(ckdd.context, initialPeer)
+			ckdd.seedersTracker.recordAttempt(initialPeer, err)
+			if err == nil {
+				log.Debug("connected to seeder", "address", initialPeer)
+				break
+			}
+
 			printConnectionErrorToSeeder(initialPeer, err)
-			startIndex++
-			startIndex = startIndex % len(initialPeersList)
-			select {
-			case <-ckdd.context.Done():
-				log.Debug("context done in ContinuousKadDhtDiscoverer")
-				return
-			case <-time.After(intervalBetweenAttempts):
+		} else {
+			// the seeders that are backing off are skipped without waiting, unless all of them are backing off
+			numSkipped++
+			if numSkipped < len(initialPeersList) {
 				continue
 			}
-		} else {
-			log.Debug("connected to seeder", "address", initialPeer)
 		}
 
-		break
+		numSkipped = 0
+		select {
+		case <-ckdd.context.Done():
+			log.Debug("context done in ContinuousKadDhtDiscoverer")
+			return
+		case <-time.After(intervalBetweenAttempts):
+		}
 	}
 	chanDone <- struct{}{}
 }
@@ -286,12 +312,41 @@ func (ckdd *ContinuousKadDhtDiscoverer) Name() string {
 // ReconnectToNetwork will try to connect to one peer from the initial peer list
 func (ckdd *ContinuousKadDhtDiscoverer) ReconnectToNetwork(ctx context.Context) {
 	select {
-	case <-ckdd.connectToOnePeerFromInitialPeersList(ckdd.peersRefreshInterval, ckdd.initialPeersList):
+	case <-ckdd.connectToOnePeerFromInitialPeersList(ckdd.getPeersRefreshInterval(), ckdd.initialPeersList):
 	case <-ctx.Done():
 		return
 	}
 }
 
+// SeedersStatus returns the connection health of each seeder
+func (ckdd *ContinuousKadDhtDiscoverer) SeedersStatus() []p2p.SeederStatus {
+	return ckdd.seedersTracker.status(func(address string) bool {
+		return isConnectedToAddress(ckdd.host, address)
+	})
+}
+
+// SetPeersRefreshInterval sets the interval between two consecutive peers discovery rounds. The new value is used
+// starting with the next round
+func (ckdd *ContinuousKadDhtDiscoverer) SetPeersRefreshInterval(interval time.Duration) error {
+	err := checkPeersRefreshInterval(interval)
+	if err != nil {
+		return err
+	}
+
+	ckdd.mutPeersRefreshInterval.Lock()
+	ckdd.peersRefreshInterval = interval
+	ckdd.mutPeersRefreshInterval.Unlock()
+
+	return nil
+}
+
+func (ckdd *ContinuousKadDhtDiscoverer) getPeersRefreshInterval() time.Duration {
+	ckdd.mutPeersRefreshInterval.RLock()
+	defer ckdd.mutPeersRefreshInterval.RUnlock()
+
+	return ckdd.peersRefreshInterval
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (ckdd *ContinuousKadDhtDiscoverer) IsInterfaceNil() bool {
 	return ckdd == nil
@@ -3,6 +3,7 @@ package discovery_test
 import (
 	"context"
 	"errors"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -12,7 +13,10 @@ import (
 	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/discovery"
 	"github.com/TerraDharitri/drt-go-chain-p2p/mock"
 	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var timeoutWaitResponses = 2 * time.Second
@@ -252,6 +256,7 @@ func TestContinuousKadDhtDiscoverer_ConnectToOnePeerFromInitialPeersOnePeerShoul
 		},
 	}
 	ckdd, _ := discovery.NewContinuousKadDhtDiscoverer(arg)
+	ckdd.SetSeedersBackoffLimits(time.Millisecond, time.Millisecond)
 
 	chanDone := ckdd.ConnectToOnePeerFromInitialPeersList(time.Millisecond*10, []string{peerID})
 
@@ -306,6 +311,7 @@ func TestContinuousKadDhtDiscoverer_ConnectToOnePeerFromInitialPeersTwoPeersShou
 		},
 	}
 	ckdd, _ := discovery.NewContinuousKadDhtDiscoverer(arg)
+	ckdd.SetSeedersBackoffLimits(time.Millisecond, time.Millisecond)
 
 	chanDone := ckdd.ConnectToOnePeerFromInitialPeersList(time.Millisecond*10, []string{peerID1, peerID2})
 
@@ -316,6 +322,116 @@ func TestContinuousKadDhtDiscoverer_ConnectToOnePeerFromInitialPeersTwoPeersShou
 	}
 }
 
+func TestContinuousKadDhtDiscoverer_ConnectToOnePeerFromInitialPeersShouldSkipTheSeedersBackingOff(t *testing.T) {
+	t.Parallel()
+
+	arg := createTestArgument()
+	peerID1 := "peer1"
+	peerID2 := "peer2"
+	numConnectCalls := map[string]int{}
+	mutConnectCalls := sync.Mutex{}
+	errDidNotConnect := errors.New("did not connect")
+	arg.Host = &mock.ConnectableHostStub{
+		ConnectToPeerCalled: func(ctx context.Context, address string) error {
+			mutConnectCalls.Lock()
+			defer mutConnectCalls.Unlock()
+
+			numConnectCalls[address]++
+			if address == peerID2 {
+				return nil
+			}
+
+			return errDidNotConnect
+		},
+		AddressToPeerInfoCalled: func(address string) (*peer.AddrInfo, error) {
+			return &peer.AddrInfo{ID: peer.ID(address)}, nil
+		},
+		NetworkCalled: func() network.Network {
+			return &mock.NetworkStub{
+				ConnectednessCalled: func(pid peer.ID) network.Connectedness {
+					if pid == peer.ID(peerID2) {
+						return network.Connected
+					}
+
+					return network.NotConnected
+				},
+				PeersCall: func() []peer.ID {
+					return []peer.ID{peer.ID(peerID2)}
+				},
+			}
+		},
+	}
+	ckdd, _ := discovery.NewContinuousKadDhtDiscoverer(arg)
+
+	// the second round should not try the first seeder again as it is backing off
+	for i := 0; i < 2; i++ {
+		chanDone := ckdd.ConnectToOnePeerFromInitialPeersList(time.Millisecond*10, []string{peerID1, peerID2})
+		select {
+		case <-chanDone:
+		case <-time.After(timeoutWaitResponses):
+			assert.Fail(t, "timeout")
+		}
+	}
+
+	mutConnectCalls.Lock()
+	assert.Equal(t, 1, numConnectCalls[peerID1])
+	assert.Equal(t, 2, numConnectCalls[peerID2])
+	mutConnectCalls.Unlock()
+
+	status := ckdd.SeedersStatus()
+	require.Equal(t, 2, len(status))
+	assert.Equal(t, peerID1, status[0].Address)
+	assert.False(t, status[0].IsConnected)
+	assert.True(t, status[0].IsBackingOff)
+	assert.Equal(t, uint64(1), status[0].NumFailures)
+	assert.Equal(t, errDidNotConnect.Error(), status[0].LastError)
+	assert.Equal(t, peerID2, status[1].Address)
+	assert.True(t, status[1].IsConnected)
+	assert.Equal(t, uint64(2), status[1].NumSuccesses)
+}
+
+func TestContinuousKadDhtDiscoverer_ConnectToOnePeerFromInitialPeersIsolatedShouldNotSkipTheSeedersBackingOff(t *testing.T) {
+	t.Parallel()
+
+	arg := createTestArgument()
+	peerID1 := "peer1"
+	peerID2 := "peer2"
+	numConnectCalls := map[string]int{}
+	mutConnectCalls := sync.Mutex{}
+	arg.Host = &mock.ConnectableHostStub{
+		ConnectToPeerCalled: func(ctx context.Context, address string) error {
+			mutConnectCalls.Lock()
+			defer mutConnectCalls.Unlock()
+
+			numConnectCalls[address]++
+			if address == peerID2 {
+				return nil
+			}
+
+			return errors.New("did not connect")
+		},
+		AddressToPeerInfoCalled: func(address string) (*peer.AddrInfo, error) {
+			return &peer.AddrInfo{ID: peer.ID(address)}, nil
+		},
+	}
+	ckdd, _ := discovery.NewContinuousKadDhtDiscoverer(arg)
+
+	// the node has no connection so the first seeder is tried again even if it is backing off
+	for i := 0; i < 2; i++ {
+		chanDone := ckdd.ConnectToOnePeerFromInitialPeersList(time.Millisecond*10, []string{peerID1, peerID2})
+		select {
+		case <-chanDone:
+		case <-time.After(timeoutWaitResponses):
+			assert.Fail(t, "timeout")
+		}
+	}
+
+	mutConnectCalls.Lock()
+	assert.Equal(t, 2, numConnectCalls[peerID1])
+	assert.Equal(t, 2, numConnectCalls[peerID2])
+	mutConnectCalls.Unlock()
+}
+
 func TestContinuousKadDhtDiscoverer_Name(t *testing.T) {
 	t.Parallel()
 
@@ -324,3 +440,18 @@ func TestContinuousKadDhtDiscoverer_Name(t *testing.T) {
 
 	assert.Equal(t, discovery.KadDhtName, kdd.Name())
 }
+
+func TestContinuousKadDhtDiscoverer_SetPeersRefreshInterval(t *testing.T) {
+	t.Parallel()
+
+	arg := createTestArgument()
+	kdd, _ := discovery.NewContinuousKadDhtDiscoverer(arg)
+
+	err := kdd.SetPeersRefreshInterval(time.Millisecond)
+	assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	assert.Equal(t, arg.PeersRefreshInterval, kdd.GetPeersRefreshInterval())
+
+	err = kdd.SetPeersRefreshInterval(time.Second * 5)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Second*5, kdd.GetPeersRefreshInterval())
+}
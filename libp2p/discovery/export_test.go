@@ -5,6 +5,8 @@ import (
 	"time"
 
 	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	coreDiscovery "github.com/libp2p/go-libp2p/core/discovery"
+	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
 )
 
 const KadDhtName = kadDhtName
@@ -48,6 +50,7 @@ func NewOptimizedKadDhtDiscovererWithInitFunc(
 		seedersReconnectionInterval: arg.SeedersReconnectionInterval,
 		protocolID:                  arg.ProtocolID,
 		initialPeersList:            arg.InitialPeersList,
+		seedersTracker:              newSeedersTracker(arg.InitialPeersList, arg.SeedersMaxBackoff),
 		bucketSize:                  arg.BucketSize,
 		routingTableRefresh:         arg.RoutingTableRefresh,
 		status:                      statNotInitialized,
@@ -71,3 +74,98 @@ func NewOptimizedKadDhtDiscovererWithInitFunc(
 
 	return okdd, nil
 }
+
+// SetSeedersBackoffLimits -
+func (ckdd *ContinuousKadDhtDiscoverer) SetSeedersBackoffLimits(minBackoff time.Duration, maxBackoff time.Duration) {
+	ckdd.seedersTracker.setBackoffLimits(minBackoff, maxBackoff)
+}
+
+// SetSeedersBackoffLimits -
+func (okdd *optimizedKadDhtDiscoverer) SetSeedersBackoffLimits(minBackoff time.Duration, maxBackoff time.Duration) {
+	okdd.seedersTracker.setBackoffLimits(minBackoff, maxBackoff)
+}
+
+// GetPeersRefreshInterval -
+func (ckdd *ContinuousKadDhtDiscoverer) GetPeersRefreshInterval() time.Duration {
+	return ckdd.getPeersRefreshInterval()
+}
+
+// GetPeersRefreshInterval -
+func (okdd *optimizedKadDhtDiscoverer) GetPeersRefreshInterval() time.Duration {
+	return okdd.getPeersRefreshInterval()
+}
+
+// ------- mdnsDiscoverer
+
+const MdnsName = mdnsName
+
+// SetMdnsServiceCreator -
+func (md *mdnsDiscoverer) SetMdnsServiceCreator(createMdnsService func(notifee mdns.Notifee) MdnsService) {
+	md.createMdnsService = createMdnsService
+}
+
+// ------- rendezvousProcessor
+
+// NewRendezvousProcessor -
+func NewRendezvousProcessor(
+	routingDiscovery coreDiscovery.Discovery,
+	sharder RendezvousSharder,
+	argsHost ArgsHostWithConnectionManagement,
+	namespacePrefix string,
+	maxPeersPerQuery uint32,
+) (*rendezvousProcessor, error) {
+	hostConnManagement, err := NewHostWithConnectionManagement(argsHost)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRendezvousProcessor(argsRendezvousProcessor{
+		routingDiscovery:   routingDiscovery,
+		sharder:            sharder,
+		hostConnManagement: hostConnManagement,
+		namespacePrefix:    namespacePrefix,
+		maxPeersPerQuery:   maxPeersPerQuery,
+	})
+}
+
+// ProcessRound -
+func (rp *rendezvousProcessor) ProcessRound(ctx context.Context) {
+	rp.processRound(ctx)
+}
+
+// SetTimeHandler -
+func (rp *rendezvousProcessor) SetTimeHandler(handler func() time.Time) {
+	rp.getTimeHandler = handler
+}
+
+// ------- seedersTracker
+
+// NewSeedersTracker -
+func NewSeedersTracker(addresses []string, maxBackoff time.Duration) *seedersTracker {
+	return newSeedersTracker(addresses, maxBackoff)
+}
+
+// SetTimeHandler -
+func (tracker *seedersTracker) SetTimeHandler(handler func() time.Time) {
+	tracker.getTimeHandler = handler
+}
+
+// SetRandomHandler -
+func (tracker *seedersTracker) SetRandomHandler(handler func() float64) {
+	tracker.getRandomHandler = handler
+}
+
+// CanAttempt -
+func (tracker *seedersTracker) CanAttempt(address string) bool {
+	return tracker.canAttempt(address)
+}
+
+// RecordAttempt -
+func (tracker *seedersTracker) RecordAttempt(address string, err error) {
+	tracker.recordAttempt(address, err)
+}
+
+// Status -
+func (tracker *seedersTracker) Status(isConnected func(address string) bool) []p2p.SeederStatus {
+	return tracker.status(isConnected)
+}
@@ -32,6 +32,16 @@ func NewPeerDiscoverer(args ArgsPeerDiscoverer) (p2p.PeerDiscoverer, error) {
 	if args.P2pConfig.KadDhtPeerDiscovery.Enabled {
 		return createKadDhtPeerDiscoverer(args)
 	}
+	if args.P2pConfig.MDNSDiscovery.Enabled {
+		log.Debug("using mDNS discoverer", "service name", args.P2pConfig.MDNSDiscovery.ServiceName)
+		return discovery.NewMdnsDiscoverer(discovery.ArgMdnsDiscoverer{
+			Context:           args.Context,
+			Host:              args.Host,
+			ServiceName:       args.P2pConfig.MDNSDiscovery.ServiceName,
+			Sharder:           args.Sharder,
+			ConnectionWatcher: args.ConnectionsWatcher,
+		})
+	}
 
 	log.Debug("using nil discoverer")
 	return discovery.NewNilDiscoverer(), nil
@@ -44,15 +54,17 @@ func createKadDhtPeerDiscoverer(args ArgsPeerDiscoverer) (p2p.PeerDiscoverer, er
 		KddSharder:                  args.Sharder,
 		PeersRefreshInterval:        time.Second * time.Duration(args.P2pConfig.KadDhtPeerDiscovery.RefreshIntervalInSec),
 		SeedersReconnectionInterval: defaultSeedersReconnectionInterval,
+		SeedersMaxBackoff:           time.Second * time.Duration(args.P2pConfig.KadDhtPeerDiscovery.SeedersMaxBackoffInSec),
 		ProtocolID:                  args.P2pConfig.KadDhtPeerDiscovery.ProtocolID,
 		InitialPeersList:            args.P2pConfig.KadDhtPeerDiscovery.InitialPeerList,
 		BucketSize:                  args.P2pConfig.KadDhtPeerDiscovery.BucketSize,
 		RoutingTableRefresh:         time.Second * time.Duration(args.P2pConfig.KadDhtPeerDiscovery.RoutingTableRefreshIntervalInSec),
 		ConnectionWatcher:           args.ConnectionsWatcher,
+		Rendezvous:                  args.P2pConfig.KadDhtPeerDiscovery.Rendezvous,
 	}
 
 	switch args.P2pConfig.Sharding.Type {
-	case p2p.ListsSharder, p2p.OneListSharder, p2p.NilListSharder:
+	case p2p.ListsSharder, p2p.OneListSharder, p2p.NilListSharder, p2p.DiversityListsSharder, p2p.LatencyListsSharder:
 		return createKadDhtDiscoverer(args.P2pConfig, arg)
 	default:
 		return nil, fmt.Errorf("%w unable to select peer discoverer based on "+
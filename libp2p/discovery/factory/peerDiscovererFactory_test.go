@@ -91,6 +91,28 @@ func TestNewPeerDiscoverer_OptimizedKadDhtShouldWork(t *testing.T) {
 	assert.Equal(t, "optimized kad-dht discovery", pDiscoverer.Name())
 }
 
+func TestNewPeerDiscoverer_MDNSShouldWork(t *testing.T) {
+	t.Parallel()
+
+	args := factory.ArgsPeerDiscoverer{
+		Context: context.Background(),
+		Host:    &mock.ConnectableHostStub{},
+		Sharder: &mock.KadSharderStub{},
+		P2pConfig: config.P2PConfig{
+			MDNSDiscovery: config.MDNSDiscoveryConfig{
+				Enabled:     true,
+				ServiceName: "drt-devnet",
+			},
+		},
+		ConnectionsWatcher: &mock.ConnectionsWatcherStub{},
+	}
+	pDiscoverer, err := factory.NewPeerDiscoverer(args)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, pDiscoverer)
+	assert.Equal(t, "mDNS local network discovery", pDiscoverer.Name())
+}
+
 func TestNewPeerDiscoverer_UnknownSharderShouldErr(t *testing.T) {
 	t.Parallel()
 
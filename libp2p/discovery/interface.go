@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/TerraDharitri/drt-go-chain-core/core"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
@@ -25,7 +26,20 @@ type Sharder interface {
 	IsInterfaceNil() bool
 }
 
+// RendezvousSharder defines the sharder able to tell which categories of peers are under quota
+type RendezvousSharder interface {
+	ComputeMissingPeers(pidList []peer.ID) []p2p.PeersCategoryQuota
+	GetSelfPeerInfo() core.P2PPeerInfo
+	IsInterfaceNil() bool
+}
+
 // KadDhtHandler defines the behavior of a component that can find new peers in a p2p network through kad dht mechanism
 type KadDhtHandler interface {
 	Bootstrap(ctx context.Context) error
 }
+
+// MdnsService defines the behavior of the service that advertises the host and finds the peers on the local network
+type MdnsService interface {
+	Start() error
+	Close() error
+}
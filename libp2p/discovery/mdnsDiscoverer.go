@@ -0,0 +1,179 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
+)
+
+var _ p2p.PeerDiscoverer = (*mdnsDiscoverer)(nil)
+var _ p2p.Reconnecter = (*mdnsDiscoverer)(nil)
+var _ mdns.Notifee = (*mdnsDiscoverer)(nil)
+
+const mdnsName = "mDNS local network discovery"
+const mdnsConnectionTimeout = time.Second * 10
+
+// ArgMdnsDiscoverer represents the mDNS discoverer argument DTO
+type ArgMdnsDiscoverer struct {
+	Context           context.Context
+	Host              ConnectableHost
+	ServiceName       string
+	Sharder           p2p.Sharder
+	ConnectionWatcher p2p.ConnectionsWatcher
+}
+
+type mdnsDiscoverer struct {
+	ctx                context.Context
+	hostConnManagement *hostWithConnectionManagement
+	serviceName        string
+	mutService         sync.Mutex
+	service            MdnsService
+	createMdnsService  func(notifee mdns.Notifee) MdnsService
+	mutFoundPeers      sync.RWMutex
+	foundPeers         map[peer.ID]peer.AddrInfo
+}
+
+// NewMdnsDiscoverer creates a peer discoverer that finds the peers advertising the same service name on the local
+// network. The connections to the found peers are opened only if the sharder allows them
+func NewMdnsDiscoverer(arg ArgMdnsDiscoverer) (*mdnsDiscoverer, error) {
+	if check.IfNilReflect(arg.Context) {
+		return nil, p2p.ErrNilContext
+	}
+	if len(arg.ServiceName) == 0 {
+		return nil, fmt.Errorf("%w, empty mDNS service name", p2p.ErrInvalidValue)
+	}
+	if check.IfNil(arg.Sharder) {
+		return nil, p2p.ErrNilSharder
+	}
+	sharder, ok := arg.Sharder.(Sharder)
+	if !ok {
+		return nil, fmt.Errorf("%w for sharder: expected discovery.Sharder type of interface", p2p.ErrWrongTypeAssertion)
+	}
+
+	hostConnManagement, err := NewHostWithConnectionManagement(ArgsHostWithConnectionManagement{
+		ConnectableHost:    arg.Host,
+		Sharder:            sharder,
+		ConnectionsWatcher: arg.ConnectionWatcher,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	md := &mdnsDiscoverer{
+		ctx:                arg.Context,
+		hostConnManagement: hostConnManagement,
+		serviceName:        arg.ServiceName,
+		foundPeers:         make(map[peer.ID]peer.AddrInfo),
+	}
+	md.createMdnsService = md.createLibp2pMdnsService
+
+	return md, nil
+}
+
+func (md *mdnsDiscoverer) createLibp2pMdnsService(notifee mdns.Notifee) MdnsService {
+	return mdns.NewMdnsService(md.hostConnManagement, md.serviceName, notifee)
+}
+
+// Bootstrap starts advertising the host and querying for the other peers on the local network
+func (md *mdnsDiscoverer) Bootstrap() error {
+	md.mutService.Lock()
+	defer md.mutService.Unlock()
+
+	if md.service != nil {
+		return p2p.ErrPeerDiscoveryProcessAlreadyStarted
+	}
+
+	service := md.createMdnsService(md)
+	err := service.Start()
+	if err != nil {
+		return err
+	}
+
+	md.service = service
+	go md.closeServiceOnContextDone(service)
+
+	return nil
+}
+
+func (md *mdnsDiscoverer) closeServiceOnContextDone(service MdnsService) {
+	<-md.ctx.Done()
+
+	log.Debug("closing the mDNS discovery service")
+	err := service.Close()
+	if err != nil {
+		log.Debug("mdnsDiscoverer: error closing the mDNS service", "error", err)
+	}
+}
+
+// HandlePeerFound is called by the mDNS service each time a peer is found on the local network
+func (md *mdnsDiscoverer) HandlePeerFound(pi peer.AddrInfo) {
+	if pi.ID == md.hostConnManagement.ID() {
+		return
+	}
+
+	md.mutFoundPeers.Lock()
+	md.foundPeers[pi.ID] = pi
+	md.mutFoundPeers.Unlock()
+
+	go md.connectToPeer(md.ctx, pi)
+}
+
+func (md *mdnsDiscoverer) connectToPeer(ctx context.Context, pi peer.AddrInfo) {
+	if md.hostConnManagement.IsConnected(pi) {
+		return
+	}
+
+	ctxConnect, cancel := context.WithTimeout(ctx, mdnsConnectionTimeout)
+	defer cancel()
+
+	err := md.hostConnManagement.Connect(ctxConnect, pi)
+	if err != nil {
+		log.Trace("mdnsDiscoverer: connection to the found peer failed", "pid", pi.ID.String(), "error", err)
+	}
+}
+
+// Name returns the name of the mDNS peer discovery implementation
+func (md *mdnsDiscoverer) Name() string {
+	return mdnsName
+}
+
+// ReconnectToNetwork will try to connect to the peers already found on the local network
+func (md *mdnsDiscoverer) ReconnectToNetwork(ctx context.Context) {
+	md.mutFoundPeers.RLock()
+	foundPeers := make([]peer.AddrInfo, 0, len(md.foundPeers))
+	for _, pi := range md.foundPeers {
+		foundPeers = append(foundPeers, pi)
+	}
+	md.mutFoundPeers.RUnlock()
+
+	for _, pi := range foundPeers {
+		md.connectToPeer(ctx, pi)
+
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+	}
+}
+
+// SetPeersRefreshInterval does nothing as the mDNS queries are sent at a fixed interval
+func (md *mdnsDiscoverer) SetPeersRefreshInterval(_ time.Duration) error {
+	return nil
+}
+
+// SeedersStatus returns an empty slice as there are no seeders
+func (md *mdnsDiscoverer) SeedersStatus() []p2p.SeederStatus {
+	return make([]p2p.SeederStatus, 0)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (md *mdnsDiscoverer) IsInterfaceNil() bool {
+	return md == nil
+}
@@ -0,0 +1,200 @@
+package discovery_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/discovery"
+	"github.com/TerraDharitri/drt-go-chain-p2p/mock"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const selfPid = peer.ID("self")
+
+func createMockArgMdnsDiscoverer() discovery.ArgMdnsDiscoverer {
+	return discovery.ArgMdnsDiscoverer{
+		Context: context.Background(),
+		Host: &mock.ConnectableHostStub{
+			IDCalled: func() peer.ID {
+				return selfPid
+			},
+			NetworkCalled: func() network.Network {
+				return createStubNetwork()
+			},
+		},
+		ServiceName:       "drt-devnet",
+		Sharder:           &mock.KadSharderStub{},
+		ConnectionWatcher: &mock.ConnectionsWatcherStub{},
+	}
+}
+
+func TestNewMdnsDiscoverer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil context should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockArgMdnsDiscoverer()
+		arg.Context = nil
+		md, err := discovery.NewMdnsDiscoverer(arg)
+
+		assert.True(t, check.IfNil(md))
+		assert.Equal(t, p2p.ErrNilContext, err)
+	})
+	t.Run("empty service name should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockArgMdnsDiscoverer()
+		arg.ServiceName = ""
+		md, err := discovery.NewMdnsDiscoverer(arg)
+
+		assert.True(t, check.IfNil(md))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("nil sharder should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockArgMdnsDiscoverer()
+		arg.Sharder = nil
+		md, err := discovery.NewMdnsDiscoverer(arg)
+
+		assert.True(t, check.IfNil(md))
+		assert.Equal(t, p2p.ErrNilSharder, err)
+	})
+	t.Run("wrong sharder type should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockArgMdnsDiscoverer()
+		arg.Sharder = &mock.SharderStub{}
+		md, err := discovery.NewMdnsDiscoverer(arg)
+
+		assert.True(t, check.IfNil(md))
+		assert.True(t, errors.Is(err, p2p.ErrWrongTypeAssertion))
+	})
+	t.Run("nil host should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockArgMdnsDiscoverer()
+		arg.Host = nil
+		md, err := discovery.NewMdnsDiscoverer(arg)
+
+		assert.True(t, check.IfNil(md))
+		assert.Equal(t, p2p.ErrNilHost, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		md, err := discovery.NewMdnsDiscoverer(createMockArgMdnsDiscoverer())
+
+		assert.False(t, check.IfNil(md))
+		assert.Nil(t, err)
+		assert.Equal(t, discovery.MdnsName, md.Name())
+	})
+}
+
+func TestMdnsDiscoverer_Bootstrap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("start error should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		md, _ := discovery.NewMdnsDiscoverer(createMockArgMdnsDiscoverer())
+		md.SetMdnsServiceCreator(func(notifee mdns.Notifee) discovery.MdnsService {
+			return &mock.MdnsServiceStub{
+				StartCalled: func() error {
+					return expectedErr
+				},
+			}
+		})
+
+		assert.Equal(t, expectedErr, md.Bootstrap())
+	})
+	t.Run("second call should error and the service should be closed with the context", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		arg := createMockArgMdnsDiscoverer()
+		arg.Context = ctx
+		md, _ := discovery.NewMdnsDiscoverer(arg)
+
+		chClosed := make(chan struct{})
+		numCreated := 0
+		md.SetMdnsServiceCreator(func(notifee mdns.Notifee) discovery.MdnsService {
+			numCreated++
+			return &mock.MdnsServiceStub{
+				CloseCalled: func() error {
+					close(chClosed)
+					return nil
+				},
+			}
+		})
+
+		assert.Nil(t, md.Bootstrap())
+		assert.Equal(t, p2p.ErrPeerDiscoveryProcessAlreadyStarted, md.Bootstrap())
+		assert.Equal(t, 1, numCreated)
+
+		cancel()
+		select {
+		case <-chClosed:
+		case <-time.After(time.Second):
+			assert.Fail(t, "the mDNS service should have been closed")
+		}
+	})
+}
+
+func TestMdnsDiscoverer_HandlePeerFoundAndReconnectToNetwork(t *testing.T) {
+	t.Parallel()
+
+	mutConnected := sync.Mutex{}
+	connected := make([]peer.ID, 0)
+	chConnected := make(chan struct{}, 10)
+	arg := createMockArgMdnsDiscoverer()
+	arg.Host = &mock.ConnectableHostStub{
+		IDCalled: func() peer.ID {
+			return selfPid
+		},
+		NetworkCalled: func() network.Network {
+			return &mock.NetworkStub{
+				PeersCall: func() []peer.ID {
+					return make([]peer.ID, 0)
+				},
+				ConnectednessCalled: func(id peer.ID) network.Connectedness {
+					return network.NotConnected
+				},
+			}
+		},
+		ConnectCalled: func(ctx context.Context, pi peer.AddrInfo) error {
+			mutConnected.Lock()
+			connected = append(connected, pi.ID)
+			mutConnected.Unlock()
+			chConnected <- struct{}{}
+
+			return nil
+		},
+	}
+	md, _ := discovery.NewMdnsDiscoverer(arg)
+
+	md.HandlePeerFound(peer.AddrInfo{ID: selfPid})
+	md.HandlePeerFound(peer.AddrInfo{ID: "pid"})
+	select {
+	case <-chConnected:
+	case <-time.After(time.Second):
+		require.Fail(t, "the found peer should have been connected")
+	}
+
+	md.ReconnectToNetwork(context.Background())
+
+	mutConnected.Lock()
+	defer mutConnected.Unlock()
+	assert.Equal(t, []peer.ID{"pid", "pid"}, connected)
+}
@@ -2,6 +2,7 @@ package discovery
 
 import (
 	"context"
+	"time"
 
 	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
 )
@@ -34,6 +35,16 @@ func (nd *NilDiscoverer) Name() string {
 func (nd *NilDiscoverer) ReconnectToNetwork(_ context.Context) {
 }
 
+// SetPeersRefreshInterval does nothing
+func (nd *NilDiscoverer) SetPeersRefreshInterval(_ time.Duration) error {
+	return nil
+}
+
+// SeedersStatus returns an empty slice as there are no seeders
+func (nd *NilDiscoverer) SeedersStatus() []p2p.SeederStatus {
+	return make([]p2p.SeederStatus, 0)
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (nd *NilDiscoverer) IsInterfaceNil() bool {
 	return nd == nil
@@ -16,4 +16,5 @@ func TestNilDiscoverer(t *testing.T) {
 	assert.False(t, check.IfNil(nd))
 	assert.Equal(t, discovery.NullName, nd.Name())
 	assert.Nil(t, nd.Bootstrap())
+	assert.Nil(t, nd.SetPeersRefreshInterval(0))
 }
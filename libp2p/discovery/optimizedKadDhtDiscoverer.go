@@ -2,11 +2,17 @@ package discovery
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
+	coreDiscovery "github.com/libp2p/go-libp2p/core/discovery"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/libp2p/go-libp2p/core/routing"
+	drouting "github.com/libp2p/go-libp2p/p2p/discovery/routing"
 )
 
 type discovererStatus string
@@ -18,10 +24,12 @@ const optimizedKadDhtName = "optimized kad-dht discovery"
 
 type optimizedKadDhtDiscoverer struct {
 	kadDHT                      KadDhtHandler
+	mutPeersRefreshInterval     sync.RWMutex
 	peersRefreshInterval        time.Duration
 	seedersReconnectionInterval time.Duration
 	protocolID                  string
 	initialPeersList            []string
+	seedersTracker              *seedersTracker
 	bucketSize                  uint32
 	routingTableRefresh         time.Duration
 	hostConnManagement          *hostWithConnectionManagement
@@ -32,6 +40,10 @@ type optimizedKadDhtDiscoverer struct {
 	chanConnectToSeeders        chan struct{}
 	createKadDhtHandler         func(ctx context.Context) (KadDhtHandler, error)
 	connectionWatcher           p2p.ConnectionsWatcher
+	rendezvousConfig            config.RendezvousConfig
+	rendezvousSharder           RendezvousSharder
+	rendezvous                  *rendezvousProcessor
+	createRoutingDiscovery      func(kadDhtHandler KadDhtHandler) (coreDiscovery.Discovery, error)
 }
 
 // NewOptimizedKadDhtDiscoverer creates an optimized kad-dht discovery type implementation
@@ -47,6 +59,11 @@ func NewOptimizedKadDhtDiscoverer(arg ArgKadDht) (*optimizedKadDhtDiscoverer, er
 		return nil, p2p.ErrInvalidSeedersReconnectionInterval
 	}
 
+	rendezvousSharder, err := getRendezvousSharder(arg)
+	if err != nil {
+		return nil, err
+	}
+
 	sharder.SetSeeders(arg.InitialPeersList)
 
 	okdd := &optimizedKadDhtDiscoverer{
@@ -55,6 +72,7 @@ func NewOptimizedKadDhtDiscoverer(arg ArgKadDht) (*optimizedKadDhtDiscoverer, er
 		seedersReconnectionInterval: arg.SeedersReconnectionInterval,
 		protocolID:                  arg.ProtocolID,
 		initialPeersList:            arg.InitialPeersList,
+		seedersTracker:              newSeedersTracker(arg.InitialPeersList, arg.SeedersMaxBackoff),
 		bucketSize:                  arg.BucketSize,
 		routingTableRefresh:         arg.RoutingTableRefresh,
 		status:                      statNotInitialized,
@@ -62,9 +80,12 @@ func NewOptimizedKadDhtDiscoverer(arg ArgKadDht) (*optimizedKadDhtDiscoverer, er
 		errChanInit:                 make(chan error),
 		chanConnectToSeeders:        make(chan struct{}),
 		connectionWatcher:           arg.ConnectionWatcher,
+		rendezvousConfig:            arg.Rendezvous,
+		rendezvousSharder:           rendezvousSharder,
 	}
 
 	okdd.createKadDhtHandler = okdd.createKadDht
+	okdd.createRoutingDiscovery = createRoutingDiscovery
 	args := ArgsHostWithConnectionManagement{
 		ConnectableHost:    arg.Host,
 		Sharder:            okdd.sharder,
@@ -80,6 +101,20 @@ func NewOptimizedKadDhtDiscoverer(arg ArgKadDht) (*optimizedKadDhtDiscoverer, er
 	return okdd, nil
 }
 
+func getRendezvousSharder(arg ArgKadDht) (RendezvousSharder, error) {
+	if !arg.Rendezvous.Enabled {
+		return nil, nil
+	}
+
+	rendezvousSharder, ok := arg.KddSharder.(RendezvousSharder)
+	if !ok {
+		return nil, fmt.Errorf("%w for sharder: the rendezvous discovery requires a discovery.RendezvousSharder",
+			p2p.ErrWrongTypeAssertion)
+	}
+
+	return rendezvousSharder, nil
+}
+
 // Bootstrap will start the bootstrapping new peers process
 func (okdd *optimizedKadDhtDiscoverer) Bootstrap() error {
 	okdd.chanInit <- struct{}{}
@@ -88,7 +123,7 @@ func (okdd *optimizedKadDhtDiscoverer) Bootstrap() error {
 
 func (okdd *optimizedKadDhtDiscoverer) processLoop(ctx context.Context) {
 	chTimeSeedersReconnect := time.After(okdd.seedersReconnectionInterval)
-	chTimeFindPeers := time.After(okdd.peersRefreshInterval)
+	chTimeFindPeers := time.After(okdd.getPeersRefreshInterval())
 
 	for {
 		select {
@@ -103,7 +138,7 @@ func (okdd *optimizedKadDhtDiscoverer) processLoop(ctx context.Context) {
 
 		case <-chTimeFindPeers:
 			okdd.findPeers(ctx)
-			chTimeFindPeers = time.After(okdd.peersRefreshInterval)
+			chTimeFindPeers = time.After(okdd.getPeersRefreshInterval())
 
 		case <-ctx.Done():
 			log.Debug("closing the p2p bootstrapping process")
@@ -146,7 +181,7 @@ func (okdd *optimizedKadDhtDiscoverer) createChTimeSeedersReconnect(isConnectedT
 	}
 
 	// no connection to seeders, let's try a little bit faster
-	return time.After(okdd.peersRefreshInterval)
+	return time.After(okdd.getPeersRefreshInterval())
 }
 
 func (okdd *optimizedKadDhtDiscoverer) init(ctx context.Context) error {
@@ -159,12 +194,46 @@ func (okdd *optimizedKadDhtDiscoverer) init(ctx context.Context) error {
 		return err
 	}
 
+	if okdd.rendezvousConfig.Enabled {
+		okdd.rendezvous, err = okdd.createRendezvousProcessor(kadDhtHandler)
+		if err != nil {
+			return err
+		}
+	}
+
 	okdd.kadDHT = kadDhtHandler
 	okdd.status = statInitialized
 
 	return nil
 }
 
+func (okdd *optimizedKadDhtDiscoverer) createRendezvousProcessor(kadDhtHandler KadDhtHandler) (*rendezvousProcessor, error) {
+	routingDiscovery, err := okdd.createRoutingDiscovery(kadDhtHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	args := argsRendezvousProcessor{
+		routingDiscovery:   routingDiscovery,
+		sharder:            okdd.rendezvousSharder,
+		hostConnManagement: okdd.hostConnManagement,
+		namespacePrefix:    okdd.rendezvousConfig.NamespacePrefix,
+		maxPeersPerQuery:   okdd.rendezvousConfig.MaxPeersPerQuery,
+	}
+
+	return newRendezvousProcessor(args)
+}
+
+func createRoutingDiscovery(kadDhtHandler KadDhtHandler) (coreDiscovery.Discovery, error) {
+	contentRouting, ok := kadDhtHandler.(routing.ContentRouting)
+	if !ok {
+		return nil, fmt.Errorf("%w for kad dht handler: expected routing.ContentRouting type of interface",
+			p2p.ErrWrongTypeAssertion)
+	}
+
+	return drouting.NewRoutingDiscovery(contentRouting), nil
+}
+
 func (okdd *optimizedKadDhtDiscoverer) createKadDht(ctx context.Context) (KadDhtHandler, error) {
 	protocolID := protocol.ID(okdd.protocolID)
 	return dht.New(
@@ -186,7 +255,14 @@ func (okdd *optimizedKadDhtDiscoverer) tryToReconnectAtLeastToASeeder(ctx contex
 	}
 
 	connectedToOneSeeder := false
+	isNodeIsolated := isIsolated(okdd.hostConnManagement)
 	for _, seederAddress := range okdd.initialPeersList {
+		if !isNodeIsolated && !okdd.seedersTracker.canAttempt(seederAddress) {
+			log.Trace("optimizedKadDhtDiscoverer.tryToReconnectAtLeastToASeeder: seeder is backing off",
+				"seeder", seederAddress)
+			continue
+		}
+
 		err := okdd.connectToSeeder(ctx, seederAddress)
 		if err != nil {
 			printConnectionErrorToSeeder(seederAddress, err)
@@ -219,7 +295,10 @@ func (okdd *optimizedKadDhtDiscoverer) connectToSeeder(ctx context.Context, seed
 		return nil
 	}
 
-	return okdd.hostConnManagement.Connect(ctx, *seederInfo)
+	err = okdd.hostConnManagement.Connect(ctx, *seederInfo)
+	okdd.seedersTracker.recordAttempt(seederAddress, err)
+
+	return err
 }
 
 func (okdd *optimizedKadDhtDiscoverer) findPeers(ctx context.Context) {
@@ -231,6 +310,10 @@ func (okdd *optimizedKadDhtDiscoverer) findPeers(ctx context.Context) {
 	if err != nil {
 		log.Debug("kad dht bootstrap", "error", err)
 	}
+
+	if okdd.rendezvous != nil {
+		okdd.rendezvous.processRoundAsync(ctx)
+	}
 }
 
 // Name returns the name of the kad dht peer discovery implementation
@@ -246,6 +329,35 @@ func (okdd *optimizedKadDhtDiscoverer) ReconnectToNetwork(_ context.Context) {
 	}
 }
 
+// SeedersStatus returns the connection health of each seeder
+func (okdd *optimizedKadDhtDiscoverer) SeedersStatus() []p2p.SeederStatus {
+	return okdd.seedersTracker.status(func(address string) bool {
+		return isConnectedToAddress(okdd.hostConnManagement, address)
+	})
+}
+
+// SetPeersRefreshInterval sets the interval between two consecutive peers discovery rounds. The new value is used
+// starting with the next round
+func (okdd *optimizedKadDhtDiscoverer) SetPeersRefreshInterval(interval time.Duration) error {
+	err := checkPeersRefreshInterval(interval)
+	if err != nil {
+		return err
+	}
+
+	okdd.mutPeersRefreshInterval.Lock()
+	okdd.peersRefreshInterval = interval
+	okdd.mutPeersRefreshInterval.Unlock()
+
+	return nil
+}
+
+func (okdd *optimizedKadDhtDiscoverer) getPeersRefreshInterval() time.Duration {
+	okdd.mutPeersRefreshInterval.RLock()
+	defer okdd.mutPeersRefreshInterval.RUnlock()
+
+	return okdd.peersRefreshInterval
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (okdd *optimizedKadDhtDiscoverer) IsInterfaceNil() bool {
 	return okdd == nil
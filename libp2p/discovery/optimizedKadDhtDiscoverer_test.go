@@ -10,10 +10,12 @@ import (
 
 	"github.com/TerraDharitri/drt-go-chain-core/core/check"
 	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
 	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/discovery"
 	"github.com/TerraDharitri/drt-go-chain-p2p/mock"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewOptimizedKadDhtDiscoverer(t *testing.T) {
@@ -65,6 +67,27 @@ func TestOptimizedKadDhtDiscoverer_BootstrapWithRealKadDhtFuncShouldNotError(t *
 	cancelFunc()
 }
 
+func TestOptimizedKadDhtDiscoverer_BootstrapWithRendezvousShouldNotError(t *testing.T) {
+	t.Parallel()
+
+	arg := createTestArgument()
+	arg.InitialPeersList = make([]string, 0)
+	arg.Rendezvous = config.RendezvousConfig{
+		Enabled:          true,
+		NamespacePrefix:  "drt",
+		MaxPeersPerQuery: 20,
+	}
+	var cancelFunc func()
+	arg.Context, cancelFunc = context.WithCancel(context.Background())
+	okdd, err := discovery.NewOptimizedKadDhtDiscoverer(arg)
+	require.Nil(t, err)
+
+	err = okdd.Bootstrap()
+
+	assert.Nil(t, err)
+	cancelFunc()
+}
+
 func TestOptimizedKadDhtDiscoverer_BootstrapEmptyPeerListShouldStartBootstrap(t *testing.T) {
 	t.Parallel()
 
@@ -261,3 +284,21 @@ func TestOptimizedKadDhtDiscoverer_ReconnectToNetwork(t *testing.T) {
 	assert.True(t, connectCalled > 0)
 	mutConnect.Unlock()
 }
+
+func TestOptimizedKadDhtDiscoverer_SetPeersRefreshInterval(t *testing.T) {
+	t.Parallel()
+
+	arg := createTestArgument()
+	var cancelFunc func()
+	arg.Context, cancelFunc = context.WithCancel(context.Background())
+	defer cancelFunc()
+	okdd, _ := discovery.NewOptimizedKadDhtDiscoverer(arg)
+
+	err := okdd.SetPeersRefreshInterval(time.Millisecond)
+	assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	assert.Equal(t, arg.PeersRefreshInterval, okdd.GetPeersRefreshInterval())
+
+	err = okdd.SetPeersRefreshInterval(time.Second * 5)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Second*5, okdd.GetPeersRefreshInterval())
+}
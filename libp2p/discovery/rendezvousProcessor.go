@@ -0,0 +1,237 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	coreDiscovery "github.com/libp2p/go-libp2p/core/discovery"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const rendezvousRoundTimeout = time.Minute
+const rendezvousConnectionTimeout = time.Second * 10
+const validatorRole = "validator"
+const observerRole = "observer"
+
+type argsRendezvousProcessor struct {
+	routingDiscovery   coreDiscovery.Discovery
+	sharder            RendezvousSharder
+	hostConnManagement *hostWithConnectionManagement
+	namespacePrefix    string
+	maxPeersPerQuery   uint32
+}
+
+// rendezvousProcessor advertises the node under its shard and role namespaces and looks for the peers advertised
+// under the namespaces of the sharder's categories that are under quota
+type rendezvousProcessor struct {
+	routingDiscovery     coreDiscovery.Discovery
+	sharder              RendezvousSharder
+	hostConnManagement   *hostWithConnectionManagement
+	namespacePrefix      string
+	maxPeersPerQuery     int
+	isRunning            uint32
+	mutAdvertise         sync.Mutex
+	advertisedNamespaces []string
+	nextAdvertise        time.Time
+	getTimeHandler       func() time.Time
+}
+
+func newRendezvousProcessor(args argsRendezvousProcessor) (*rendezvousProcessor, error) {
+	if check.IfNilReflect(args.routingDiscovery) {
+		return nil, p2p.ErrNilRoutingDiscovery
+	}
+	if check.IfNil(args.sharder) {
+		return nil, p2p.ErrNilSharder
+	}
+	if args.hostConnManagement == nil {
+		return nil, p2p.ErrNilHost
+	}
+	if len(args.namespacePrefix) == 0 {
+		return nil, fmt.Errorf("%w, empty rendezvous namespace prefix", p2p.ErrInvalidValue)
+	}
+	if args.maxPeersPerQuery == 0 {
+		return nil, fmt.Errorf("%w, MaxPeersPerQuery should be at least 1", p2p.ErrInvalidValue)
+	}
+
+	return &rendezvousProcessor{
+		routingDiscovery:   args.routingDiscovery,
+		sharder:            args.sharder,
+		hostConnManagement: args.hostConnManagement,
+		namespacePrefix:    args.namespacePrefix,
+		maxPeersPerQuery:   int(args.maxPeersPerQuery),
+		getTimeHandler:     time.Now,
+	}, nil
+}
+
+// processRoundAsync starts a new rendezvous round, if the previous one finished, without blocking the caller
+func (rp *rendezvousProcessor) processRoundAsync(ctx context.Context) {
+	if !atomic.CompareAndSwapUint32(&rp.isRunning, 0, 1) {
+		return
+	}
+
+	go func() {
+		defer atomic.StoreUint32(&rp.isRunning, 0)
+
+		rp.processRound(ctx)
+	}()
+}
+
+func (rp *rendezvousProcessor) processRound(ctx context.Context) {
+	selfPeerInfo := rp.sharder.GetSelfPeerInfo()
+	if selfPeerInfo.PeerType == core.UnknownPeer {
+		log.Trace("rendezvousProcessor: self peer type not yet known, skipping round")
+		return
+	}
+
+	ctxRound, cancel := context.WithTimeout(ctx, rendezvousRoundTimeout)
+	defer cancel()
+
+	rp.advertise(ctxRound, selfPeerInfo)
+	rp.findMissingPeers(ctxRound, selfPeerInfo)
+}
+
+func (rp *rendezvousProcessor) advertise(ctx context.Context, selfPeerInfo core.P2PPeerInfo) {
+	role := peerTypeToRole(selfPeerInfo.PeerType)
+	namespaces := []string{
+		rp.shardNamespace(selfPeerInfo.ShardID, role),
+		rp.roleNamespace(role),
+	}
+
+	rp.mutAdvertise.Lock()
+	defer rp.mutAdvertise.Unlock()
+
+	now := rp.getTimeHandler()
+	if equalNamespaces(namespaces, rp.advertisedNamespaces) && now.Before(rp.nextAdvertise) {
+		return
+	}
+
+	minTTL := time.Duration(0)
+	for _, namespace := range namespaces {
+		ttl, err := rp.routingDiscovery.Advertise(ctx, namespace)
+		if err != nil {
+			log.Debug("rendezvousProcessor: advertise failed", "namespace", namespace, "error", err)
+			return
+		}
+		if minTTL == 0 || ttl < minTTL {
+			minTTL = ttl
+		}
+	}
+
+	log.Debug("rendezvousProcessor: advertised", "namespaces", namespaces, "ttl", minTTL)
+	rp.advertisedNamespaces = namespaces
+	// re-advertise before the provider records expire
+	rp.nextAdvertise = now.Add(minTTL / 2)
+}
+
+func (rp *rendezvousProcessor) findMissingPeers(ctx context.Context, selfPeerInfo core.P2PPeerInfo) {
+	quotas := rp.sharder.ComputeMissingPeers(rp.hostConnManagement.Network().Peers())
+	for _, quota := range quotas {
+		namespace := rp.categoryNamespace(quota.Category, selfPeerInfo.ShardID)
+		numConnected := rp.connectToAdvertisedPeers(ctx, namespace, quota.NumMissing)
+
+		log.Debug("rendezvousProcessor: category under quota",
+			"category", quota.Category.String(),
+			"namespace", namespace,
+			"missing", quota.NumMissing,
+			"connected", numConnected,
+		)
+
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+	}
+}
+
+func (rp *rendezvousProcessor) connectToAdvertisedPeers(ctx context.Context, namespace string, numMissing int) int {
+	chPeers, err := rp.routingDiscovery.FindPeers(ctx, namespace, coreDiscovery.Limit(rp.maxPeersPerQuery))
+	if err != nil {
+		log.Debug("rendezvousProcessor: find peers failed", "namespace", namespace, "error", err)
+		return 0
+	}
+
+	numConnected := 0
+	for pi := range chPeers {
+		if numConnected >= numMissing {
+			// keep draining the channel so the query can finish
+			continue
+		}
+		if pi.ID == rp.hostConnManagement.ID() || rp.hostConnManagement.IsConnected(pi) {
+			continue
+		}
+
+		err = rp.connect(ctx, pi)
+		if err != nil {
+			log.Trace("rendezvousProcessor: connection failed", "pid", pi.ID.String(), "error", err)
+			continue
+		}
+
+		numConnected++
+	}
+
+	return numConnected
+}
+
+func (rp *rendezvousProcessor) connect(ctx context.Context, pi peer.AddrInfo) error {
+	ctxConnect, cancel := context.WithTimeout(ctx, rendezvousConnectionTimeout)
+	defer cancel()
+
+	return rp.hostConnManagement.Connect(ctxConnect, pi)
+}
+
+// categoryNamespace returns the namespace queried for a category. The cross shard categories use the role namespace
+// as the sharder does not care about the exact shard of the cross shard peers
+func (rp *rendezvousProcessor) categoryNamespace(category p2p.PeersCategory, selfShardID uint32) string {
+	switch category {
+	case p2p.IntraShardValidatorsCategory:
+		return rp.shardNamespace(selfShardID, validatorRole)
+	case p2p.IntraShardObserversCategory:
+		return rp.shardNamespace(selfShardID, observerRole)
+	case p2p.CrossShardValidatorsCategory:
+		return rp.roleNamespace(validatorRole)
+	default:
+		return rp.roleNamespace(observerRole)
+	}
+}
+
+func (rp *rendezvousProcessor) shardNamespace(shardID uint32, role string) string {
+	return fmt.Sprintf("%s/shard/%d/%s", rp.namespacePrefix, shardID, role)
+}
+
+func (rp *rendezvousProcessor) roleNamespace(role string) string {
+	return fmt.Sprintf("%s/%s", rp.namespacePrefix, role)
+}
+
+func peerTypeToRole(peerType core.P2PPeerType) string {
+	if peerType == core.ValidatorPeer {
+		return validatorRole
+	}
+
+	return observerRole
+}
+
+func equalNamespaces(first []string, second []string) bool {
+	if len(first) != len(second) {
+		return false
+	}
+
+	for idx := range first {
+		if first[idx] != second[idx] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rp *rendezvousProcessor) IsInterfaceNil() bool {
+	return rp == nil
+}
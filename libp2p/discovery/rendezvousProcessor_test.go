@@ -0,0 +1,193 @@
+package discovery_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/discovery"
+	"github.com/TerraDharitri/drt-go-chain-p2p/mock"
+	coreDiscovery "github.com/libp2p/go-libp2p/core/discovery"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rendezvousPrefix = "drt"
+
+func createRendezvousHostArgs(connectedPid peer.ID, connectCalled func(pi peer.AddrInfo)) discovery.ArgsHostWithConnectionManagement {
+	args := createMockArgsHostWithConnectionManagement()
+	args.ConnectableHost = &mock.ConnectableHostStub{
+		IDCalled: func() peer.ID {
+			return selfPid
+		},
+		NetworkCalled: func() network.Network {
+			return &mock.NetworkStub{
+				PeersCall: func() []peer.ID {
+					return []peer.ID{connectedPid}
+				},
+				ConnectednessCalled: func(pid peer.ID) network.Connectedness {
+					if pid == connectedPid {
+						return network.Connected
+					}
+
+					return network.NotConnected
+				},
+			}
+		},
+		ConnectCalled: func(ctx context.Context, pi peer.AddrInfo) error {
+			connectCalled(pi)
+			return nil
+		},
+	}
+
+	return args
+}
+
+func createPeersChannel(pids ...peer.ID) <-chan peer.AddrInfo {
+	ch := make(chan peer.AddrInfo, len(pids))
+	for _, pid := range pids {
+		ch <- peer.AddrInfo{ID: pid}
+	}
+	close(ch)
+
+	return ch
+}
+
+func TestNewRendezvousProcessor(t *testing.T) {
+	t.Parallel()
+
+	host := createRendezvousHostArgs("", func(pi peer.AddrInfo) {})
+
+	rp, err := discovery.NewRendezvousProcessor(nil, &mock.KadSharderStub{}, host, rendezvousPrefix, 1)
+	assert.True(t, check.IfNil(rp))
+	assert.Equal(t, p2p.ErrNilRoutingDiscovery, err)
+
+	rp, err = discovery.NewRendezvousProcessor(&mock.RoutingDiscoveryStub{}, nil, host, rendezvousPrefix, 1)
+	assert.True(t, check.IfNil(rp))
+	assert.Equal(t, p2p.ErrNilSharder, err)
+
+	rp, err = discovery.NewRendezvousProcessor(&mock.RoutingDiscoveryStub{}, &mock.KadSharderStub{}, host, "", 1)
+	assert.True(t, check.IfNil(rp))
+	assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+
+	rp, err = discovery.NewRendezvousProcessor(&mock.RoutingDiscoveryStub{}, &mock.KadSharderStub{}, host, rendezvousPrefix, 0)
+	assert.True(t, check.IfNil(rp))
+	assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+
+	rp, err = discovery.NewRendezvousProcessor(&mock.RoutingDiscoveryStub{}, &mock.KadSharderStub{}, host, rendezvousPrefix, 1)
+	assert.False(t, check.IfNil(rp))
+	assert.Nil(t, err)
+}
+
+func TestRendezvousProcessor_ProcessRound(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown self peer type should not advertise", func(t *testing.T) {
+		t.Parallel()
+
+		routingDiscovery := &mock.RoutingDiscoveryStub{
+			AdvertiseCalled: func(ctx context.Context, ns string, opts ...coreDiscovery.Option) (time.Duration, error) {
+				assert.Fail(t, "should not advertise")
+				return 0, nil
+			},
+		}
+		host := createRendezvousHostArgs("", func(pi peer.AddrInfo) {})
+		rp, _ := discovery.NewRendezvousProcessor(routingDiscovery, &mock.KadSharderStub{}, host, rendezvousPrefix, 1)
+
+		rp.ProcessRound(context.Background())
+	})
+	t.Run("should advertise the shard and role namespaces until the ttl expires", func(t *testing.T) {
+		t.Parallel()
+
+		advertised := make([]string, 0)
+		routingDiscovery := &mock.RoutingDiscoveryStub{
+			AdvertiseCalled: func(ctx context.Context, ns string, opts ...coreDiscovery.Option) (time.Duration, error) {
+				advertised = append(advertised, ns)
+				return time.Hour, nil
+			},
+		}
+		selfPeerInfo := core.P2PPeerInfo{PeerType: core.ObserverPeer, ShardID: 1}
+		sharder := &mock.KadSharderStub{
+			GetSelfPeerInfoCalled: func() core.P2PPeerInfo {
+				return selfPeerInfo
+			},
+		}
+		host := createRendezvousHostArgs("", func(pi peer.AddrInfo) {})
+		rp, _ := discovery.NewRendezvousProcessor(routingDiscovery, sharder, host, rendezvousPrefix, 1)
+		now := time.Now()
+		rp.SetTimeHandler(func() time.Time {
+			return now
+		})
+
+		rp.ProcessRound(context.Background())
+		assert.Equal(t, []string{"drt/shard/1/observer", "drt/observer"}, advertised)
+
+		now = now.Add(time.Minute)
+		rp.ProcessRound(context.Background())
+		assert.Equal(t, 2, len(advertised))
+
+		selfPeerInfo.PeerType = core.ValidatorPeer
+		rp.ProcessRound(context.Background())
+		assert.Equal(t, []string{"drt/shard/1/validator", "drt/validator"}, advertised[2:])
+
+		now = now.Add(time.Hour)
+		rp.ProcessRound(context.Background())
+		assert.Equal(t, 6, len(advertised))
+	})
+	t.Run("should connect to the peers of the categories under quota", func(t *testing.T) {
+		t.Parallel()
+
+		connectedPid := peer.ID("connected")
+		mutQueried := sync.Mutex{}
+		queried := make(map[string]int)
+		routingDiscovery := &mock.RoutingDiscoveryStub{
+			FindPeersCalled: func(ctx context.Context, ns string, opts ...coreDiscovery.Option) (<-chan peer.AddrInfo, error) {
+				options := coreDiscovery.Options{}
+				_ = options.Apply(opts...)
+
+				mutQueried.Lock()
+				queried[ns] = options.Limit
+				mutQueried.Unlock()
+
+				switch ns {
+				case "drt/shard/1/validator":
+					return createPeersChannel(selfPid, connectedPid, "intra1", "intra2", "intra3"), nil
+				case "drt/observer":
+					return createPeersChannel("cross1"), nil
+				default:
+					return nil, errors.New("unexpected namespace")
+				}
+			},
+		}
+		sharder := &mock.KadSharderStub{
+			GetSelfPeerInfoCalled: func() core.P2PPeerInfo {
+				return core.P2PPeerInfo{PeerType: core.ValidatorPeer, ShardID: 1}
+			},
+			ComputeMissingPeersCalled: func(pidList []peer.ID) []p2p.PeersCategoryQuota {
+				assert.Equal(t, []peer.ID{connectedPid}, pidList)
+
+				return []p2p.PeersCategoryQuota{
+					{Category: p2p.IntraShardValidatorsCategory, NumMissing: 2},
+					{Category: p2p.CrossShardObserversCategory, NumMissing: 5},
+				}
+			},
+		}
+		connected := make([]peer.ID, 0)
+		host := createRendezvousHostArgs(connectedPid, func(pi peer.AddrInfo) {
+			connected = append(connected, pi.ID)
+		})
+		rp, _ := discovery.NewRendezvousProcessor(routingDiscovery, sharder, host, rendezvousPrefix, 20)
+
+		rp.ProcessRound(context.Background())
+
+		require.Equal(t, map[string]int{"drt/shard/1/validator": 20, "drt/observer": 20}, queried)
+		assert.Equal(t, []peer.ID{"intra1", "intra2", "cross1"}, connected)
+	})
+}
@@ -0,0 +1,171 @@
+package discovery
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/libp2p/go-libp2p/core/network"
+)
+
+const (
+	minSeederBackoff = time.Second
+	maxSeederBackoff = time.Minute * 5
+)
+
+type seederHealth struct {
+	numSuccesses        uint64
+	numFailures         uint64
+	consecutiveFailures uint32
+	lastError           string
+	lastAttempt         time.Time
+	lastSuccess         time.Time
+	nextAttempt         time.Time
+}
+
+// seedersTracker keeps the connection health of each seeder and delays the connection attempts to the failing
+// seeders using an exponential backoff with jitter
+type seedersTracker struct {
+	mut              sync.RWMutex
+	addresses        []string
+	seeders          map[string]*seederHealth
+	minBackoff       time.Duration
+	maxBackoff       time.Duration
+	getTimeHandler   func() time.Time
+	getRandomHandler func() float64
+}
+
+// newSeedersTracker creates a seeders tracker. A zero or negative maxBackoff uses the default maximum backoff
+func newSeedersTracker(addresses []string, maxBackoff time.Duration) *seedersTracker {
+	if maxBackoff <= 0 {
+		maxBackoff = maxSeederBackoff
+	}
+
+	tracker := &seedersTracker{
+		addresses:        make([]string, 0, len(addresses)),
+		seeders:          make(map[string]*seederHealth, len(addresses)),
+		minBackoff:       minSeederBackoff,
+		maxBackoff:       maxBackoff,
+		getTimeHandler:   time.Now,
+		getRandomHandler: rand.Float64,
+	}
+	for _, address := range addresses {
+		tracker.getOrCreateSeeder(address)
+	}
+
+	return tracker
+}
+
+func (tracker *seedersTracker) getOrCreateSeeder(address string) *seederHealth {
+	seeder, found := tracker.seeders[address]
+	if !found {
+		seeder = &seederHealth{}
+		tracker.seeders[address] = seeder
+		tracker.addresses = append(tracker.addresses, address)
+	}
+
+	return seeder
+}
+
+func (tracker *seedersTracker) setBackoffLimits(minBackoff time.Duration, maxBackoff time.Duration) {
+	tracker.mut.Lock()
+	tracker.minBackoff = minBackoff
+	tracker.maxBackoff = maxBackoff
+	tracker.mut.Unlock()
+}
+
+// canAttempt returns false while the seeder is backing off after a failed connection attempt
+func (tracker *seedersTracker) canAttempt(address string) bool {
+	tracker.mut.RLock()
+	defer tracker.mut.RUnlock()
+
+	seeder, found := tracker.seeders[address]
+	if !found {
+		return true
+	}
+
+	return !tracker.getTimeHandler().Before(seeder.nextAttempt)
+}
+
+// recordAttempt updates the seeder's counters with the result of a connection attempt. A seeder refusing the
+// connection because it is not wanted by the sharder is not considered failing
+func (tracker *seedersTracker) recordAttempt(address string, err error) {
+	tracker.mut.Lock()
+	defer tracker.mut.Unlock()
+
+	now := tracker.getTimeHandler()
+	seeder := tracker.getOrCreateSeeder(address)
+	seeder.lastAttempt = now
+	if err == nil {
+		seeder.numSuccesses++
+		seeder.consecutiveFailures = 0
+		seeder.lastSuccess = now
+		seeder.nextAttempt = time.Time{}
+		return
+	}
+
+	seeder.lastError = err.Error()
+	if errors.Is(err, p2p.ErrUnwantedPeer) {
+		return
+	}
+
+	seeder.numFailures++
+	seeder.consecutiveFailures++
+	seeder.nextAttempt = now.Add(tracker.computeBackoff(seeder.consecutiveFailures))
+}
+
+// computeBackoff doubles the minimum backoff for each consecutive failure, up to the maximum backoff, and then
+// randomly picks a duration in the upper half of the interval so the nodes restarted together do not dial in sync
+func (tracker *seedersTracker) computeBackoff(consecutiveFailures uint32) time.Duration {
+	backoff := tracker.maxBackoff
+	shift := consecutiveFailures - 1
+	if shift < 32 && tracker.minBackoff<<shift < tracker.maxBackoff {
+		backoff = tracker.minBackoff << shift
+	}
+
+	halfBackoff := backoff / 2
+	return halfBackoff + time.Duration(tracker.getRandomHandler()*float64(halfBackoff))
+}
+
+// status returns the health of each seeder, in the order the seeders were provided
+func (tracker *seedersTracker) status(isConnected func(address string) bool) []p2p.SeederStatus {
+	tracker.mut.RLock()
+	defer tracker.mut.RUnlock()
+
+	now := tracker.getTimeHandler()
+	statuses := make([]p2p.SeederStatus, 0, len(tracker.addresses))
+	for _, address := range tracker.addresses {
+		seeder := tracker.seeders[address]
+		statuses = append(statuses, p2p.SeederStatus{
+			Address:             address,
+			IsConnected:         isConnected(address),
+			IsBackingOff:        now.Before(seeder.nextAttempt),
+			NumSuccesses:        seeder.numSuccesses,
+			NumFailures:         seeder.numFailures,
+			ConsecutiveFailures: seeder.consecutiveFailures,
+			LastError:           seeder.lastError,
+			LastAttempt:         seeder.lastAttempt,
+			LastSuccess:         seeder.lastSuccess,
+			NextAttempt:         seeder.nextAttempt,
+		})
+	}
+
+	return statuses
+}
+
+// isIsolated returns true if the host is not connected to any peer. The seeders are then the only way back to the
+// network so they are dialed even while backing off
+func isIsolated(host ConnectableHost) bool {
+	return len(host.Network().Peers()) == 0
+}
+
+func isConnectedToAddress(host ConnectableHost, address string) bool {
+	peerInfo, err := host.AddressToPeerInfo(address)
+	if err != nil {
+		return false
+	}
+
+	return host.Network().Connectedness(peerInfo.ID) == network.Connected
+}
@@ -0,0 +1,155 @@
+package discovery_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/discovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func isNeverConnected(_ string) bool {
+	return false
+}
+
+func TestSeedersTracker_FailuresShouldBackOffExponentially(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Unix(1000000, 0)
+	tracker := discovery.NewSeedersTracker([]string{"seeder"}, 0)
+	tracker.SetTimeHandler(func() time.Time {
+		return currentTime
+	})
+	tracker.SetRandomHandler(func() float64 {
+		return 1
+	})
+
+	assert.True(t, tracker.CanAttempt("seeder"))
+
+	expectedErr := errors.New("expected error")
+	expectedBackoffs := []time.Duration{time.Second, time.Second * 2, time.Second * 4, time.Second * 8}
+	for _, backoff := range expectedBackoffs {
+		tracker.RecordAttempt("seeder", expectedErr)
+
+		currentTime = currentTime.Add(backoff - time.Millisecond)
+		assert.False(t, tracker.CanAttempt("seeder"))
+		currentTime = currentTime.Add(time.Millisecond)
+		assert.True(t, tracker.CanAttempt("seeder"))
+	}
+
+	for i := 0; i < 20; i++ {
+		tracker.RecordAttempt("seeder", expectedErr)
+	}
+	status := tracker.Status(isNeverConnected)
+	require.Equal(t, 1, len(status))
+	assert.Equal(t, currentTime.Add(time.Minute*5), status[0].NextAttempt)
+	assert.Equal(t, uint64(24), status[0].NumFailures)
+	assert.Equal(t, uint32(24), status[0].ConsecutiveFailures)
+}
+
+func TestSeedersTracker_BackoffShouldHaveJitter(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Unix(1000000, 0)
+	tracker := discovery.NewSeedersTracker([]string{"seeder"}, 0)
+	tracker.SetTimeHandler(func() time.Time {
+		return currentTime
+	})
+	tracker.SetRandomHandler(func() float64 {
+		return 0
+	})
+
+	tracker.RecordAttempt("seeder", errors.New("expected error"))
+	tracker.RecordAttempt("seeder", errors.New("expected error"))
+
+	// the second backoff is 2 seconds and the jitter can shorten it by half
+	status := tracker.Status(isNeverConnected)
+	assert.Equal(t, currentTime.Add(time.Second), status[0].NextAttempt)
+}
+
+func TestSeedersTracker_SuccessShouldResetTheBackoff(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Unix(1000000, 0)
+	tracker := discovery.NewSeedersTracker([]string{"seeder"}, 0)
+	tracker.SetTimeHandler(func() time.Time {
+		return currentTime
+	})
+
+	tracker.RecordAttempt("seeder", errors.New("expected error"))
+	tracker.RecordAttempt("seeder", errors.New("expected error"))
+	assert.False(t, tracker.CanAttempt("seeder"))
+
+	currentTime = currentTime.Add(time.Minute)
+	tracker.RecordAttempt("seeder", nil)
+	assert.True(t, tracker.CanAttempt("seeder"))
+
+	status := tracker.Status(isNeverConnected)
+	require.Equal(t, 1, len(status))
+	assert.Equal(t, uint64(1), status[0].NumSuccesses)
+	assert.Equal(t, uint64(2), status[0].NumFailures)
+	assert.Equal(t, uint32(0), status[0].ConsecutiveFailures)
+	assert.Equal(t, "expected error", status[0].LastError)
+	assert.Equal(t, currentTime, status[0].LastAttempt)
+	assert.Equal(t, currentTime, status[0].LastSuccess)
+	assert.False(t, status[0].IsBackingOff)
+	assert.True(t, status[0].NextAttempt.IsZero())
+}
+
+func TestSeedersTracker_UnwantedPeerShouldNotBackOff(t *testing.T) {
+	t.Parallel()
+
+	tracker := discovery.NewSeedersTracker([]string{"seeder"}, 0)
+	tracker.RecordAttempt("seeder", p2p.ErrUnwantedPeer)
+
+	assert.True(t, tracker.CanAttempt("seeder"))
+	status := tracker.Status(isNeverConnected)
+	require.Equal(t, 1, len(status))
+	assert.Equal(t, uint64(0), status[0].NumFailures)
+	assert.Equal(t, uint32(0), status[0].ConsecutiveFailures)
+	assert.Equal(t, p2p.ErrUnwantedPeer.Error(), status[0].LastError)
+}
+
+func TestSeedersTracker_StatusShouldKeepTheSeedersOrder(t *testing.T) {
+	t.Parallel()
+
+	tracker := discovery.NewSeedersTracker([]string{"seeder1", "seeder2"}, 0)
+	tracker.RecordAttempt("seeder3", nil)
+	tracker.RecordAttempt("seeder1", errors.New("expected error"))
+
+	status := tracker.Status(func(address string) bool {
+		return address == "seeder3"
+	})
+	require.Equal(t, 3, len(status))
+	assert.Equal(t, "seeder1", status[0].Address)
+	assert.False(t, status[0].IsConnected)
+	assert.True(t, status[0].IsBackingOff)
+	assert.Equal(t, "seeder2", status[1].Address)
+	assert.False(t, status[1].IsBackingOff)
+	assert.True(t, status[1].LastAttempt.IsZero())
+	assert.Equal(t, "seeder3", status[2].Address)
+	assert.True(t, status[2].IsConnected)
+}
+
+func TestSeedersTracker_MaxBackoffShouldBeConfigurable(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Unix(1000000, 0)
+	tracker := discovery.NewSeedersTracker([]string{"seeder"}, time.Second*3)
+	tracker.SetTimeHandler(func() time.Time {
+		return currentTime
+	})
+	tracker.SetRandomHandler(func() float64 {
+		return 1
+	})
+
+	for i := 0; i < 10; i++ {
+		tracker.RecordAttempt("seeder", errors.New("expected error"))
+	}
+
+	status := tracker.Status(isNeverConnected)
+	assert.Equal(t, currentTime.Add(time.Second*3), status[0].NextAttempt)
+}
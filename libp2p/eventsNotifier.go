@@ -0,0 +1,156 @@
+package libp2p
+
+import (
+	"sync"
+	"time"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+)
+
+const defaultEventsBufferSize = 100
+
+var _ p2p.EventsNotifier = (*eventsNotifier)(nil)
+
+type eventsSubscription struct {
+	types  map[p2p.EventType]struct{}
+	topics map[string]struct{}
+	events chan p2p.Event
+}
+
+func newEventsSubscription(filter p2p.EventFilter) *eventsSubscription {
+	bufferSize := filter.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultEventsBufferSize
+	}
+
+	subscription := &eventsSubscription{
+		types:  make(map[p2p.EventType]struct{}, len(filter.Types)),
+		topics: make(map[string]struct{}, len(filter.Topics)),
+		events: make(chan p2p.Event, bufferSize),
+	}
+	for _, eventType := range filter.Types {
+		subscription.types[eventType] = struct{}{}
+	}
+	for _, topic := range filter.Topics {
+		subscription.topics[topic] = struct{}{}
+	}
+
+	return subscription
+}
+
+func (subscription *eventsSubscription) accepts(event p2p.Event) bool {
+	if len(subscription.types) > 0 {
+		_, found := subscription.types[event.Type]
+		if !found {
+			return false
+		}
+	}
+	if len(subscription.topics) > 0 && len(event.Topic) > 0 {
+		_, found := subscription.topics[event.Topic]
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// eventsNotifier dispatches the network events to the subscribers without blocking the caller
+type eventsNotifier struct {
+	mut            sync.RWMutex
+	subscriptions  []*eventsSubscription
+	isClosed       bool
+	getTimeHandler func() time.Time
+}
+
+func newEventsNotifier() *eventsNotifier {
+	return &eventsNotifier{
+		subscriptions:  make([]*eventsSubscription, 0),
+		getTimeHandler: time.Now,
+	}
+}
+
+// Subscribe returns a channel on which the events matching the filter will be delivered
+func (en *eventsNotifier) Subscribe(filter p2p.EventFilter) <-chan p2p.Event {
+	subscription := newEventsSubscription(filter)
+
+	en.mut.Lock()
+	defer en.mut.Unlock()
+
+	if en.isClosed {
+		close(subscription.events)
+		return subscription.events
+	}
+	en.subscriptions = append(en.subscriptions, subscription)
+
+	return subscription.events
+}
+
+// Unsubscribe removes the subscription that uses the provided channel and closes the channel
+func (en *eventsNotifier) Unsubscribe(events <-chan p2p.Event) error {
+	en.mut.Lock()
+	defer en.mut.Unlock()
+
+	for idx, subscription := range en.subscriptions {
+		if subscription.events != events {
+			continue
+		}
+
+		en.subscriptions = append(en.subscriptions[:idx], en.subscriptions[idx+1:]...)
+		close(subscription.events)
+
+		return nil
+	}
+
+	return p2p.ErrEventsSubscriptionNotFound
+}
+
+// NotifyEvent delivers the event to all the matching subscribers. If a subscriber's buffer is full, the event is
+// dropped for that subscriber
+func (en *eventsNotifier) NotifyEvent(event p2p.Event) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = en.getTimeHandler()
+	}
+
+	en.mut.RLock()
+	defer en.mut.RUnlock()
+
+	for _, subscription := range en.subscriptions {
+		if !subscription.accepts(event) {
+			continue
+		}
+
+		select {
+		case subscription.events <- event:
+		default:
+			log.Trace("eventsNotifier.NotifyEvent: subscriber buffer full, event dropped",
+				"type", event.Type.String(),
+				"pid", event.Peer.Pretty(),
+				"topic", event.Topic,
+			)
+		}
+	}
+}
+
+// Close closes all the subscriptions channels
+func (en *eventsNotifier) Close() error {
+	en.mut.Lock()
+	defer en.mut.Unlock()
+
+	if en.isClosed {
+		return nil
+	}
+	en.isClosed = true
+
+	for _, subscription := range en.subscriptions {
+		close(subscription.events)
+	}
+	en.subscriptions = make([]*eventsSubscription, 0)
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (en *eventsNotifier) IsInterfaceNil() bool {
+	return en == nil
+}
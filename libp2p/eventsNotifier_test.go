@@ -0,0 +1,103 @@
+package libp2p_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEventsNotifier(t *testing.T) {
+	t.Parallel()
+
+	en := libp2p.NewEventsNotifier()
+	assert.False(t, check.IfNil(en))
+}
+
+func TestEventsNotifier_NotifyEvent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should deliver the events matching the filter", func(t *testing.T) {
+		t.Parallel()
+
+		en := libp2p.NewEventsNotifier()
+		currentTime := time.Now()
+		en.SetTimeHandler(func() time.Time {
+			return currentTime
+		})
+
+		allEvents := en.Subscribe(p2p.EventFilter{})
+		topicEvents := en.Subscribe(p2p.EventFilter{
+			Types:  []p2p.EventType{p2p.PeerJoinedTopicEvent},
+			Topics: []string{"topic"},
+		})
+
+		en.NotifyEvent(p2p.Event{Type: p2p.PeerConnectedEvent, Peer: "pid"})
+		en.NotifyEvent(p2p.Event{Type: p2p.PeerJoinedTopicEvent, Peer: "pid", Topic: "other topic"})
+		en.NotifyEvent(p2p.Event{Type: p2p.PeerJoinedTopicEvent, Peer: "pid", Topic: "topic"})
+
+		assert.Equal(t, 3, len(allEvents))
+		assert.Equal(t, 1, len(topicEvents))
+		expectedEvent := p2p.Event{
+			Type:      p2p.PeerJoinedTopicEvent,
+			Peer:      "pid",
+			Topic:     "topic",
+			Timestamp: currentTime,
+		}
+		assert.Equal(t, expectedEvent, <-topicEvents)
+	})
+	t.Run("full buffer should drop the events", func(t *testing.T) {
+		t.Parallel()
+
+		en := libp2p.NewEventsNotifier()
+		events := en.Subscribe(p2p.EventFilter{BufferSize: 1})
+
+		en.NotifyEvent(p2p.Event{Type: p2p.PeerConnectedEvent, Peer: "pid1"})
+		en.NotifyEvent(p2p.Event{Type: p2p.PeerConnectedEvent, Peer: "pid2"})
+
+		assert.Equal(t, 1, len(events))
+		assert.Equal(t, core.PeerID("pid1"), (<-events).Peer)
+	})
+}
+
+func TestEventsNotifier_Unsubscribe(t *testing.T) {
+	t.Parallel()
+
+	en := libp2p.NewEventsNotifier()
+	events := en.Subscribe(p2p.EventFilter{})
+
+	err := en.Unsubscribe(events)
+	assert.Nil(t, err)
+	_, isOpen := <-events
+	assert.False(t, isOpen)
+
+	err = en.Unsubscribe(events)
+	assert.Equal(t, p2p.ErrEventsSubscriptionNotFound, err)
+
+	// notifying after unsubscribe should not panic
+	en.NotifyEvent(p2p.Event{Type: p2p.PeerConnectedEvent})
+}
+
+func TestEventsNotifier_Close(t *testing.T) {
+	t.Parallel()
+
+	en := libp2p.NewEventsNotifier()
+	events := en.Subscribe(p2p.EventFilter{})
+
+	err := en.Close()
+	assert.Nil(t, err)
+	_, isOpen := <-events
+	assert.False(t, isOpen)
+
+	// subscribing after close should return a closed channel
+	events = en.Subscribe(p2p.EventFilter{})
+	_, isOpen = <-events
+	assert.False(t, isOpen)
+
+	err = en.Close()
+	assert.Nil(t, err)
+}
@@ -7,12 +7,18 @@ import (
 	"github.com/TerraDharitri/drt-go-chain-core/core"
 	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
 	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	"github.com/TerraDharitri/drt-go-chain-p2p/data"
+	"github.com/TerraDharitri/drt-go-chain-p2p/peersHolder"
 	"github.com/TerraDharitri/drt-go-chain-storage/types"
 	"github.com/libp2p/go-libp2p"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/multiformats/go-multiaddr"
 	"github.com/whyrusleeping/timecache"
 )
 
@@ -23,6 +29,7 @@ var AcceptMessagesInAdvanceDuration = acceptMessagesInAdvanceDuration
 var SequenceNumberSize = sequenceNumberSize
 
 const CurrentTopicMessageVersion = currentTopicMessageVersion
+const CompressedTopicMessageVersion = compressedTopicMessageVersion
 const PollWaitForConnectionsInterval = pollWaitForConnectionsInterval
 
 // SetHost -
@@ -40,14 +47,24 @@ func (netMes *networkMessenger) SetPeerDiscoverer(discoverer p2p.PeerDiscoverer)
 	netMes.peerDiscoverer = discoverer
 }
 
+// SetSharder -
+func (netMes *networkMessenger) SetSharder(sharder p2p.Sharder) {
+	netMes.sharder = sharder
+}
+
 // PubsubCallback -
-func (netMes *networkMessenger) PubsubCallback(handler p2p.MessageProcessor, topic string) func(ctx context.Context, pid peer.ID, message *pubsub.Message) bool {
+func (netMes *networkMessenger) PubsubCallback(handler p2p.MessageProcessor, topic string) func(ctx context.Context, pid peer.ID, message *pubsub.Message) pubsub.ValidationResult {
 	topicProcs := newTopicProcessors()
 	_ = topicProcs.addTopicProcessor("identifier", handler)
 
 	return netMes.pubsubCallback(topicProcs, topic)
 }
 
+// PubsubCallbackWithTopicProcessors -
+func (netMes *networkMessenger) PubsubCallbackWithTopicProcessors(topicProcs *topicProcessors, topic string) func(ctx context.Context, pid peer.ID, message *pubsub.Message) pubsub.ValidationResult {
+	return netMes.pubsubCallback(topicProcs, topic)
+}
+
 // ValidMessageByTimestamp -
 func (netMes *networkMessenger) ValidMessageByTimestamp(msg p2p.MessageP2P) error {
 	return netMes.validMessageByTimestamp(msg)
@@ -121,6 +138,26 @@ func (oplb *OutgoingChannelLoadBalancer) NamesChans() map[string]chan *SendableD
 	return oplb.namesChans
 }
 
+// EnqueueAfterRemovingChannel simulates an Enqueue call that fetched the channel queue right before the channel was removed
+func (oplb *OutgoingChannelLoadBalancer) EnqueueAfterRemovingChannel(channel string, data *SendableData) error {
+	oplb.mut.RLock()
+	var queue *channelQueue
+	for idx, name := range oplb.names {
+		if name == channel {
+			queue = oplb.queues[idx]
+		}
+	}
+	oplb.mut.RUnlock()
+
+	_ = oplb.RemoveChannel(channel)
+	_, err := oplb.enqueue(queue, data)
+
+	return err
+}
+
+// DefaultChannelMaxQueueDepth -
+const DefaultChannelMaxQueueDepth = defaultChannelMaxQueueDepth
+
 func DefaultSendChannel() string {
 	return defaultSendChannel
 }
@@ -129,8 +166,36 @@ func NewConnectionMonitorWrapper(
 	network network.Network,
 	connMonitor ConnectionMonitor,
 	peerDenialEvaluator p2p.PeerDenialEvaluator,
+	eventsNotifier p2p.EventsNotifier,
 ) *connectionMonitorWrapper {
-	return newConnectionMonitorWrapper(network, connMonitor, peerDenialEvaluator)
+	preferredPeersHolder, _ := peersHolder.NewPeersHolder(nil)
+
+	return newConnectionMonitorWrapper(
+		network,
+		connMonitor,
+		peerDenialEvaluator,
+		eventsNotifier,
+		preferredPeersHolder,
+		config.PeerDenialConfig{},
+	)
+}
+
+func NewConnectionMonitorWrapperWithCaps(
+	network network.Network,
+	connMonitor ConnectionMonitor,
+	peerDenialEvaluator p2p.PeerDenialEvaluator,
+	eventsNotifier p2p.EventsNotifier,
+	preferredPeersHolder p2p.PreferredPeersHolderHandler,
+	peerDenialConfig config.PeerDenialConfig,
+) *connectionMonitorWrapper {
+	return newConnectionMonitorWrapper(
+		network,
+		connMonitor,
+		peerDenialEvaluator,
+		eventsNotifier,
+		preferredPeersHolder,
+		peerDenialConfig,
+	)
 }
 
 func NewPeersOnChannel(
@@ -192,3 +257,206 @@ func NewUnknownPeerShardResolver() *unknownPeerShardResolver {
 func ParseTransportOptions(configs config.TransportConfig, port int) ([]libp2p.Option, []string, error) {
 	return parseTransportOptions(configs, port)
 }
+
+// CreatePeerScoreOptions -
+func CreatePeerScoreOptions(cfg config.PeerScoringConfig, inspect func(scores map[peer.ID]float64)) ([]pubsub.Option, error) {
+	return createPeerScoreOptions(cfg, inspect)
+}
+
+// UpdateMeshScores -
+func (netMes *networkMessenger) UpdateMeshScores(scores map[peer.ID]float64) {
+	netMes.updateMeshScores(scores)
+}
+
+// MaxRequestBuffSize -
+const MaxRequestBuffSize = maxRequestBuffSize
+
+// MaxConcurrentRequestsPerPeer -
+const MaxConcurrentRequestsPerPeer = maxConcurrentRequestsPerPeer
+
+// MaxChunkPayloadSize -
+var MaxChunkPayloadSize = maxChunkPayloadSize
+
+// LargePayloadChunkTopic -
+const LargePayloadChunkTopic = largePayloadChunkTopic
+
+// NewChunksAssembler -
+func NewChunksAssembler(maxPayloadSize uint64, maxPendingSize uint64, timeout time.Duration) (*chunksAssembler, error) {
+	return NewChunksAssemblerWithPeerLimits(maxPayloadSize, maxPendingSize, 0, 0, timeout)
+}
+
+// NewChunksAssemblerWithPeerLimits -
+func NewChunksAssemblerWithPeerLimits(
+	maxPayloadSize uint64,
+	maxPendingSize uint64,
+	maxPendingSizePerPeer uint64,
+	maxPendingTransfersPerPeer uint32,
+	timeout time.Duration,
+) (*chunksAssembler, error) {
+	return newChunksAssembler(config.LargePayloadsConfig{
+		Enabled:                      true,
+		MaxPayloadSizeInBytes:        maxPayloadSize,
+		MaxPendingSizeInBytes:        maxPendingSize,
+		MaxPendingSizePerPeerInBytes: maxPendingSizePerPeer,
+		MaxPendingTransfersPerPeer:   maxPendingTransfersPerPeer,
+		TransferTimeoutInSec:         uint32(timeout / time.Second),
+	})
+}
+
+// SplitInChunks -
+func SplitInChunks(transferID uint64, topic string, buff []byte, chunkSize int) []*data.ChunkMessage {
+	return splitInChunks(transferID, topic, buff, chunkSize)
+}
+
+// AddChunk -
+func (ca *chunksAssembler) AddChunk(chunk *data.ChunkMessage, from core.PeerID) ([]byte, error) {
+	return ca.addChunk(chunk, from)
+}
+
+// SetTimeHandler -
+func (ca *chunksAssembler) SetTimeHandler(handler func() time.Time) {
+	ca.getTimeHandler = handler
+}
+
+// NumPendingTransfers -
+func (ca *chunksAssembler) NumPendingTransfers() int {
+	ca.mut.Lock()
+	defer ca.mut.Unlock()
+
+	return len(ca.transfers)
+}
+
+// PendingSize -
+func (ca *chunksAssembler) PendingSize() uint64 {
+	ca.mut.Lock()
+	defer ca.mut.Unlock()
+
+	return ca.pendingSize
+}
+
+// CompressPayload -
+func CompressPayload(codec p2p.CompressionCodec, buff []byte) ([]byte, error) {
+	return compressPayload(codec, buff)
+}
+
+// DirectMessageHandler -
+func (netMes *networkMessenger) DirectMessageHandler(message *pubsub.Message, fromConnectedPeer core.PeerID) error {
+	return netMes.directMessageHandler(message, fromConnectedPeer)
+}
+
+// NewEventsNotifier -
+func NewEventsNotifier() *eventsNotifier {
+	return newEventsNotifier()
+}
+
+// SetTimeHandler -
+func (en *eventsNotifier) SetTimeHandler(handler func() time.Time) {
+	en.getTimeHandler = handler
+}
+
+// CreateRelayOptions -
+func CreateRelayOptions(relayConfig config.RelayConfig) ([]libp2p.Option, error) {
+	return createRelayOptions(relayConfig)
+}
+
+// GetNumRelays -
+func GetNumRelays(clientConfig config.RelayClientConfig, numStaticRelays int) int {
+	return getNumRelays(clientConfig, numStaticRelays)
+}
+
+// NewRelayedConnectionsChecker -
+func NewRelayedConnectionsChecker(netw network.Network) *relayedConnectionsChecker {
+	return newRelayedConnectionsChecker(netw)
+}
+
+// NewPeersLatencyTracker -
+func NewPeersLatencyTracker(ctx context.Context, h host.Host, pingInterval time.Duration) (*peersLatencyTracker, error) {
+	return newPeersLatencyTracker(ctx, h, pingInterval)
+}
+
+// StartPinging -
+func (tracker *peersLatencyTracker) StartPinging(pid peer.ID) {
+	tracker.startPinging(pid)
+}
+
+// NewPeersIPResolver -
+func NewPeersIPResolver(netw network.Network) *peersIPResolver {
+	return newPeersIPResolver(netw)
+}
+
+// CreateAddressesFactory -
+func CreateAddressesFactory(announcedConfig config.AnnouncedAddressesConfig, port int) (func([]multiaddr.Multiaddr) []multiaddr.Multiaddr, error) {
+	return createAddressesFactory(announcedConfig, port)
+}
+
+// NewReachabilityWatcher -
+func NewReachabilityWatcher(ctx context.Context, bus event.Bus, eventsNotifier p2p.EventsNotifier) (*reachabilityWatcher, error) {
+	return newReachabilityWatcher(ctx, bus, eventsNotifier)
+}
+
+// NewPersistentPeerstore -
+func NewPersistentPeerstore(
+	persister types.Persister,
+	ps peerstore.Peerstore,
+	recordTTL time.Duration,
+	maxPeers int,
+	gcInterval time.Duration,
+) (*persistentPeerstore, error) {
+	return newPersistentPeerstore(argsPersistentPeerstore{
+		persister:  persister,
+		peerstore:  ps,
+		recordTTL:  recordTTL,
+		maxPeers:   maxPeers,
+		gcInterval: gcInterval,
+	})
+}
+
+// SetTimeHandler -
+func (pps *persistentPeerstore) SetTimeHandler(handler func() time.Time) {
+	pps.mut.Lock()
+	pps.getTimeHandler = handler
+	pps.mut.Unlock()
+}
+
+// SavePeer -
+func (pps *persistentPeerstore) SavePeer(pid peer.ID) {
+	pps.savePeer(pid)
+}
+
+// UpdateKnownPeers -
+func (pps *persistentPeerstore) UpdateKnownPeers(pids []peer.ID) {
+	pps.updateKnownPeers(pids)
+}
+
+// StartWatching -
+func (pps *persistentPeerstore) StartWatching(ctx context.Context, bus event.Bus) error {
+	return pps.startWatching(ctx, bus)
+}
+
+// RemoveExpired -
+func (pps *persistentPeerstore) RemoveExpired() {
+	pps.removeExpired()
+}
+
+// NumRecords -
+func (pps *persistentPeerstore) NumRecords() int {
+	pps.mut.Lock()
+	defer pps.mut.Unlock()
+
+	return len(pps.records)
+}
+
+// NewConnectionGater -
+func NewConnectionGater(gaterConfig config.ConnectionGaterConfig) (*connectionGater, error) {
+	return newConnectionGater(gaterConfig)
+}
+
+// CreateResourceManager -
+func CreateResourceManager(p2pConfig config.P2PConfig) (network.ResourceManager, error) {
+	return createResourceManager(p2pConfig)
+}
+
+// ComputeWarmStartThreshold -
+func ComputeWarmStartThreshold(minConnectedPeers int, numDialed int) uint32 {
+	return computeWarmStartThreshold(minConnectedPeers, numDialed)
+}
@@ -1,8 +1,12 @@
 package libp2p
 
 import (
+	"context"
+	"time"
+
 	"github.com/TerraDharitri/drt-go-chain-core/core"
 	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -14,6 +18,7 @@ type ConnectionMonitor interface {
 	IsConnectedToTheNetwork(netw network.Network) bool
 	SetThresholdMinConnectedPeers(thresholdMinConnectedPeers int, netw network.Network)
 	ThresholdMinConnectedPeers() int
+	TrimConnections(netw network.Network)
 	Close() error
 	IsInterfaceNil() bool
 }
@@ -24,6 +29,22 @@ type PeerDiscovererWithSharder interface {
 	SetSharder(sharder p2p.Sharder) error
 }
 
+type shardingConfigUpdater interface {
+	UpdateShardingConfig(shardingConfig config.ShardingConfig) error
+}
+
+type peersRefreshIntervalSetter interface {
+	SetPeersRefreshInterval(interval time.Duration) error
+}
+
+type seedersStatusProvider interface {
+	SeedersStatus() []p2p.SeederStatus
+}
+
+type evictionExplainer interface {
+	ExplainEviction(pidList []peer.ID) []p2p.PeerEvictionExplanation
+}
+
 type p2pSigner interface {
 	Sign(payload []byte) ([]byte, error)
 	Verify(payload []byte, pid core.PeerID, signature []byte) error
@@ -36,14 +57,50 @@ type SendableData struct {
 	Topic string
 	Sk    crypto.PrivKey
 	ID    peer.ID
+	// Ctx, if set, drops the data if it is done before the data is sent
+	Ctx context.Context
+	// ResultHandler, if set, is called with the result of the sending
+	ResultHandler func(err error)
+}
+
+func (data *SendableData) notifyResult(err error) {
+	if data.ResultHandler != nil {
+		data.ResultHandler(err)
+	}
 }
 
 // ChannelLoadBalancer defines what a load balancer that uses chans should do
 type ChannelLoadBalancer interface {
 	AddChannel(channel string) error
+	AddChannelWithOptions(channel string, options p2p.ChannelOptions) error
 	RemoveChannel(channel string) error
 	GetChannelOrDefault(channel string) chan *SendableData
+	Enqueue(channel string, data *SendableData) error
 	CollectOneElementFromChannels() *SendableData
+	ChannelsStats() []p2p.ChannelStats
+	DiscardAll() int
 	Close() error
 	IsInterfaceNil() bool
 }
+
+// PeerTopicRateLimiter defines the behavior of a component able to limit the inbound messages rate of each
+// (peer, topic) pair
+type PeerTopicRateLimiter interface {
+	Allow(pid core.PeerID, topic string) (isAllowed bool, shouldDeny bool)
+	IsInterfaceNil() bool
+}
+
+// InboundRateLimitChecker defines the behavior of a component able to tell if a message or a request received from a
+// connected peer exceeds the inbound rate limits
+type InboundRateLimitChecker interface {
+	IsRateLimited(fromConnectedPeer core.PeerID, topic string, size int) bool
+	IsInterfaceNil() bool
+}
+
+// RequestResponseHandler defines the behavior of a component able to send requests and to answer the received ones
+type RequestResponseHandler interface {
+	SendRequest(ctx context.Context, topic string, buff []byte, pid core.PeerID) ([]byte, error)
+	RegisterRequestResponder(topic string, responder p2p.RequestResponder) error
+	UnregisterRequestResponder(topic string) error
+	IsInterfaceNil() bool
+}
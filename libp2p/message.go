@@ -12,18 +12,29 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
-const currentTopicMessageVersion = uint32(1)
+const (
+	currentTopicMessageVersion = uint32(1)
+	// compressedTopicMessageVersion is used for the messages sent on topics that opted in for payload compression.
+	// Both versions are accepted on receive so the topics can opt in while older nodes are still in the network
+	compressedTopicMessageVersion = uint32(2)
+)
 
 // NewMessage returns a new instance of a Message object
 func NewMessage(msg *pubsub.Message, marshalizer p2p.Marshalizer) (*message.Message, error) {
+	newMsg, _, err := newMessage(msg, marshalizer)
+	return newMsg, err
+}
+
+// newMessage also returns the size of the compressed payload or 0 if the payload was not compressed
+func newMessage(msg *pubsub.Message, marshalizer p2p.Marshalizer) (*message.Message, int, error) {
 	if check.IfNil(marshalizer) {
-		return nil, p2p.ErrNilMarshalizer
+		return nil, 0, p2p.ErrNilMarshalizer
 	}
 	if msg == nil {
-		return nil, p2p.ErrNilMessage
+		return nil, 0, p2p.ErrNilMessage
 	}
 	if msg.Topic == nil {
-		return nil, p2p.ErrNilTopic
+		return nil, 0, p2p.ErrNilTopic
 	}
 
 	newMsg := &message.Message{
@@ -38,28 +49,60 @@ func NewMessage(msg *pubsub.Message, marshalizer p2p.Marshalizer) (*message.Mess
 	topicMessage := &data.TopicMessage{}
 	err := marshalizer.Unmarshal(topicMessage, msg.Data)
 	if err != nil {
-		return nil, fmt.Errorf("%w error: %s", p2p.ErrMessageUnmarshalError, err.Error())
-	}
-
-	// TODO change this area when new versions of the message will need to be implemented
-	if topicMessage.Version != currentTopicMessageVersion {
-		return nil, fmt.Errorf("%w, supported %d, got %d",
-			p2p.ErrUnsupportedMessageVersion, currentTopicMessageVersion, topicMessage.Version)
+		return nil, 0, fmt.Errorf("%w error: %s", p2p.ErrMessageUnmarshalError, err.Error())
 	}
 
 	if len(topicMessage.SignatureOnPid)+len(topicMessage.Pk) > 0 {
-		return nil, fmt.Errorf("%w for topicMessage.SignatureOnPid and topicMessage.Pk",
+		return nil, 0, fmt.Errorf("%w for topicMessage.SignatureOnPid and topicMessage.Pk",
 			p2p.ErrUnsupportedFields)
 	}
 
+	compressedSize := 0
+	switch topicMessage.Version {
+	case currentTopicMessageVersion:
+		if topicMessage.Compression != uint32(p2p.NoCompression) {
+			return nil, 0, fmt.Errorf("%w for topicMessage.Compression on version %d",
+				p2p.ErrUnsupportedFields, currentTopicMessageVersion)
+		}
+	case compressedTopicMessageVersion:
+		codec := p2p.CompressionCodec(topicMessage.Compression)
+		if codec != p2p.NoCompression {
+			compressedSize = len(topicMessage.Payload)
+		}
+
+		topicMessage.Payload, err = decompressPayload(codec, topicMessage.Payload)
+		if err != nil {
+			return nil, 0, err
+		}
+	default:
+		return nil, 0, fmt.Errorf("%w, supported %d and %d, got %d", p2p.ErrUnsupportedMessageVersion,
+			currentTopicMessageVersion, compressedTopicMessageVersion, topicMessage.Version)
+	}
+
 	newMsg.DataField = topicMessage.Payload
 	newMsg.TimestampField = topicMessage.Timestamp
 
 	id, err := peer.IDFromBytes(newMsg.From())
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	newMsg.PeerField = core.PeerID(id)
-	return newMsg, nil
+	return newMsg, compressedSize, nil
+}
+
+// newAssembledMessage returns a message holding the payload reassembled from chunks. The originator fields are taken
+// from the last received chunk
+func newAssembledMessage(lastChunk p2p.MessageP2P, topic string, payload []byte) *message.Message {
+	return &message.Message{
+		FromField:      lastChunk.From(),
+		DataField:      payload,
+		PayloadField:   lastChunk.Payload(),
+		SeqNoField:     lastChunk.SeqNo(),
+		TopicField:     topic,
+		SignatureField: lastChunk.Signature(),
+		KeyField:       lastChunk.Key(),
+		PeerField:      lastChunk.Peer(),
+		TimestampField: lastChunk.Timestamp(),
+	}
 }
@@ -1,6 +1,7 @@
 package libp2p_test
 
 import (
+	"bytes"
 	"crypto/rand"
 	"errors"
 	"testing"
@@ -141,7 +142,7 @@ func TestMessage_WrongVersionShouldErr(t *testing.T) {
 	marshalizer := &mock.ProtoMarshallerMock{}
 
 	topicMessage := &data.TopicMessage{
-		Version:   libp2p.CurrentTopicMessageVersion + 1,
+		Version:   libp2p.CompressedTopicMessageVersion + 1,
 		Timestamp: time.Now().Unix(),
 		Payload:   []byte("data"),
 	}
@@ -160,6 +161,107 @@ func TestMessage_WrongVersionShouldErr(t *testing.T) {
 	assert.True(t, errors.Is(err, p2p.ErrUnsupportedMessageVersion))
 }
 
+func createPubsubMessageWithTopicMessage(topicMessage *data.TopicMessage) *pubsub.Message {
+	buff, _ := (&mock.ProtoMarshallerMock{}).Marshal(topicMessage)
+	topic := "topic"
+	mes := &pb.Message{
+		From:  getRandomID(),
+		Data:  buff,
+		Topic: &topic,
+	}
+
+	return &pubsub.Message{Message: mes}
+}
+
+func TestMessage_CompressedPayload(t *testing.T) {
+	t.Parallel()
+
+	payload := bytes.Repeat([]byte("compressible payload "), 100)
+
+	t.Run("compressed payloads should work", func(t *testing.T) {
+		t.Parallel()
+
+		for _, codec := range []p2p.CompressionCodec{p2p.NoCompression, p2p.SnappyCompression, p2p.ZstdCompression} {
+			compressed, err := libp2p.CompressPayload(codec, payload)
+			require.Nil(t, err)
+
+			pMes := createPubsubMessageWithTopicMessage(&data.TopicMessage{
+				Version:     libp2p.CompressedTopicMessageVersion,
+				Timestamp:   time.Now().Unix(),
+				Payload:     compressed,
+				Compression: uint32(codec),
+			})
+			m, err := libp2p.NewMessage(pMes, &mock.ProtoMarshallerMock{})
+
+			require.Nil(t, err, codec.String())
+			assert.Equal(t, payload, m.Data(), codec.String())
+		}
+	})
+	t.Run("compression set on the first version should error", func(t *testing.T) {
+		t.Parallel()
+
+		compressed, _ := libp2p.CompressPayload(p2p.SnappyCompression, payload)
+		pMes := createPubsubMessageWithTopicMessage(&data.TopicMessage{
+			Version:     libp2p.CurrentTopicMessageVersion,
+			Timestamp:   time.Now().Unix(),
+			Payload:     compressed,
+			Compression: uint32(p2p.SnappyCompression),
+		})
+		m, err := libp2p.NewMessage(pMes, &mock.ProtoMarshallerMock{})
+
+		assert.True(t, check.IfNil(m))
+		assert.True(t, errors.Is(err, p2p.ErrUnsupportedFields))
+	})
+	t.Run("unsupported codec should error", func(t *testing.T) {
+		t.Parallel()
+
+		pMes := createPubsubMessageWithTopicMessage(&data.TopicMessage{
+			Version:     libp2p.CompressedTopicMessageVersion,
+			Timestamp:   time.Now().Unix(),
+			Payload:     payload,
+			Compression: 100,
+		})
+		m, err := libp2p.NewMessage(pMes, &mock.ProtoMarshallerMock{})
+
+		assert.True(t, check.IfNil(m))
+		assert.True(t, errors.Is(err, p2p.ErrUnsupportedCompressionCodec))
+	})
+	t.Run("corrupted payload should error", func(t *testing.T) {
+		t.Parallel()
+
+		for _, codec := range []p2p.CompressionCodec{p2p.SnappyCompression, p2p.ZstdCompression} {
+			pMes := createPubsubMessageWithTopicMessage(&data.TopicMessage{
+				Version:     libp2p.CompressedTopicMessageVersion,
+				Timestamp:   time.Now().Unix(),
+				Payload:     []byte("not a compressed payload"),
+				Compression: uint32(codec),
+			})
+			m, err := libp2p.NewMessage(pMes, &mock.ProtoMarshallerMock{})
+
+			assert.True(t, check.IfNil(m), codec.String())
+			assert.True(t, errors.Is(err, p2p.ErrDecompressionFailed), codec.String())
+		}
+	})
+	t.Run("decompressed size over the limit should error", func(t *testing.T) {
+		t.Parallel()
+
+		largePayload := make([]byte, libp2p.MaxSendBuffSize+1)
+		for _, codec := range []p2p.CompressionCodec{p2p.SnappyCompression, p2p.ZstdCompression} {
+			compressed, _ := libp2p.CompressPayload(codec, largePayload)
+			pMes := createPubsubMessageWithTopicMessage(&data.TopicMessage{
+				Version:     libp2p.CompressedTopicMessageVersion,
+				Timestamp:   time.Now().Unix(),
+				Payload:     compressed,
+				Compression: uint32(codec),
+			})
+			m, err := libp2p.NewMessage(pMes, &mock.ProtoMarshallerMock{})
+
+			assert.True(t, check.IfNil(m), codec.String())
+			assert.True(t, errors.Is(err, p2p.ErrDecompressionFailed), codec.String())
+		}
+	})
+}
+
 func TestMessage_PopulatedPkFieldShouldErr(t *testing.T) {
 	t.Parallel()
 
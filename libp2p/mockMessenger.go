@@ -36,12 +36,19 @@ func NewMockMessenger(
 		return nil, err
 	}
 
+	// the mocked network does not support connection gating, the gater is created only to hold the denial evaluator
+	connGater, err := newConnectionGater(args.P2pConfig.ConnectionGater)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx, cancelFunc := context.WithCancel(context.Background())
 	p2pNode := &networkMessenger{
 		p2pSigner:  signer,
 		p2pHost:    NewConnectableHost(h),
 		ctx:        ctx,
 		cancelFunc: cancelFunc,
+		connGater:  connGater,
 	}
 	p2pNode.printConnectionsWatcher, err = factory.NewConnectionsWatcher(args.ConnectionWatcherType, ttlConnectionsWatcher)
 	if err != nil {
@@ -3,10 +3,12 @@ package libp2p
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/TerraDharitri/drt-go-chain-core/core"
@@ -21,10 +23,13 @@ import (
 	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/connectionMonitor"
 	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/crypto"
 	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/disabled"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/discovery"
 	discoveryFactory "github.com/TerraDharitri/drt-go-chain-p2p/libp2p/discovery/factory"
 	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/metrics"
 	metricsFactory "github.com/TerraDharitri/drt-go-chain-p2p/libp2p/metrics/factory"
 	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/networksharding/factory"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/rateLimiter"
+	"github.com/TerraDharitri/drt-go-chain-storage/leveldb"
 	logging "github.com/ipfs/go-log"
 	"github.com/libp2p/go-libp2p"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -44,6 +49,7 @@ const (
 	DirectSendID = protocol.ID("/drt/directsend/1.0.0")
 
 	durationBetweenSends            = time.Microsecond * 10
+	durationCheckDrain              = time.Millisecond * 10
 	durationCheckConnections        = time.Second
 	refreshPeersOnTopic             = time.Second * 3
 	ttlPeersOnTopic                 = time.Second * 10
@@ -96,17 +102,32 @@ type networkMessenger struct {
 	port       int
 	pb         *pubsub.PubSub
 	ds         p2p.DirectSender
+	reqResp    RequestResponseHandler
+	// chunksAssembler is nil when the large payloads feature is disabled
+	chunksAssembler      *chunksAssembler
+	transferCounter      uint64
+	isClosing            uint32
+	numPublishing        int32
+	numFlushed           uint64
+	rateLimiter          PeerTopicRateLimiter
+	rateLimitBanDuration time.Duration
 	// TODO refactor this (connMonitor & connMonitorWrapper)
 	connMonitor             ConnectionMonitor
 	connMonitorWrapper      p2p.ConnectionMonitorWrapper
 	peerDiscoverer          p2p.PeerDiscoverer
 	sharder                 p2p.Sharder
+	relayedConnsChecker     p2p.RelayedConnectionsChecker
+	connGater               *connectionGater
 	peerShardResolver       p2p.PeerShardResolver
 	mutPeerResolver         sync.RWMutex
 	mutTopics               sync.RWMutex
 	processors              map[string]*topicProcessors
 	topics                  map[string]*pubsub.Topic
+	topicOptions            map[string]p2p.TopicOptions
 	subscriptions           map[string]*pubsub.Subscription
+	topicEventHandlers      map[string]*pubsub.TopicEventHandler
+	eventsNotifier          *eventsNotifier
+	reachabilityWatcher     *reachabilityWatcher
 	outgoingPLB             ChannelLoadBalancer
 	poc                     *peersOnChannel
 	goRoutinesThrottler     *throttler.NumGoRoutinesThrottler
@@ -119,6 +140,15 @@ type networkMessenger struct {
 	peersRatingHandler      p2p.PeersRatingHandler
 	mutPeerTopicNotifiers   sync.RWMutex
 	peerTopicNotifiers      []p2p.PeerTopicNotifier
+	mutP2pConfig            sync.Mutex
+	p2pConfig               config.P2PConfig
+	// persistentPeerstore is nil when the persistent peerstore is disabled
+	persistentPeerstore *persistentPeerstore
+	// warmStartHost dials the saved peers through the sharder's admission, nil when the persistent peerstore is disabled
+	warmStartHost discovery.ConnectableHost
+	warmStartDone uint32
+	// limitedProtocols holds the protocols limited by the resource manager, set once when the host is created
+	limitedProtocols []protocol.ID
 }
 
 // ArgsNetworkMessenger defines the options used to create a p2p wrapper
@@ -162,6 +192,10 @@ func newNetworkMessenger(args ArgsNetworkMessenger, messageSigning messageSignin
 	if check.IfNil(args.P2pKeyGenerator) {
 		return nil, fmt.Errorf("%w %s", p2p.ErrNilP2pKeyGenerator, baseErrorSuffix)
 	}
+	err := args.P2pConfig.Validate()
+	if err != nil {
+		return nil, err
+	}
 
 	setupExternalP2PLoggers()
 
@@ -172,13 +206,24 @@ func newNetworkMessenger(args ArgsNetworkMessenger, messageSigning messageSignin
 
 	err = addComponentsToNode(args, p2pNode, messageSigning)
 	if err != nil {
-		log.LogIfError(p2pNode.p2pHost.Close())
+		p2pNode.closeAfterFailedConstruction()
 		return nil, err
 	}
 
 	return p2pNode, nil
 }
 
+// closeAfterFailedConstruction stops the go routines started and releases the resources acquired while adding the
+// components to the node, so a new messenger can be created with the same persistent peerstore database
+func (netMes *networkMessenger) closeAfterFailedConstruction() {
+	netMes.cancelFunc()
+	if netMes.persistentPeerstore != nil {
+		log.LogIfError(netMes.persistentPeerstore.Close())
+		netMes.persistentPeerstore = nil
+	}
+	log.LogIfError(netMes.p2pHost.Close())
+}
+
 func constructNode(
 	args ArgsNetworkMessenger,
 ) (*networkMessenger, error) {
@@ -204,19 +249,46 @@ func constructNode(
 		return nil, err
 	}
 
+	relayOptions, err := createRelayOptions(args.P2pConfig.Relay)
+	if err != nil {
+		return nil, err
+	}
+
+	addrsFactory, err := createAddressesFactory(args.P2pConfig.Node.AnnouncedAddresses, port)
+	if err != nil {
+		return nil, err
+	}
+
+	connGater, err := newConnectionGater(args.P2pConfig.ConnectionGater)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceManager, err := createResourceManager(args.P2pConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	options := []libp2p.Option{
 		libp2p.ListenAddrStrings(addresses...),
 		libp2p.Identity(p2pPrivateKey),
 		libp2p.DefaultMuxers,
 		libp2p.DefaultSecurity,
-		// we need to disable relay option in order to save the node's bandwidth as much as possible
-		libp2p.DisableRelay(),
 		libp2p.NATPortMap(),
+		libp2p.AddrsFactory(addrsFactory),
+		libp2p.ConnectionGater(connGater),
+		libp2p.ResourceManager(resourceManager),
 	}
 	options = append(options, transportOptions...)
+	options = append(options, relayOptions...)
+	if args.P2pConfig.Node.AutoNAT.EnableService {
+		options = append(options, libp2p.EnableNATService())
+	}
 
 	h, err := libp2p.New(options...)
 	if err != nil {
+		// the host closes the resource manager only if it was created
+		_ = resourceManager.Close()
 		return nil, err
 	}
 
@@ -241,6 +313,8 @@ func constructNode(
 		printConnectionsWatcher: connWatcher,
 		peersRatingHandler:      args.PeersRatingHandler,
 		peerTopicNotifiers:      make([]p2p.PeerTopicNotifier, 0),
+		connGater:               connGater,
+		limitedProtocols:        limitedProtocols(args.P2pConfig.KadDhtPeerDiscovery),
 	}
 
 	return p2pNode, nil
@@ -349,18 +423,43 @@ func addComponentsToNode(
 ) error {
 	var err error
 
+	p2pNode.p2pConfig = args.P2pConfig
 	p2pNode.processors = make(map[string]*topicProcessors)
 	p2pNode.topics = make(map[string]*pubsub.Topic)
+	p2pNode.topicOptions = make(map[string]p2p.TopicOptions)
 	p2pNode.subscriptions = make(map[string]*pubsub.Subscription)
+	p2pNode.topicEventHandlers = make(map[string]*pubsub.TopicEventHandler)
+	p2pNode.eventsNotifier = newEventsNotifier()
+	p2pNode.reachabilityWatcher, err = newReachabilityWatcher(p2pNode.ctx, p2pNode.p2pHost.EventBus(), p2pNode.eventsNotifier)
+	if err != nil {
+		return err
+	}
 	p2pNode.outgoingPLB = NewOutgoingChannelLoadBalancer()
 	p2pNode.peerShardResolver = &unknownPeerShardResolver{}
+	p2pNode.relayedConnsChecker = newRelayedConnectionsChecker(p2pNode.p2pHost.Network())
 	p2pNode.marshalizer = args.Marshalizer
 	p2pNode.syncTimer = args.SyncTimer
 	p2pNode.preferredPeersHolder = args.PreferredPeersHolder
 	p2pNode.debugger = debug.NewP2PDebugger(core.PeerID(p2pNode.p2pHost.ID()))
 	p2pNode.peersRatingHandler = args.PeersRatingHandler
+	p2pNode.transferCounter = uint64(time.Now().UnixNano())
 
-	err = p2pNode.createPubSub(messageSigning)
+	err = p2pNode.createChunksAssembler(args.P2pConfig.LargePayloads)
+	if err != nil {
+		return err
+	}
+
+	err = p2pNode.createRateLimiter(args.P2pConfig.InboundRateLimiter)
+	if err != nil {
+		return err
+	}
+
+	err = p2pNode.createPubSub(args.P2pConfig, messageSigning)
+	if err != nil {
+		return err
+	}
+
+	err = p2pNode.createPersistentPeerstore(args.P2pConfig.Peerstore)
 	if err != nil {
 		return err
 	}
@@ -380,6 +479,11 @@ func addComponentsToNode(
 		return err
 	}
 
+	err = p2pNode.createWarmStartHost()
+	if err != nil {
+		return err
+	}
+
 	p2pNode.createConnectionsMetric()
 
 	p2pNode.ds, err = NewDirectSender(p2pNode.ctx, p2pNode.p2pHost, p2pNode.directMessageHandler, p2pNode)
@@ -387,6 +491,18 @@ func addComponentsToNode(
 		return err
 	}
 
+	argsRequestResponse := ArgsRequestResponseHandler{
+		Context:            p2pNode.ctx,
+		Host:               p2pNode.p2pHost,
+		PeersRatingHandler: p2pNode.peersRatingHandler,
+		Debugger:           p2pNode.debugger,
+		RateLimitChecker:   &inboundRateLimitChecker{netMes: p2pNode},
+	}
+	p2pNode.reqResp, err = NewRequestResponseHandler(argsRequestResponse)
+	if err != nil {
+		return err
+	}
+
 	p2pNode.goRoutinesThrottler, err = throttler.NewNumGoRoutinesThrottler(broadcastGoRoutines)
 	if err != nil {
 		return err
@@ -397,7 +513,7 @@ func addComponentsToNode(
 	return nil
 }
 
-func (netMes *networkMessenger) createPubSub(messageSigning messageSigningConfig) error {
+func (netMes *networkMessenger) createPubSub(p2pConfig config.P2PConfig, messageSigning messageSigningConfig) error {
 	optsPS := make([]pubsub.Option, 0)
 	if messageSigning == withoutMessageSigning {
 		log.Warn("signature verification is turned off in network messenger instance. NOT recommended in production environment")
@@ -409,7 +525,12 @@ func (netMes *networkMessenger) createPubSub(messageSigning messageSigningConfig
 		pubsub.WithMaxMessageSize(pubSubMaxMessageSize),
 	)
 
-	var err error
+	optsPeerScore, err := createPeerScoreOptions(p2pConfig.PeerScoring, netMes.updateMeshScores)
+	if err != nil {
+		return err
+	}
+	optsPS = append(optsPS, optsPeerScore...)
+
 	netMes.pb, err = pubsub.NewGossipSub(netMes.ctx, netMes.p2pHost, optsPS...)
 	if err != nil {
 		return err
@@ -438,27 +559,16 @@ func (netMes *networkMessenger) createPubSub(messageSigning messageSigningConfig
 				continue
 			}
 
-			netMes.mutTopics.RLock()
-			topic := netMes.topics[sendableData.Topic]
-			netMes.mutTopics.RUnlock()
-
-			if topic == nil {
-				log.Warn("writing on a topic that the node did not register on - message dropped",
-					"topic", sendableData.Topic,
-				)
-
-				continue
-			}
-
-			packedSendableDataBuff := netMes.createMessageBytes(sendableData.Buff)
-			if len(packedSendableDataBuff) == 0 {
-				continue
-			}
-
-			errPublish := netMes.publish(topic, sendableData, packedSendableDataBuff)
+			atomic.AddInt32(&netMes.numPublishing, 1)
+			errPublish := netMes.publishSendableData(sendableData)
 			if errPublish != nil {
 				log.Trace("error sending data", "error", errPublish)
 			}
+			if errPublish == nil && netMes.closing() {
+				atomic.AddUint64(&netMes.numFlushed, 1)
+			}
+			atomic.AddInt32(&netMes.numPublishing, -1)
+			sendableData.notifyResult(errPublish)
 		}
 	}(netMes.outgoingPLB)
 
@@ -475,6 +585,45 @@ func (netMes *networkMessenger) newPeerFound(pid peer.ID, topic string) bool {
 	return true
 }
 
+func (netMes *networkMessenger) updateMeshScores(scores map[peer.ID]float64) {
+	meshScoresHandler, ok := netMes.peersRatingHandler.(p2p.MeshScoresHandler)
+	if !ok {
+		return
+	}
+
+	meshScores := make(map[core.PeerID]float64, len(scores))
+	for pid, score := range scores {
+		meshScores[core.PeerID(pid)] = score
+	}
+
+	meshScoresHandler.UpdateMeshScores(meshScores)
+}
+
+func (netMes *networkMessenger) publishSendableData(sendableData *SendableData) error {
+	if sendableData.Ctx != nil && sendableData.Ctx.Err() != nil {
+		return sendableData.Ctx.Err()
+	}
+
+	netMes.mutTopics.RLock()
+	topic := netMes.topics[sendableData.Topic]
+	netMes.mutTopics.RUnlock()
+
+	if topic == nil {
+		log.Warn("writing on a topic that the node did not register on - message dropped",
+			"topic", sendableData.Topic,
+		)
+
+		return fmt.Errorf("%w, topic %s", p2p.ErrUnknownTopic, sendableData.Topic)
+	}
+
+	packedSendableDataBuff := netMes.createMessageBytes(sendableData.Topic, sendableData.Buff)
+	if len(packedSendableDataBuff) == 0 {
+		return fmt.Errorf("%w, topic %s", p2p.ErrMessageCreationFailed, sendableData.Topic)
+	}
+
+	return netMes.publish(topic, sendableData, packedSendableDataBuff)
+}
+
 func (netMes *networkMessenger) publish(topic *pubsub.Topic, data *SendableData, packedSendableDataBuff []byte) error {
 	options := make([]pubsub.PubOpt, 0, 1)
 
@@ -485,13 +634,54 @@ func (netMes *networkMessenger) publish(topic *pubsub.Topic, data *SendableData,
 	return topic.Publish(netMes.ctx, packedSendableDataBuff, options...)
 }
 
-func (netMes *networkMessenger) createMessageBytes(buff []byte) []byte {
+func (netMes *networkMessenger) createMessageBytes(topic string, buff []byte) []byte {
+	return netMes.createMessageBytesWithCodec(topic, buff, netMes.getTopicOptions(topic).Compression)
+}
+
+// getTopicOptions returns the options of the provided topic or the default ones if the topic was not created
+func (netMes *networkMessenger) getTopicOptions(topic string) p2p.TopicOptions {
+	netMes.mutTopics.RLock()
+	options, found := netMes.topicOptions[topic]
+	netMes.mutTopics.RUnlock()
+
+	if !found {
+		return applyTopicOptionsDefaults(p2p.TopicOptions{}, netMes.maxAllowedMessageSize())
+	}
+
+	return options
+}
+
+func (netMes *networkMessenger) maxAllowedMessageSize() int {
+	if netMes.chunksAssembler != nil {
+		return int(netMes.chunksAssembler.maxPayloadSize)
+	}
+
+	return maxSendBuffSize
+}
+
+func (netMes *networkMessenger) createMessageBytesWithCodec(topic string, buff []byte, codec p2p.CompressionCodec) []byte {
 	message := &data.TopicMessage{
 		Version:   currentTopicMessageVersion,
 		Payload:   buff,
 		Timestamp: netMes.syncTimer.CurrentTime().Unix(),
 	}
 
+	if codec != p2p.NoCompression {
+		compressed, err := compressPayload(codec, buff)
+		if err != nil {
+			log.Warn("error compressing data", "topic", topic, "error", err)
+			return nil
+		}
+
+		// the version 1 message is kept for the payloads that do not benefit from compression
+		if len(compressed) < len(buff) {
+			message.Version = compressedTopicMessageVersion
+			message.Compression = uint32(codec)
+			message.Payload = compressed
+			netMes.debugger.AddCompressedMessage(topic, uint64(len(buff)), uint64(len(compressed)))
+		}
+	}
+
 	buffToSend, errMarshal := netMes.marshalizer.Marshal(message)
 	if errMarshal != nil {
 		log.Warn("error sending data", "error", errMarshal)
@@ -501,13 +691,83 @@ func (netMes *networkMessenger) createMessageBytes(buff []byte) []byte {
 	return buffToSend
 }
 
+func (netMes *networkMessenger) createChunksAssembler(cfg config.LargePayloadsConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	var err error
+	netMes.chunksAssembler, err = newChunksAssembler(cfg)
+
+	return err
+}
+
+func (netMes *networkMessenger) createRateLimiter(cfg config.InboundRateLimiterConfig) error {
+	if !cfg.Enabled {
+		netMes.rateLimiter = &disabled.PeerTopicRateLimiter{}
+		return nil
+	}
+
+	var err error
+	netMes.rateLimiter, err = rateLimiter.NewPeerTopicRateLimiter(cfg)
+	netMes.rateLimitBanDuration = time.Duration(cfg.BanDurationInSec) * time.Second
+
+	return err
+}
+
+func (netMes *networkMessenger) createPersistentPeerstore(cfg config.PeerstoreConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	persister, err := leveldb.NewDB(cfg.DatabasePath, peerstoreBatchDelayInSec, peerstoreMaxBatchSize, peerstoreMaxOpenFiles)
+	if err != nil {
+		return fmt.Errorf("%w while opening the persistent peerstore database", err)
+	}
+
+	args := argsPersistentPeerstore{
+		persister:  persister,
+		peerstore:  netMes.p2pHost.Peerstore(),
+		recordTTL:  time.Duration(cfg.RecordTTLInSec) * time.Second,
+		maxPeers:   int(cfg.MaxPeers),
+		gcInterval: time.Duration(cfg.GCIntervalInSec) * time.Second,
+	}
+	netMes.persistentPeerstore, err = newPersistentPeerstore(args)
+	if err != nil {
+		log.LogIfError(persister.Close())
+		return err
+	}
+
+	err = netMes.persistentPeerstore.startWatching(netMes.ctx, netMes.p2pHost.EventBus())
+	if err != nil {
+		log.LogIfError(netMes.persistentPeerstore.Close())
+		netMes.persistentPeerstore = nil
+	}
+
+	return err
+}
+
 func (netMes *networkMessenger) createSharder(argsNetMes ArgsNetworkMessenger) error {
 	args := factory.ArgsSharderFactory{
-		PeerShardResolver:    &unknownPeerShardResolver{},
-		Pid:                  netMes.p2pHost.ID(),
-		P2pConfig:            argsNetMes.P2pConfig,
-		PreferredPeersHolder: netMes.preferredPeersHolder,
-		NodeOperationMode:    argsNetMes.NodeOperationMode,
+		PeerShardResolver:         &unknownPeerShardResolver{},
+		Pid:                       netMes.p2pHost.ID(),
+		P2pConfig:                 argsNetMes.P2pConfig,
+		PreferredPeersHolder:      netMes.preferredPeersHolder,
+		NodeOperationMode:         argsNetMes.NodeOperationMode,
+		RelayedConnectionsChecker: netMes.relayedConnsChecker,
+		PeersIPResolver:           newPeersIPResolver(netMes.p2pHost.Network()),
+	}
+
+	shardingConfig := argsNetMes.P2pConfig.Sharding
+	if shardingConfig.Type == p2p.LatencyListsSharder {
+		pingInterval := time.Second * time.Duration(shardingConfig.Latency.PingIntervalInSec)
+		peersLatencyTracker, err := newPeersLatencyTracker(netMes.ctx, netMes.p2pHost, pingInterval)
+		if err != nil {
+			return err
+		}
+
+		netMes.p2pHost.Network().Notify(peersLatencyTracker)
+		args.PeersLatencyProvider = peersLatencyTracker
 	}
 
 	var err error
@@ -532,6 +792,26 @@ func (netMes *networkMessenger) createDiscoverer(p2pConfig config.P2PConfig) err
 	return err
 }
 
+func (netMes *networkMessenger) createWarmStartHost() error {
+	if netMes.persistentPeerstore == nil {
+		return nil
+	}
+
+	sharder, ok := netMes.sharder.(discovery.Sharder)
+	if !ok {
+		return fmt.Errorf("%w in networkMessenger.createWarmStartHost", p2p.ErrWrongTypeAssertion)
+	}
+
+	var err error
+	netMes.warmStartHost, err = discovery.NewHostWithConnectionManagement(discovery.ArgsHostWithConnectionManagement{
+		ConnectableHost:    netMes.p2pHost,
+		Sharder:            sharder,
+		ConnectionsWatcher: netMes.printConnectionsWatcher,
+	})
+
+	return err
+}
+
 func (netMes *networkMessenger) createConnectionMonitor(p2pConfig config.P2PConfig) error {
 	reconnecter, ok := netMes.peerDiscoverer.(p2p.Reconnecter)
 	if !ok {
@@ -549,6 +829,7 @@ func (netMes *networkMessenger) createConnectionMonitor(p2pConfig config.P2PConf
 		ThresholdMinConnectedPeers: p2pConfig.Node.ThresholdMinConnectedPeers,
 		PreferredPeersHolder:       netMes.preferredPeersHolder,
 		ConnectionsWatcher:         netMes.printConnectionsWatcher,
+		EventsNotifier:             netMes.eventsNotifier,
 	}
 	var err error
 	netMes.connMonitor, err = connectionMonitor.NewLibp2pConnectionMonitorSimple(args)
@@ -560,6 +841,9 @@ func (netMes *networkMessenger) createConnectionMonitor(p2pConfig config.P2PConf
 		netMes.p2pHost.Network(),
 		netMes.connMonitor,
 		&disabled.PeerDenialEvaluator{},
+		netMes.eventsNotifier,
+		netMes.preferredPeersHolder,
+		p2pConfig.PeerDenial,
 	)
 	netMes.p2pHost.Network().Notify(cmw)
 	netMes.connMonitorWrapper = cmw
@@ -675,8 +959,74 @@ func (netMes *networkMessenger) checkExternalLoggers() {
 	}
 }
 
+// CloseWithTimeout stops accepting new broadcasts and publishes the messages still queued for at most the
+// provided duration before closing the messenger. The messages not published until the timeout are discarded
+func (netMes *networkMessenger) CloseWithTimeout(timeout time.Duration) (p2p.DrainStats, error) {
+	if timeout < 0 {
+		return p2p.DrainStats{}, fmt.Errorf("%w for the drain timeout, got %v", p2p.ErrInvalidDurationProvided, timeout)
+	}
+
+	atomic.StoreUint32(&netMes.isClosing, 1)
+	numFlushedBefore := atomic.LoadUint64(&netMes.numFlushed)
+
+	netMes.waitPendingMessages(timeout)
+
+	stats := p2p.DrainStats{
+		NumDiscarded: netMes.outgoingPLB.DiscardAll(),
+	}
+	err := netMes.Close()
+	stats.NumFlushed = int(atomic.LoadUint64(&netMes.numFlushed) - numFlushedBefore)
+
+	log.Debug("network messenger drained the outgoing queues",
+		"flushed", stats.NumFlushed,
+		"discarded", stats.NumDiscarded,
+	)
+
+	return stats, err
+}
+
+func (netMes *networkMessenger) closing() bool {
+	return atomic.LoadUint32(&netMes.isClosing) == 1
+}
+
+func (netMes *networkMessenger) waitPendingMessages(timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	numChecksWithoutPending := 0
+	// the queues are considered drained after 2 consecutive checks as the send go routine might have just
+	// collected a message without marking it as being published
+	for numChecksWithoutPending < 2 && time.Now().Before(deadline) {
+		numChecksWithoutPending++
+		if netMes.hasPendingMessages() {
+			numChecksWithoutPending = 0
+		}
+
+		time.Sleep(durationCheckDrain)
+	}
+}
+
+func (netMes *networkMessenger) hasPendingMessages() bool {
+	if atomic.LoadInt32(&netMes.numPublishing) > 0 {
+		return true
+	}
+
+	for _, stats := range netMes.outgoingPLB.ChannelsStats() {
+		if stats.QueueDepth > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Close closes the host, connections and streams
 func (netMes *networkMessenger) Close() error {
+	atomic.StoreUint32(&netMes.isClosing, 1)
+
+	if netMes.persistentPeerstore != nil {
+		log.Debug("saving the connected peers in the persistent peerstore...")
+		netMes.persistentPeerstore.updateKnownPeers(netMes.p2pHost.Network().Peers())
+	}
+
 	log.Debug("closing network messenger's host...")
 
 	var err error
@@ -725,6 +1075,20 @@ func (netMes *networkMessenger) Close() error {
 	log.Debug("closing network messenger's components through the context...")
 	netMes.cancelFunc()
 
+	if netMes.persistentPeerstore != nil {
+		log.Debug("closing network messenger's persistent peerstore...")
+		errPersistentPeerstore := netMes.persistentPeerstore.Close()
+		if errPersistentPeerstore != nil {
+			err = errPersistentPeerstore
+			log.Warn("networkMessenger.Close",
+				"component", "persistentPeerstore",
+				"error", err)
+		}
+	}
+
+	log.Debug("closing network messenger's events notifier...")
+	_ = netMes.eventsNotifier.Close()
+
 	log.Debug("closing network messenger's debugger...")
 	errDebugger := netMes.debugger.Close()
 	if errDebugger != nil {
@@ -783,8 +1147,12 @@ func (netMes *networkMessenger) ConnectToPeer(address string) error {
 	return netMes.p2pHost.ConnectToPeer(netMes.ctx, address)
 }
 
-// Bootstrap will start the peer discovery mechanism
+// Bootstrap will start the peer discovery mechanism. If the persistent peerstore is enabled, the most recently seen
+// peers are redialed first so the node does not depend only on the seeders after a restart. The redial stops waiting
+// as soon as the minimum connected peers threshold is met, the remaining dials continuing in the background
 func (netMes *networkMessenger) Bootstrap() error {
+	netMes.warmStart()
+
 	err := netMes.peerDiscoverer.Bootstrap()
 	if err == nil {
 		log.Info("started the network discovery process...")
@@ -792,6 +1160,76 @@ func (netMes *networkMessenger) Bootstrap() error {
 	return err
 }
 
+func (netMes *networkMessenger) warmStart() {
+	if netMes.persistentPeerstore == nil {
+		return
+	}
+	if !atomic.CompareAndSwapUint32(&netMes.warmStartDone, 0, 1) {
+		return
+	}
+
+	netMes.mutP2pConfig.Lock()
+	cfg := netMes.p2pConfig.Peerstore
+	netMes.mutP2pConfig.Unlock()
+
+	peersInfo := netMes.persistentPeerstore.RecentPeers(int(cfg.WarmStartMaxPeers))
+	if len(peersInfo) == 0 {
+		return
+	}
+
+	// the context is canceled when all dials are done, not when this function returns, so the dials still in
+	// progress after the threshold was met are not aborted
+	ctx, cancel := context.WithTimeout(netMes.ctx, time.Duration(cfg.WarmStartTimeoutInSec)*time.Second)
+	threshold := computeWarmStartThreshold(netMes.connMonitor.ThresholdMinConnectedPeers(), len(peersInfo))
+	chThresholdMet := make(chan struct{})
+	chAllDone := make(chan struct{})
+
+	numConnected := uint32(0)
+	wg := &sync.WaitGroup{}
+	wg.Add(len(peersInfo))
+	for _, peerInfo := range peersInfo {
+		go func(peerInfo peer.AddrInfo) {
+			defer wg.Done()
+
+			err := netMes.warmStartHost.Connect(ctx, peerInfo)
+			if err != nil {
+				log.Trace("warm start: can not connect to peer", "pid", peerInfo.ID.String(), "error", err)
+				return
+			}
+
+			if atomic.AddUint32(&numConnected, 1) == threshold {
+				close(chThresholdMet)
+			}
+		}(peerInfo)
+	}
+	go func() {
+		wg.Wait()
+		cancel()
+		close(chAllDone)
+	}()
+
+	select {
+	case <-chThresholdMet:
+	case <-chAllDone:
+	}
+
+	log.Info("warm start: redialed the recently seen peers",
+		"num dialed", len(peersInfo),
+		"num connected", atomic.LoadUint32(&numConnected),
+		"threshold", threshold,
+	)
+}
+
+// computeWarmStartThreshold returns the number of connected peers after which the warm start stops waiting. A 0
+// minimum connected peers threshold means waiting for all the dials to finish
+func computeWarmStartThreshold(minConnectedPeers int, numDialed int) uint32 {
+	if minConnectedPeers <= 0 || minConnectedPeers > numDialed {
+		return uint32(numDialed)
+	}
+
+	return uint32(minConnectedPeers)
+}
+
 // WaitForConnections will wait the maxWaitingTime duration or until the target connected peers was achieved
 func (netMes *networkMessenger) WaitForConnections(maxWaitingTime time.Duration, minNumOfPeers uint32) {
 	startTime := time.Now()
@@ -921,6 +1359,18 @@ func (netMes *networkMessenger) ConnectedFullHistoryPeersOnTopic(topic string) [
 
 // CreateTopic opens a new topic using pubsub infrastructure
 func (netMes *networkMessenger) CreateTopic(name string, createChannelForTopic bool) error {
+	return netMes.CreateTopicWithOptions(name, p2p.TopicOptions{
+		CreateChannel: createChannelForTopic,
+	})
+}
+
+// CreateTopicWithOptions opens a new topic using pubsub infrastructure and the provided options
+func (netMes *networkMessenger) CreateTopicWithOptions(name string, options p2p.TopicOptions) error {
+	err := checkTopicOptions(options, netMes.maxAllowedMessageSize())
+	if err != nil {
+		return fmt.Errorf("%w for topic %s", err, name)
+	}
+
 	netMes.mutTopics.Lock()
 	defer netMes.mutTopics.Unlock()
 	_, found := netMes.topics[name]
@@ -934,14 +1384,23 @@ func (netMes *networkMessenger) CreateTopic(name string, createChannelForTopic b
 	}
 
 	netMes.topics[name] = topic
+	netMes.topicOptions[name] = applyTopicOptionsDefaults(options, netMes.maxAllowedMessageSize())
 	subscrRequest, err := topic.Subscribe()
 	if err != nil {
 		return fmt.Errorf("%w for topic %s", err, name)
 	}
 
 	netMes.subscriptions[name] = subscrRequest
-	if createChannelForTopic {
-		err = netMes.outgoingPLB.AddChannel(name)
+	topicEventHandler, err := topic.EventHandler()
+	if err != nil {
+		return fmt.Errorf("%w for topic %s", err, name)
+	}
+
+	netMes.topicEventHandlers[name] = topicEventHandler
+	go netMes.processTopicEvents(name, topicEventHandler)
+
+	if options.CreateChannel {
+		err = netMes.outgoingPLB.AddChannelWithOptions(name, options.ChannelOptions)
 	}
 
 	// just a dummy func to consume messages received by the newly created topic
@@ -962,6 +1421,34 @@ func (netMes *networkMessenger) CreateTopic(name string, createChannelForTopic b
 	return err
 }
 
+func (netMes *networkMessenger) processTopicEvents(topic string, handler *pubsub.TopicEventHandler) {
+	for {
+		peerEvent, err := handler.NextPeerEvent(netMes.ctx)
+		if err != nil {
+			log.Debug("closed topic event handler",
+				"topic", topic,
+				"err", err,
+			)
+			return
+		}
+
+		eventType := p2p.PeerJoinedTopicEvent
+		if peerEvent.Type == pubsub.PeerLeave {
+			eventType = p2p.PeerLeftTopicEvent
+		}
+		netMes.eventsNotifier.NotifyEvent(p2p.Event{
+			Type:  eventType,
+			Peer:  core.PeerID(peerEvent.Peer),
+			Topic: topic,
+		})
+	}
+}
+
+// OutgoingChannelsStats returns the queue depth and the dropped messages counter for each send channel
+func (netMes *networkMessenger) OutgoingChannelsStats() []p2p.ChannelStats {
+	return netMes.outgoingPLB.ChannelsStats()
+}
+
 // HasTopic returns true if the topic has been created
 func (netMes *networkMessenger) HasTopic(name string) bool {
 	netMes.mutTopics.RLock()
@@ -972,9 +1459,13 @@ func (netMes *networkMessenger) HasTopic(name string) bool {
 }
 
 // BroadcastOnChannelBlocking tries to send a byte buffer onto a topic using provided channel
-// It is a blocking method. It needs to be launched on a go routine
+// It is a blocking method that waits while the channel queue is full. It needs to be launched on a go routine
 func (netMes *networkMessenger) BroadcastOnChannelBlocking(channel string, topic string, buff []byte) error {
-	err := netMes.checkSendableData(buff)
+	if netMes.closing() {
+		return p2p.ErrMessengerClosed
+	}
+
+	err := netMes.checkSendableData(topic, buff)
 	if err != nil {
 		return err
 	}
@@ -995,9 +1486,13 @@ func (netMes *networkMessenger) BroadcastOnChannelBlocking(channel string, topic
 	return nil
 }
 
-func (netMes *networkMessenger) checkSendableData(buff []byte) error {
-	if len(buff) > maxSendBuffSize {
-		return fmt.Errorf("%w, to be sent: %d, maximum: %d", p2p.ErrMessageTooLarge, len(buff), maxSendBuffSize)
+func (netMes *networkMessenger) checkSendableData(topic string, buff []byte) error {
+	maxSize := netMes.getTopicOptions(topic).MaxMessageSize
+	if maxSize > maxSendBuffSize {
+		maxSize = maxSendBuffSize
+	}
+	if len(buff) > maxSize {
+		return fmt.Errorf("%w, to be sent: %d, maximum: %d", p2p.ErrMessageTooLarge, len(buff), maxSize)
 	}
 	if len(buff) == 0 {
 		return p2p.ErrEmptyBufferToSend
@@ -1021,8 +1516,51 @@ func (netMes *networkMessenger) Broadcast(topic string, buff []byte) {
 	netMes.BroadcastOnChannel(topic, topic, buff)
 }
 
+// BroadcastContext queues a byte buffer to be sent onto a topic using provided channel. It does not block
+func (netMes *networkMessenger) BroadcastContext(ctx context.Context, channel string, topic string, buff []byte) error {
+	return netMes.BroadcastContextWithResult(ctx, channel, topic, buff, nil)
+}
+
+// BroadcastContextWithResult queues a byte buffer to be sent onto a topic using provided channel. The result handler,
+// if provided, will be called with the result of the publishing. It does not block
+func (netMes *networkMessenger) BroadcastContextWithResult(
+	ctx context.Context,
+	channel string,
+	topic string,
+	buff []byte,
+	resultHandler func(err error),
+) error {
+	if ctx == nil {
+		return p2p.ErrNilContext
+	}
+	if netMes.closing() {
+		return p2p.ErrMessengerClosed
+	}
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+	if !netMes.HasTopic(topic) {
+		return fmt.Errorf("%w, topic %s", p2p.ErrUnknownTopic, topic)
+	}
+	err = netMes.checkSendableData(topic, buff)
+	if err != nil {
+		return err
+	}
+
+	sendable := &SendableData{
+		Buff:          buff,
+		Topic:         topic,
+		ID:            netMes.p2pHost.ID(),
+		Ctx:           ctx,
+		ResultHandler: resultHandler,
+	}
+
+	return netMes.outgoingPLB.Enqueue(channel, sendable)
+}
+
 // BroadcastOnChannelBlockingUsingPrivateKey tries to send a byte buffer onto a topic using provided channel
-// It is a blocking method. It needs to be launched on a go routine
+// It is a blocking method that waits while the channel queue is full. It needs to be launched on a go routine
 func (netMes *networkMessenger) BroadcastOnChannelBlockingUsingPrivateKey(
 	channel string,
 	topic string,
@@ -1030,13 +1568,17 @@ func (netMes *networkMessenger) BroadcastOnChannelBlockingUsingPrivateKey(
 	pid core.PeerID,
 	skBytes []byte,
 ) error {
+	if netMes.closing() {
+		return p2p.ErrMessengerClosed
+	}
+
 	id := peer.ID(pid)
 	sk, err := libp2pCrypto.UnmarshalSecp256k1PrivateKey(skBytes)
 	if err != nil {
 		return err
 	}
 
-	err = netMes.checkSendableData(buff)
+	err = netMes.checkSendableData(topic, buff)
 	if err != nil {
 		return err
 	}
@@ -1114,43 +1656,114 @@ func (netMes *networkMessenger) RegisterMessageProcessor(topic string, identifie
 	return nil
 }
 
-func (netMes *networkMessenger) pubsubCallback(topicProcs *topicProcessors, topic string) func(ctx context.Context, pid peer.ID, message *pubsub.Message) bool {
-	return func(ctx context.Context, pid peer.ID, message *pubsub.Message) bool {
+func (netMes *networkMessenger) pubsubCallback(topicProcs *topicProcessors, topic string) func(ctx context.Context, pid peer.ID, message *pubsub.Message) pubsub.ValidationResult {
+	return func(ctx context.Context, pid peer.ID, message *pubsub.Message) pubsub.ValidationResult {
 		fromConnectedPeer := core.PeerID(pid)
+		if netMes.isRateLimited(fromConnectedPeer, topic, len(message.Data)) {
+			return pubsub.ValidationIgnore
+		}
+
 		msg, err := netMes.transformAndCheckMessage(message, fromConnectedPeer, topic)
 		if err != nil {
 			log.Trace("p2p validator - new message", "error", err.Error(), "topic", topic)
-			return false
+			return pubsub.ValidationReject
 		}
 
 		identifiers, handlers := topicProcs.getList()
-		messageOk := true
-		for index, handler := range handlers {
-			err = handler.ProcessReceivedMessage(msg, fromConnectedPeer)
-			if err != nil {
-				log.Trace("p2p validator",
-					"error", err.Error(),
-					"topic", topic,
-					"originator", p2p.MessageOriginatorPid(msg),
-					"from connected peer", p2p.PeerIdToShortString(fromConnectedPeer),
-					"seq no", p2p.MessageOriginatorSeq(msg),
-					"topic identifier", identifiers[index],
-				)
-				messageOk = false
-			}
+		result := netMes.processMessageWithHandlers(msg, fromConnectedPeer, identifiers, handlers)
+		netMes.processDebugMessage(topic, fromConnectedPeer, uint64(len(message.Data)), result == p2p.ValidationReject)
+		netMes.updatePeerRating(fromConnectedPeer, result)
+
+		return toPubsubValidationResult(result)
+	}
+}
+
+// processMessageWithHandlers calls all the provided handlers and returns the most severe validation result
+func (netMes *networkMessenger) processMessageWithHandlers(
+	msg p2p.MessageP2P,
+	fromConnectedPeer core.PeerID,
+	identifiers []string,
+	handlers []p2p.MessageProcessor,
+) p2p.ValidationResult {
+	result := p2p.ValidationAccept
+	for index, handler := range handlers {
+		err := handler.ProcessReceivedMessage(msg, fromConnectedPeer)
+		if err == nil {
+			continue
 		}
-		netMes.processDebugMessage(topic, fromConnectedPeer, uint64(len(message.Data)), !messageOk)
 
-		if messageOk {
-			netMes.peersRatingHandler.IncreaseRating(fromConnectedPeer)
+		handlerResult := p2p.ValidationResultFromError(err)
+		log.Trace("p2p validator",
+			"error", err.Error(),
+			"result", handlerResult.String(),
+			"topic", msg.Topic(),
+			"originator", p2p.MessageOriginatorPid(msg),
+			"from connected peer", p2p.PeerIdToShortString(fromConnectedPeer),
+			"seq no", p2p.MessageOriginatorSeq(msg),
+			"topic identifier", identifiers[index],
+		)
+		if handlerResult > result {
+			result = handlerResult
 		}
+	}
+
+	return result
+}
+
+func (netMes *networkMessenger) updatePeerRating(pid core.PeerID, result p2p.ValidationResult) {
+	switch result {
+	case p2p.ValidationAccept:
+		netMes.peersRatingHandler.IncreaseRating(pid)
+	case p2p.ValidationReject:
+		netMes.peersRatingHandler.DecreaseRating(pid)
+	}
+}
+
+func toPubsubValidationResult(result p2p.ValidationResult) pubsub.ValidationResult {
+	switch result {
+	case p2p.ValidationAccept:
+		return pubsub.ValidationAccept
+	case p2p.ValidationIgnore:
+		return pubsub.ValidationIgnore
+	default:
+		return pubsub.ValidationReject
+	}
+}
+
+// isRateLimited is called before unmarshalling the message so the flooding peers will not consume resources
+func (netMes *networkMessenger) isRateLimited(fromConnectedPeer core.PeerID, topic string, size int) bool {
+	if fromConnectedPeer == netMes.ID() {
+		return false
+	}
 
-		return messageOk
+	isAllowed, shouldDeny := netMes.rateLimiter.Allow(fromConnectedPeer, topic)
+	if shouldDeny {
+		netMes.blacklistPid(fromConnectedPeer, netMes.rateLimitBanDuration)
 	}
+	if !isAllowed {
+		netMes.debugger.AddRateLimitedMessage(topic, uint64(size))
+	}
+
+	return !isAllowed
+}
+
+// inboundRateLimitChecker applies the messenger's inbound rate limits, also used for the received requests
+type inboundRateLimitChecker struct {
+	netMes *networkMessenger
+}
+
+// IsRateLimited returns true if the message or the request received from the connected peer exceeds the limits
+func (checker *inboundRateLimitChecker) IsRateLimited(fromConnectedPeer core.PeerID, topic string, size int) bool {
+	return checker.netMes.isRateLimited(fromConnectedPeer, topic, size)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (checker *inboundRateLimitChecker) IsInterfaceNil() bool {
+	return checker == nil
 }
 
 func (netMes *networkMessenger) transformAndCheckMessage(pbMsg *pubsub.Message, pid core.PeerID, topic string) (p2p.MessageP2P, error) {
-	msg, errUnmarshal := NewMessage(pbMsg, netMes.marshalizer)
+	msg, compressedSize, errUnmarshal := newMessage(pbMsg, netMes.marshalizer)
 	if errUnmarshal != nil {
 		// this error is so severe that will need to blacklist both the originator and the connected peer as there is
 		// no way this node can communicate with them
@@ -1160,8 +1773,14 @@ func (netMes *networkMessenger) transformAndCheckMessage(pbMsg *pubsub.Message,
 
 		return nil, errUnmarshal
 	}
+	if compressedSize > 0 {
+		netMes.debugger.AddCompressedMessage(topic, uint64(len(msg.Data())), uint64(compressedSize))
+	}
 
-	err := netMes.validMessageByTimestamp(msg)
+	err := netMes.validMessageBySize(msg)
+	if err == nil {
+		err = netMes.validMessageByTimestamp(msg)
+	}
 	if err != nil {
 		// not reprocessing nor re-broadcasting the same message over and over again
 		log.Trace("received an invalid message",
@@ -1201,17 +1820,27 @@ func (netMes *networkMessenger) blacklistPid(pid core.PeerID, banDuration time.D
 	}
 }
 
-// invalidMessageByTimestamp will check that the message time stamp should be in the interval
-// (now-pubsubTimeCacheDuration+acceptMessagesInAdvanceDuration, now+acceptMessagesInAdvanceDuration)
+func (netMes *networkMessenger) validMessageBySize(msg p2p.MessageP2P) error {
+	maxSize := netMes.getTopicOptions(msg.Topic()).MaxMessageSize
+	if len(msg.Data()) > maxSize {
+		return fmt.Errorf("%w, received: %d, maximum: %d", p2p.ErrMessageTooLarge, len(msg.Data()), maxSize)
+	}
+
+	return nil
+}
+
+// validMessageByTimestamp will check that the message time stamp should be in the interval
+// (now-MaxMessageAge, now+MaxClockSkew) defined by the message topic options
 func (netMes *networkMessenger) validMessageByTimestamp(msg p2p.MessageP2P) error {
+	options := netMes.getTopicOptions(msg.Topic())
 	now := netMes.syncTimer.CurrentTime()
-	isInFuture := now.Add(acceptMessagesInAdvanceDuration).Unix() < msg.Timestamp()
+	isInFuture := now.Add(options.MaxClockSkew).Unix() < msg.Timestamp()
 	if isInFuture {
 		return fmt.Errorf("%w, self timestamp %d, message timestamp %d",
 			p2p.ErrMessageTooNew, now.Unix(), msg.Timestamp())
 	}
 
-	past := now.Unix() - int64(pubsubTimeCacheDuration.Seconds())
+	past := now.Unix() - int64(options.MaxMessageAge.Seconds())
 	if msg.Timestamp() < past {
 		return fmt.Errorf("%w, self timestamp %d, message timestamp %d",
 			p2p.ErrMessageTooOld, now.Unix(), msg.Timestamp())
@@ -1255,6 +1884,11 @@ func (netMes *networkMessenger) UnjoinAllTopics() error {
 		if subscr != nil {
 			subscr.Cancel()
 		}
+		// the topic can not be closed while it has active event handlers
+		topicEventHandler := netMes.topicEventHandlers[topicName]
+		if topicEventHandler != nil {
+			topicEventHandler.Cancel()
+		}
 
 		err := t.Close()
 		if err != nil {
@@ -1266,6 +1900,8 @@ func (netMes *networkMessenger) UnjoinAllTopics() error {
 		}
 
 		delete(netMes.topics, topicName)
+		delete(netMes.topicOptions, topicName)
+		delete(netMes.topicEventHandlers, topicName)
 	}
 
 	return errFound
@@ -1296,14 +1932,19 @@ func (netMes *networkMessenger) UnregisterMessageProcessor(topic string, identif
 	return nil
 }
 
-// SendToConnectedPeer sends a direct message to a connected peer
+// SendToConnectedPeer sends a direct message to a connected peer. If the large payloads feature is enabled,
+// the buffers exceeding the maximum message size will be sent in chunks
 func (netMes *networkMessenger) SendToConnectedPeer(topic string, buff []byte, peerID core.PeerID) error {
-	err := netMes.checkSendableData(buff)
+	if len(buff) > maxSendBuffSize && netMes.chunksAssembler != nil {
+		return netMes.sendLargePayload(topic, buff, peerID)
+	}
+
+	err := netMes.checkSendableData(topic, buff)
 	if err != nil {
 		return err
 	}
 
-	buffToSend := netMes.createMessageBytes(buff)
+	buffToSend := netMes.createMessageBytes(topic, buff)
 	if len(buffToSend) == 0 {
 		return nil
 	}
@@ -1318,6 +1959,75 @@ func (netMes *networkMessenger) SendToConnectedPeer(topic string, buff []byte, p
 	return err
 }
 
+func (netMes *networkMessenger) sendLargePayload(topic string, buff []byte, peerID core.PeerID) error {
+	maxPayloadSize := netMes.chunksAssembler.maxPayloadSize
+	topicMaxSize := uint64(netMes.getTopicOptions(topic).MaxMessageSize)
+	if topicMaxSize < maxPayloadSize {
+		maxPayloadSize = topicMaxSize
+	}
+	if uint64(len(buff)) > maxPayloadSize {
+		return fmt.Errorf("%w, to be sent: %d, maximum: %d", p2p.ErrMessageTooLarge, len(buff), maxPayloadSize)
+	}
+
+	if peerID == netMes.ID() {
+		// the large payloads are not compressed as the decompressed size can not exceed the maximum message size
+		buffToSend := netMes.createMessageBytesWithCodec(topic, buff, p2p.NoCompression)
+		if len(buffToSend) == 0 {
+			return nil
+		}
+
+		return netMes.sendDirectToSelf(topic, buffToSend)
+	}
+
+	chunkSize := maxChunkPayloadSize - len(topic)
+	if chunkSize <= 0 {
+		return fmt.Errorf("%w for the topic length, got %d", p2p.ErrInvalidValue, len(topic))
+	}
+
+	transferID := atomic.AddUint64(&netMes.transferCounter, 1)
+	chunks := splitInChunks(transferID, topic, buff, chunkSize)
+	err := netMes.sendChunks(chunks, peerID)
+	netMes.debugger.AddOutgoingMessage(topic, uint64(len(buff)), err != nil)
+
+	return err
+}
+
+func (netMes *networkMessenger) sendChunks(chunks []*data.ChunkMessage, peerID core.PeerID) error {
+	for _, chunk := range chunks {
+		chunkBuff, err := netMes.marshalizer.Marshal(chunk)
+		if err != nil {
+			return err
+		}
+
+		buffToSend := netMes.createMessageBytesWithCodec(largePayloadChunkTopic, chunkBuff, p2p.NoCompression)
+		if len(buffToSend) == 0 {
+			return fmt.Errorf("%w, chunk %d of transfer %d", p2p.ErrMessageCreationFailed, chunk.ChunkIndex, chunk.TransferID)
+		}
+
+		err = netMes.ds.Send(largePayloadChunkTopic, buffToSend, peerID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SendRequest sends a request to a connected peer and waits for its response
+func (netMes *networkMessenger) SendRequest(ctx context.Context, topic string, buff []byte, peerID core.PeerID) ([]byte, error) {
+	return netMes.reqResp.SendRequest(ctx, topic, buff, peerID)
+}
+
+// RegisterRequestResponder registers the component that will answer the requests received on the provided topic
+func (netMes *networkMessenger) RegisterRequestResponder(topic string, responder p2p.RequestResponder) error {
+	return netMes.reqResp.RegisterRequestResponder(topic, responder)
+}
+
+// UnregisterRequestResponder unregisters the request responder set on the provided topic
+func (netMes *networkMessenger) UnregisterRequestResponder(topic string) error {
+	return netMes.reqResp.UnregisterRequestResponder(topic)
+}
+
 func (netMes *networkMessenger) sendDirectToSelf(topic string, buff []byte) error {
 	msg := &pubsub.Message{
 		Message: &pubsubPb.Message{
@@ -1334,11 +2044,28 @@ func (netMes *networkMessenger) sendDirectToSelf(topic string, buff []byte) erro
 
 func (netMes *networkMessenger) directMessageHandler(message *pubsub.Message, fromConnectedPeer core.PeerID) error {
 	topic := *message.Topic
+	// the unhandled topics are rejected before reaching the rate limiter so they can not grow its buckets
+	if !netMes.isDirectTopicHandled(topic) {
+		return fmt.Errorf("%w on directMessageHandler for topic %s", p2p.ErrNilValidator, topic)
+	}
+	if netMes.isRateLimited(fromConnectedPeer, topic, len(message.Data)) {
+		return fmt.Errorf("%w for topic %s, peer %s", p2p.ErrRateLimitExceeded, topic, fromConnectedPeer.Pretty())
+	}
+
 	msg, err := netMes.transformAndCheckMessage(message, fromConnectedPeer, topic)
 	if err != nil {
 		return err
 	}
 
+	if topic == largePayloadChunkTopic && netMes.chunksAssembler != nil {
+		msg, err = netMes.processChunk(msg, fromConnectedPeer)
+		if err != nil || check.IfNil(msg) {
+			return err
+		}
+
+		topic = msg.Topic()
+	}
+
 	netMes.mutTopics.RLock()
 	topicProcs := netMes.processors[topic]
 	netMes.mutTopics.RUnlock()
@@ -1355,30 +2082,57 @@ func (netMes *networkMessenger) directMessageHandler(message *pubsub.Message, fr
 
 		// we won't recheck the message id against the cacher here as there might be collisions since we are using
 		// a separate sequence counter for direct sender
-		messageOk := true
-		for index, handler := range handlers {
-			errProcess := handler.ProcessReceivedMessage(msg, fromConnectedPeer)
-			if errProcess != nil {
-				log.Trace("p2p validator",
-					"error", errProcess.Error(),
-					"topic", msg.Topic(),
-					"originator", p2p.MessageOriginatorPid(msg),
-					"from connected peer", p2p.PeerIdToShortString(fromConnectedPeer),
-					"seq no", p2p.MessageOriginatorSeq(msg),
-					"topic identifier", identifiers[index],
-				)
-				messageOk = false
-			}
-		}
+		result := netMes.processMessageWithHandlers(msg, fromConnectedPeer, identifiers, handlers)
+		netMes.debugger.AddIncomingMessage(msg.Topic(), uint64(len(msg.Data())), result == p2p.ValidationReject)
+		netMes.updatePeerRating(fromConnectedPeer, result)
+	}(msg)
+
+	return nil
+}
+
+func (netMes *networkMessenger) isDirectTopicHandled(topic string) bool {
+	if topic == largePayloadChunkTopic {
+		return netMes.chunksAssembler != nil
+	}
+
+	netMes.mutTopics.RLock()
+	defer netMes.mutTopics.RUnlock()
 
-		netMes.debugger.AddIncomingMessage(msg.Topic(), uint64(len(msg.Data())), !messageOk)
+	return netMes.processors[topic] != nil
+}
+
+// processChunk returns the reassembled message when the last chunk of a transfer was received or nil otherwise
+func (netMes *networkMessenger) processChunk(msg p2p.MessageP2P, fromConnectedPeer core.PeerID) (p2p.MessageP2P, error) {
+	chunk := &data.ChunkMessage{}
+	err := netMes.marshalizer.Unmarshal(chunk, msg.Data())
+	if err != nil {
+		netMes.updatePeerRating(fromConnectedPeer, p2p.ValidationReject)
+		return nil, fmt.Errorf("%w, error: %s", p2p.ErrInvalidChunk, err.Error())
+	}
 
-		if messageOk {
-			netMes.peersRatingHandler.IncreaseRating(fromConnectedPeer)
+	// the transfers are kept by the connected peer as the originator field is set by the sender
+	payload, err := netMes.chunksAssembler.addChunk(chunk, fromConnectedPeer)
+	if err != nil {
+		isInvalidChunk := errors.Is(err, p2p.ErrInvalidChunk)
+		if isInvalidChunk {
+			netMes.updatePeerRating(fromConnectedPeer, p2p.ValidationReject)
 		}
-	}(msg)
+		netMes.processDebugMessage(chunk.Topic, fromConnectedPeer, chunk.TotalSize, isInvalidChunk)
 
-	return nil
+		return nil, err
+	}
+	if len(payload) == 0 {
+		return nil, nil
+	}
+
+	assembledMsg := newAssembledMessage(msg, chunk.Topic, payload)
+	err = netMes.validMessageBySize(assembledMsg)
+	if err != nil {
+		netMes.processDebugMessage(chunk.Topic, fromConnectedPeer, uint64(len(payload)), true)
+		return nil, err
+	}
+
+	return assembledMsg, nil
 }
 
 // IsConnectedToTheNetwork returns true if the current node is connected to the network
@@ -1404,6 +2158,71 @@ func (netMes *networkMessenger) ThresholdMinConnectedPeers() int {
 	return netMes.connMonitor.ThresholdMinConnectedPeers()
 }
 
+// ApplyConfig validates the provided configuration and applies the sharding limits, the minimum connected peers
+// threshold and the peers discovery refresh interval without restarting the messenger. The connections that do not
+// fit in the new sharding limits are closed. The fields that can not be changed at runtime should be left untouched
+func (netMes *networkMessenger) ApplyConfig(p2pConfig config.P2PConfig) error {
+	if netMes.closing() {
+		return p2p.ErrMessengerClosed
+	}
+
+	netMes.mutP2pConfig.Lock()
+	defer netMes.mutP2pConfig.Unlock()
+
+	err := p2pConfig.Validate()
+	if err != nil {
+		return err
+	}
+	err = checkNonReloadableConfigFields(netMes.p2pConfig, p2pConfig)
+	if err != nil {
+		return err
+	}
+
+	sharder, ok := netMes.sharder.(shardingConfigUpdater)
+	if !ok {
+		return fmt.Errorf("%w for sharder in networkMessenger.ApplyConfig", p2p.ErrWrongTypeAssertion)
+	}
+	peerDiscoverer, ok := netMes.peerDiscoverer.(peersRefreshIntervalSetter)
+	if !ok {
+		return fmt.Errorf("%w for peer discoverer in networkMessenger.ApplyConfig", p2p.ErrWrongTypeAssertion)
+	}
+
+	// the sharder also checks the limits against the node operation mode, so it is updated first as to leave
+	// the messenger unchanged in case of an error
+	err = sharder.UpdateShardingConfig(p2pConfig.Sharding)
+	if err != nil {
+		return fmt.Errorf("%w in networkMessenger.ApplyConfig", err)
+	}
+
+	if p2pConfig.KadDhtPeerDiscovery.Enabled {
+		refreshInterval := time.Second * time.Duration(p2pConfig.KadDhtPeerDiscovery.RefreshIntervalInSec)
+		err = peerDiscoverer.SetPeersRefreshInterval(refreshInterval)
+		if err != nil {
+			// the current sharding config was already accepted by the sharder, so it can be restored
+			errRollback := sharder.UpdateShardingConfig(netMes.p2pConfig.Sharding)
+			if errRollback != nil {
+				log.Error("networkMessenger.ApplyConfig: can not restore the sharding config", "error", errRollback)
+			}
+
+			return fmt.Errorf("%w in networkMessenger.ApplyConfig", err)
+		}
+	}
+
+	netw := netMes.p2pHost.Network()
+	netMes.connMonitor.SetThresholdMinConnectedPeers(int(p2pConfig.Node.ThresholdMinConnectedPeers), netw)
+	netMes.p2pConfig = p2pConfig
+
+	log.Debug("networkMessenger.ApplyConfig: new configuration applied",
+		"target peer count", p2pConfig.Sharding.TargetPeerCount,
+		"threshold min connected peers", p2pConfig.Node.ThresholdMinConnectedPeers,
+		"peers refresh interval in sec", p2pConfig.KadDhtPeerDiscovery.RefreshIntervalInSec,
+	)
+
+	netMes.connMonitor.TrimConnections(netw)
+
+	return nil
+}
+
 // SetPeerShardResolver sets the peer shard resolver component that is able to resolve the link
 // between peerID and shardId
 func (netMes *networkMessenger) SetPeerShardResolver(peerShardResolver p2p.PeerShardResolver) error {
@@ -1426,7 +2245,12 @@ func (netMes *networkMessenger) SetPeerShardResolver(peerShardResolver p2p.PeerS
 // SetPeerDenialEvaluator sets the peer black list handler
 // TODO decide if we continue on using setters or switch to options. Refactor if necessary
 func (netMes *networkMessenger) SetPeerDenialEvaluator(handler p2p.PeerDenialEvaluator) error {
-	return netMes.connMonitorWrapper.SetPeerDenialEvaluator(handler)
+	err := netMes.connMonitorWrapper.SetPeerDenialEvaluator(handler)
+	if err != nil {
+		return err
+	}
+
+	return netMes.connGater.SetPeerDenialEvaluator(handler)
 }
 
 // GetConnectedPeersInfo gets the current connected peers information
@@ -1443,6 +2267,7 @@ func (netMes *networkMessenger) GetConnectedPeersInfo() *p2p.ConnectedPeersInfo
 		NumObserversOnShard:      make(map[uint32]int),
 		NumValidatorsOnShard:     make(map[uint32]int),
 		NumPreferredPeersOnShard: make(map[uint32]int),
+		RelayedPeers:             make([]string, 0),
 	}
 
 	netMes.mutPeerResolver.RLock()
@@ -1496,6 +2321,10 @@ func (netMes *networkMessenger) GetConnectedPeersInfo() *p2p.ConnectedPeersInfo
 		if netMes.preferredPeersHolder.Contains(pid) {
 			connPeerInfo.NumPreferredPeersOnShard[peerInfo.ShardID]++
 		}
+		if netMes.relayedConnsChecker.IsRelayed(pid) {
+			connPeerInfo.RelayedPeers = append(connPeerInfo.RelayedPeers, connString)
+			connPeerInfo.NumRelayedPeers++
+		}
 	}
 
 	return connPeerInfo
@@ -1521,6 +2350,50 @@ func (netMes *networkMessenger) AddPeerTopicNotifier(notifier p2p.PeerTopicNotif
 	return nil
 }
 
+// Reachability returns whether the node can be dialed by the other peers, as detected by AutoNAT
+func (netMes *networkMessenger) Reachability() p2p.Reachability {
+	return netMes.reachabilityWatcher.Reachability()
+}
+
+// SeedersStatus returns the connection health of each seeder, as tracked by the peer discoverer
+func (netMes *networkMessenger) SeedersStatus() []p2p.SeederStatus {
+	provider, ok := netMes.peerDiscoverer.(seedersStatusProvider)
+	if !ok {
+		return make([]p2p.SeederStatus, 0)
+	}
+
+	return provider.SeedersStatus()
+}
+
+// ExplainEviction returns the sharder's decision about each connected peer, without evicting any peer
+func (netMes *networkMessenger) ExplainEviction() []p2p.PeerEvictionExplanation {
+	explainer, ok := netMes.sharder.(evictionExplainer)
+	if !ok {
+		return make([]p2p.PeerEvictionExplanation, 0)
+	}
+
+	return explainer.ExplainEviction(netMes.p2pHost.Network().Peers())
+}
+
+// ResourceUsage returns the resources currently used in the system and transient scopes of the resource manager and
+// by each of the limited protocols
+func (netMes *networkMessenger) ResourceUsage() p2p.ResourceUsage {
+	return getResourceUsage(
+		netMes.p2pHost.Network().ResourceManager(),
+		netMes.limitedProtocols,
+	)
+}
+
+// SubscribeEvents returns a channel on which the network events matching the provided filter are delivered
+func (netMes *networkMessenger) SubscribeEvents(filter p2p.EventFilter) <-chan p2p.Event {
+	return netMes.eventsNotifier.Subscribe(filter)
+}
+
+// UnsubscribeEvents stops the delivery of the events on the provided channel and closes it
+func (netMes *networkMessenger) UnsubscribeEvents(events <-chan p2p.Event) error {
+	return netMes.eventsNotifier.Unsubscribe(events)
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (netMes *networkMessenger) IsInterfaceNil() bool {
 	return netMes == nil
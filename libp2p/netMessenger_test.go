@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
@@ -306,6 +307,34 @@ func TestNewNetworkMessenger_NilChecksShouldErr(t *testing.T) {
 		assert.Nil(t, messenger)
 		assert.True(t, errors.Is(err, p2p.ErrNoTransportsDefined))
 	})
+	t.Run("invalid config should report all the problems", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockNetworkArgs()
+		arg.P2pConfig.Node.Port = "-1"
+		arg.P2pConfig.Sharding.Type = "unknown"
+		messenger, err := libp2p.NewNetworkMessenger(arg)
+
+		assert.Nil(t, messenger)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidConfig))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidPortValue))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("invalid large payloads config should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockNetworkArgs()
+		arg.P2pConfig.LargePayloads = config.LargePayloadsConfig{
+			Enabled:               true,
+			MaxPayloadSizeInBytes: uint64(libp2p.MaxSendBuffSize),
+			MaxPendingSizeInBytes: uint64(libp2p.MaxSendBuffSize),
+			TransferTimeoutInSec:  10,
+		}
+		messenger, err := libp2p.NewNetworkMessenger(arg)
+
+		assert.Nil(t, messenger)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
 }
 
 func TestNewNetworkMessenger_WithDeactivatedKadDiscovererShouldWork(t *testing.T) {
@@ -357,6 +386,28 @@ func TestNewNetworkMessenger_WithKadDiscovererListSharderShouldWork(t *testing.T
 	assert.Nil(t, err)
 }
 
+func TestNewNetworkMessenger_WithLatencyListsSharderShouldWork(t *testing.T) {
+	arg := createMockNetworkArgs()
+	arg.P2pConfig.Sharding = config.ShardingConfig{
+		Type:                    p2p.LatencyListsSharder,
+		TargetPeerCount:         10,
+		MaxIntraShardValidators: 2,
+		MaxCrossShardValidators: 2,
+		MaxIntraShardObservers:  2,
+		MaxCrossShardObservers:  2,
+		Latency: config.LatencyConfig{
+			PingIntervalInSec:   30,
+			RandomPeersFraction: 0.25,
+		},
+	}
+	arg.NodeOperationMode = p2p.NormalOperation
+	messenger, err := libp2p.NewNetworkMessenger(arg)
+	require.Nil(t, err)
+	defer closeMessengers(messenger)
+
+	assert.False(t, check.IfNil(messenger))
+}
+
 func TestNewNetworkMessenger_WithListenAddrWithIp4AndTcpShouldWork(t *testing.T) {
 	arg := createMockNetworkArgs()
 	arg.P2pConfig.KadDhtPeerDiscovery = config.KadDhtPeerDiscoveryConfig{
@@ -436,6 +487,113 @@ func TestLibp2pMessenger_CreateTopicTwiceShouldNotErr(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestLibp2pMessenger_CreateTopicWithOptionsInvalidCompressionShouldErr(t *testing.T) {
+	messenger := createMockMessenger()
+	defer closeMessengers(messenger)
+
+	err := messenger.CreateTopicWithOptions("test", p2p.TopicOptions{Compression: 100})
+	assert.True(t, errors.Is(err, p2p.ErrUnsupportedCompressionCodec))
+	assert.False(t, messenger.HasTopic("test"))
+}
+
+func TestLibp2pMessenger_CreateTopicWithOptionsInvalidLimitsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	testInvalidOptions := func(options p2p.TopicOptions) {
+		messenger := createMockMessenger()
+		defer closeMessengers(messenger)
+
+		err := messenger.CreateTopicWithOptions("test", options)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.False(t, messenger.HasTopic("test"))
+	}
+
+	t.Run("negative max message size should error", func(t *testing.T) {
+		t.Parallel()
+
+		testInvalidOptions(p2p.TopicOptions{MaxMessageSize: -1})
+	})
+	t.Run("max message size larger than the maximum should error", func(t *testing.T) {
+		t.Parallel()
+
+		testInvalidOptions(p2p.TopicOptions{MaxMessageSize: libp2p.MaxSendBuffSize + 1})
+	})
+	t.Run("max message age larger than the pubsub time cache should error", func(t *testing.T) {
+		t.Parallel()
+
+		testInvalidOptions(p2p.TopicOptions{MaxMessageAge: libp2p.PubsubTimeCacheDuration + time.Second})
+	})
+	t.Run("negative max clock skew should error", func(t *testing.T) {
+		t.Parallel()
+
+		testInvalidOptions(p2p.TopicOptions{MaxClockSkew: -time.Second})
+	})
+	t.Run("invalid channel options should error", func(t *testing.T) {
+		t.Parallel()
+
+		testInvalidOptions(p2p.TopicOptions{
+			CreateChannel:  true,
+			ChannelOptions: p2p.ChannelOptions{MaxQueueDepth: -1},
+		})
+	})
+}
+
+func TestLibp2pMessenger_CreateTopicWithChannelOptions(t *testing.T) {
+	t.Parallel()
+
+	messenger := createMockMessenger()
+	defer closeMessengers(messenger)
+
+	err := messenger.CreateTopicWithOptions("test", p2p.TopicOptions{
+		CreateChannel: true,
+		ChannelOptions: p2p.ChannelOptions{
+			Weight:        4,
+			MaxQueueDepth: 100,
+			DropPolicy:    p2p.DropNewest,
+		},
+	})
+	assert.Nil(t, err)
+
+	stats := messenger.OutgoingChannelsStats()
+	require.Equal(t, 2, len(stats))
+	assert.Equal(t, p2p.ChannelStats{Name: "test", Weight: 4, MaxQueueDepth: 100}, stats[1])
+}
+
+func TestLibp2pMessenger_CreateTopicWithOptionsLargeMaxMessageSize(t *testing.T) {
+	t.Parallel()
+
+	args := createLargePayloadsArgs()
+	messenger, _ := libp2p.NewNetworkMessenger(args)
+	defer closeMessengers(messenger)
+
+	options := p2p.TopicOptions{MaxMessageSize: int(args.P2pConfig.LargePayloads.MaxPayloadSizeInBytes)}
+	err := messenger.CreateTopicWithOptions("test", options)
+	assert.Nil(t, err)
+
+	// the broadcast is still limited by the maximum message size
+	err = messenger.BroadcastOnChannelBlocking("test", "test", make([]byte, libp2p.MaxSendBuffSize+1))
+	assert.True(t, errors.Is(err, p2p.ErrMessageTooLarge))
+}
+
+func TestLibp2pMessenger_TopicMaxMessageSizeShouldBeEnforcedOnSend(t *testing.T) {
+	messenger := createMockMessenger()
+	defer closeMessengers(messenger)
+
+	err := messenger.CreateTopicWithOptions("test", p2p.TopicOptions{MaxMessageSize: 10})
+	require.Nil(t, err)
+	err = messenger.RegisterMessageProcessor("test", "identifier", &mock.MessageProcessorStub{})
+	require.Nil(t, err)
+
+	err = messenger.BroadcastOnChannelBlocking("test", "test", make([]byte, 11))
+	assert.True(t, errors.Is(err, p2p.ErrMessageTooLarge))
+
+	err = messenger.SendToConnectedPeer("test", make([]byte, 11), messenger.ID())
+	assert.True(t, errors.Is(err, p2p.ErrMessageTooLarge))
+
+	err = messenger.SendToConnectedPeer("test", make([]byte, 10), messenger.ID())
+	assert.Nil(t, err)
+}
+
 func TestLibp2pMessenger_HasTopicIfHaveTopicShouldReturnTrue(t *testing.T) {
 	messenger := createMockMessenger()
 	defer closeMessengers(messenger)
@@ -637,6 +795,92 @@ func TestLibp2pMessenger_BroadcastDataBetween2PeersShouldWork(t *testing.T) {
 	waitDoneWithTimeout(t, chanDone, timeoutWaitResponses)
 }
 
+func waitEvent(t *testing.T, events <-chan p2p.Event, expectedEvent p2p.Event) {
+	timeout := time.After(timeoutWaitResponses)
+	for {
+		select {
+		case event := <-events:
+			event.Timestamp = time.Time{}
+			if event == expectedEvent {
+				return
+			}
+		case <-timeout:
+			assert.Fail(t, "timeout while waiting for event "+expectedEvent.Type.String())
+			return
+		}
+	}
+}
+
+func TestLibp2pMessenger_SubscribeEventsShouldNotifyConnectionAndTopicEvents(t *testing.T) {
+	topic := "test"
+	_, messenger1, messenger2 := createMockNetworkOf2()
+	defer closeMessengers(messenger1, messenger2)
+
+	connectionEvents := messenger1.SubscribeEvents(p2p.EventFilter{
+		Types: []p2p.EventType{p2p.PeerConnectedEvent, p2p.PeerDisconnectedEvent},
+	})
+	topicEvents := messenger1.SubscribeEvents(p2p.EventFilter{
+		Types:  []p2p.EventType{p2p.PeerJoinedTopicEvent, p2p.PeerLeftTopicEvent},
+		Topics: []string{topic},
+	})
+
+	_ = messenger1.CreateTopic(topic, true)
+	_ = messenger2.CreateTopic(topic, true)
+	err := messenger1.ConnectToPeer(messenger2.Addresses()[0])
+	require.Nil(t, err)
+
+	waitEvent(t, connectionEvents, p2p.Event{Type: p2p.PeerConnectedEvent, Peer: messenger2.ID()})
+	waitEvent(t, topicEvents, p2p.Event{Type: p2p.PeerJoinedTopicEvent, Peer: messenger2.ID(), Topic: topic})
+
+	err = messenger2.UnjoinAllTopics()
+	assert.Nil(t, err)
+	waitEvent(t, topicEvents, p2p.Event{Type: p2p.PeerLeftTopicEvent, Peer: messenger2.ID(), Topic: topic})
+
+	_ = messenger2.Close()
+	waitEvent(t, connectionEvents, p2p.Event{Type: p2p.PeerDisconnectedEvent, Peer: messenger2.ID()})
+
+	err = messenger1.UnsubscribeEvents(topicEvents)
+	assert.Nil(t, err)
+	_, isOpen := <-topicEvents
+	assert.False(t, isOpen)
+}
+
+func TestLibp2pMessenger_BroadcastAndSendCompressedDataBetween2PeersShouldWork(t *testing.T) {
+	msg := bytes.Repeat([]byte("compressible test message "), 100)
+
+	messenger1, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+	messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+	defer closeMessengers(messenger1, messenger2)
+
+	err := messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+	require.Nil(t, err)
+
+	wg := &sync.WaitGroup{}
+	chanDone := make(chan bool)
+	wg.Add(3)
+
+	go func() {
+		wg.Wait()
+		chanDone <- true
+	}()
+
+	// only the sender opts in for compression, the receiver decompresses based on the message version
+	err = messenger1.CreateTopicWithOptions(testTopic, p2p.TopicOptions{Compression: p2p.ZstdCompression})
+	require.Nil(t, err)
+	prepareMessengerForMatchDataReceive(messenger1, msg, wg, noSigCheckHandler)
+	prepareMessengerForMatchDataReceive(messenger2, msg, wg, noSigCheckHandler)
+
+	fmt.Println("Delaying as to allow peers to announce themselves on the opened topic...")
+	time.Sleep(time.Second)
+
+	messenger1.Broadcast(testTopic, msg)
+
+	err = messenger1.SendToConnectedPeer(testTopic, msg, messenger2.ID())
+	assert.Nil(t, err)
+
+	waitDoneWithTimeout(t, chanDone, timeoutWaitResponses)
+}
+
 func TestLibp2pMessenger_BroadcastOnChannelBlockingShouldLimitNumberOfGoRoutines(t *testing.T) {
 	if testing.Short() {
 		t.Skip("this test does not perform well in TC with race detector on")
@@ -688,6 +932,104 @@ func TestLibp2pMessenger_BroadcastOnChannelBlockingShouldLimitNumberOfGoRoutines
 	assert.True(t, atomic.LoadUint32(&numErrors) > 0)
 }
 
+func TestLibp2pMessenger_BroadcastContext(t *testing.T) {
+	t.Parallel()
+
+	topic := "test"
+	buff := []byte("buff")
+
+	t.Run("nil context should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger := createMockMessenger()
+		defer closeMessengers(messenger)
+
+		_ = messenger.CreateTopic(topic, true)
+		var ctx context.Context = nil
+		err := messenger.BroadcastContext(ctx, topic, topic, buff)
+		assert.Equal(t, p2p.ErrNilContext, err)
+	})
+	t.Run("closed messenger should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger := createMockMessenger()
+		_ = messenger.CreateTopic(topic, true)
+		_ = messenger.Close()
+
+		err := messenger.BroadcastContext(context.Background(), topic, topic, buff)
+		assert.Equal(t, p2p.ErrMessengerClosed, err)
+	})
+	t.Run("done context should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger := createMockMessenger()
+		defer closeMessengers(messenger)
+
+		_ = messenger.CreateTopic(topic, true)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := messenger.BroadcastContext(ctx, topic, topic, buff)
+		assert.Equal(t, context.Canceled, err)
+	})
+	t.Run("unknown topic should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger := createMockMessenger()
+		defer closeMessengers(messenger)
+
+		err := messenger.BroadcastContext(context.Background(), topic, topic, buff)
+		assert.True(t, errors.Is(err, p2p.ErrUnknownTopic))
+	})
+	t.Run("oversized payload should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger := createMockMessenger()
+		defer closeMessengers(messenger)
+
+		_ = messenger.CreateTopic(topic, true)
+		err := messenger.BroadcastContext(context.Background(), topic, topic, make([]byte, libp2p.MaxSendBuffSize+1))
+		assert.True(t, errors.Is(err, p2p.ErrMessageTooLarge))
+	})
+	t.Run("full queue should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger)
+
+		_ = messenger.CreateTopic(topic, true)
+		messenger.SetLoadBalancer(&mock.ChannelLoadBalancerStub{
+			EnqueueCalled: func(pipe string, data *libp2p.SendableData) error {
+				return p2p.ErrSendQueueFull
+			},
+			CollectOneElementFromChannelsCalled: func() *libp2p.SendableData {
+				return nil
+			},
+		})
+		err := messenger.BroadcastContext(context.Background(), topic, topic, buff)
+		assert.True(t, errors.Is(err, p2p.ErrSendQueueFull))
+	})
+	t.Run("should publish and call the result handler", func(t *testing.T) {
+		t.Parallel()
+
+		messenger := createMockMessenger()
+		defer closeMessengers(messenger)
+
+		_ = messenger.CreateTopic(topic, true)
+		chanResult := make(chan error, 1)
+		err := messenger.BroadcastContextWithResult(context.Background(), topic, topic, buff, func(err error) {
+			chanResult <- err
+		})
+		assert.Nil(t, err)
+
+		select {
+		case errResult := <-chanResult:
+			assert.Nil(t, errResult)
+		case <-time.After(time.Second * 2):
+			assert.Fail(t, "timeout while waiting for the publishing result")
+		}
+	})
+}
+
 func TestLibp2pMessenger_BroadcastDataBetween2PeersWithLargeMsgShouldWork(t *testing.T) {
 	msg := bytes.Repeat([]byte{'A'}, libp2p.MaxSendBuffSize)
 
@@ -1357,61 +1699,477 @@ func TestLibp2pMessenger_SendDirectWithRealNetToSelfShouldWork(t *testing.T) {
 	waitDoneWithTimeout(t, chanDone, timeoutWaitResponses)
 }
 
-// ------- Bootstrap
+func TestLibp2pMessenger_SendRequestWithRealMessengersShouldWork(t *testing.T) {
+	args := createMockNetworkArgs()
+	messenger1, _ := libp2p.NewNetworkMessenger(args)
+	args.P2pPrivateKey = mock.NewPrivateKeyMock()
+	messenger2, _ := libp2p.NewNetworkMessenger(args)
+	defer closeMessengers(messenger1, messenger2)
 
-func TestNetworkMessenger_BootstrapPeerDiscoveryShouldCallPeerBootstrapper(t *testing.T) {
-	wasCalled := false
+	err := messenger1.ConnectToPeer(messenger2.Addresses()[0])
+	require.Nil(t, err)
 
-	netw := mocknet.New()
-	pdm := &mock.PeerDiscovererStub{
-		BootstrapCalled: func() error {
-			wasCalled = true
-			return nil
-		},
-		CloseCalled: func() error {
-			return nil
+	err = messenger2.RegisterRequestResponder(testTopic, &mock.RequestResponderStub{
+		ProcessRequestCalled: func(topic string, request []byte, fromConnectedPeer core.PeerID) ([]byte, error) {
+			assert.Equal(t, messenger1.ID(), fromConnectedPeer)
+			return append([]byte("response for "), request...), nil
 		},
-	}
-	messenger, _ := libp2p.NewMockMessenger(createMockNetworkArgs(), netw)
-	defer closeMessengers(messenger)
+	})
+	require.Nil(t, err)
 
-	messenger.SetPeerDiscoverer(pdm)
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutWaitResponses)
+	defer cancel()
 
-	_ = messenger.Bootstrap()
+	response, err := messenger1.SendRequest(ctx, testTopic, []byte("request"), messenger2.ID())
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("response for request"), response)
 
-	assert.True(t, wasCalled)
+	err = messenger2.UnregisterRequestResponder(testTopic)
+	assert.Nil(t, err)
+
+	response, err = messenger1.SendRequest(ctx, testTopic, []byte("request"), messenger2.ID())
+	assert.True(t, errors.Is(err, p2p.ErrRequestFailed))
+	assert.Nil(t, response)
 }
 
-// ------- SetThresholdMinConnectedPeers
+func createLargePayloadsArgs() libp2p.ArgsNetworkMessenger {
+	args := createMockNetworkArgs()
+	args.P2pConfig.LargePayloads = config.LargePayloadsConfig{
+		Enabled:               true,
+		MaxPayloadSizeInBytes: uint64(libp2p.MaxSendBuffSize * 4),
+		MaxPendingSizeInBytes: uint64(libp2p.MaxSendBuffSize * 8),
+		TransferTimeoutInSec:  10,
+	}
 
-func TestNetworkMessenger_SetThresholdMinConnectedPeersInvalidValueShouldErr(t *testing.T) {
-	messenger := createMockMessenger()
-	defer closeMessengers(messenger)
+	return args
+}
 
-	err := messenger.SetThresholdMinConnectedPeers(-1)
+func TestLibp2pMessenger_SendDirectLargePayloadWithRealNetShouldWork(t *testing.T) {
+	msg := bytes.Repeat([]byte("large payload "), libp2p.MaxSendBuffSize/4)
 
-	assert.Equal(t, p2p.ErrInvalidValue, err)
-}
+	messenger1, _ := libp2p.NewNetworkMessenger(createLargePayloadsArgs())
+	args := createLargePayloadsArgs()
+	args.P2pPrivateKey = mock.NewPrivateKeyMock()
+	messenger2, _ := libp2p.NewNetworkMessenger(args)
+	defer closeMessengers(messenger1, messenger2)
 
-func TestNetworkMessenger_SetThresholdMinConnectedPeersShouldWork(t *testing.T) {
-	messenger := createMockMessenger()
-	defer closeMessengers(messenger)
+	err := messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+	require.Nil(t, err)
 
-	minConnectedPeers := 56
-	err := messenger.SetThresholdMinConnectedPeers(minConnectedPeers)
+	wg := &sync.WaitGroup{}
+	chanDone := make(chan bool)
+	wg.Add(2)
 
-	assert.Nil(t, err)
-	assert.Equal(t, minConnectedPeers, messenger.ThresholdMinConnectedPeers())
-}
+	go func() {
+		wg.Wait()
+		chanDone <- true
+	}()
 
-// ------- IsConnectedToTheNetwork
+	prepareMessengerForMatchDataReceive(messenger1, msg, wg, noSigCheckHandler)
+	prepareMessengerForMatchDataReceive(messenger2, msg, wg, noSigCheckHandler)
 
-func TestNetworkMessenger_IsConnectedToTheNetworkRetFalse(t *testing.T) {
-	messenger := createMockMessenger()
-	defer closeMessengers(messenger)
+	err = messenger1.SendToConnectedPeer(testTopic, msg, messenger2.ID())
+	assert.Nil(t, err)
 
-	minConnectedPeers := 56
-	_ = messenger.SetThresholdMinConnectedPeers(minConnectedPeers)
+	err = messenger1.SendToConnectedPeer(testTopic, msg, messenger1.ID())
+	assert.Nil(t, err)
+
+	waitDoneWithTimeout(t, chanDone, timeoutWaitResponses)
+}
+
+func TestLibp2pMessenger_SendDirectLargePayloadShouldErr(t *testing.T) {
+	t.Parallel()
+
+	t.Run("feature disabled should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, messenger1, messenger2 := createMockNetworkOf2()
+		defer closeMessengers(messenger1, messenger2)
+
+		err := messenger1.SendToConnectedPeer(testTopic, make([]byte, libp2p.MaxSendBuffSize+1), messenger2.ID())
+		assert.True(t, errors.Is(err, p2p.ErrMessageTooLarge))
+	})
+	t.Run("payload larger than the maximum should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createLargePayloadsArgs()
+		messenger, _ := libp2p.NewNetworkMessenger(args)
+		defer closeMessengers(messenger)
+
+		buff := make([]byte, args.P2pConfig.LargePayloads.MaxPayloadSizeInBytes+1)
+		err := messenger.SendToConnectedPeer(testTopic, buff, messenger.ID())
+		assert.True(t, errors.Is(err, p2p.ErrMessageTooLarge))
+	})
+	t.Run("chunk message creation failure should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		args := createLargePayloadsArgs()
+		protoMarshaller := &mock.ProtoMarshallerMock{}
+		args.Marshalizer = &mock.MarshallerStub{
+			MarshalCalled: func(obj interface{}) ([]byte, error) {
+				_, isTopicMessage := obj.(*data.TopicMessage)
+				if isTopicMessage {
+					return nil, expectedErr
+				}
+
+				return protoMarshaller.Marshal(obj)
+			},
+			UnmarshalCalled: protoMarshaller.Unmarshal,
+		}
+		messenger1, _ := libp2p.NewNetworkMessenger(args)
+		messenger2, _ := libp2p.NewNetworkMessenger(createLargePayloadsArgs())
+		defer closeMessengers(messenger1, messenger2)
+
+		err := messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+		require.Nil(t, err)
+
+		err = messenger1.SendToConnectedPeer(testTopic, make([]byte, libp2p.MaxSendBuffSize+1), messenger2.ID())
+		assert.True(t, errors.Is(err, p2p.ErrMessageCreationFailed))
+	})
+}
+
+func TestLibp2pMessenger_LargePayloadChunksShouldBeKeptByTheConnectedPeer(t *testing.T) {
+	t.Parallel()
+
+	args := createLargePayloadsArgs()
+	messenger, _ := libp2p.NewNetworkMessenger(args)
+	defer closeMessengers(messenger)
+
+	createChunkMessage := func(originator core.PeerID, transferID uint64) *pubsub.Message {
+		buff := make([]byte, libp2p.MaxSendBuffSize*3)
+		chunk := libp2p.SplitInChunks(transferID, testTopic, buff, libp2p.MaxChunkPayloadSize)[0]
+		chunkBuff, _ := args.Marshalizer.Marshal(chunk)
+		topicMessage := &data.TopicMessage{
+			Payload:   chunkBuff,
+			Timestamp: time.Now().Unix(),
+			Version:   libp2p.CurrentTopicMessageVersion,
+		}
+		topicMessageBuff, _ := args.Marshalizer.Marshal(topicMessage)
+		topic := libp2p.LargePayloadChunkTopic
+
+		return &pubsub.Message{
+			Message: &pb.Message{
+				From:  []byte(originator),
+				Data:  topicMessageBuff,
+				Seqno: []byte{0, 0, 0, byte(transferID)},
+				Topic: &topic,
+			},
+		}
+	}
+
+	originator1 := core.PeerID(createPersistentPeerstorePid())
+	originator2 := core.PeerID(createPersistentPeerstorePid())
+	connectedPeer := core.PeerID("connected peer")
+	err := messenger.DirectMessageHandler(createChunkMessage(originator1, 1), connectedPeer)
+	assert.Nil(t, err)
+
+	// the same connected peer can not bypass its pending bytes cap by claiming another originator
+	err = messenger.DirectMessageHandler(createChunkMessage(originator2, 2), connectedPeer)
+	assert.True(t, errors.Is(err, p2p.ErrLargePayloadsPeerLimitReached))
+
+	err = messenger.DirectMessageHandler(createChunkMessage(originator2, 2), "another connected peer")
+	assert.Nil(t, err)
+}
+
+// ------- Bootstrap
+
+func TestNetworkMessenger_BootstrapPeerDiscoveryShouldCallPeerBootstrapper(t *testing.T) {
+	wasCalled := false
+
+	netw := mocknet.New()
+	pdm := &mock.PeerDiscovererStub{
+		BootstrapCalled: func() error {
+			wasCalled = true
+			return nil
+		},
+		CloseCalled: func() error {
+			return nil
+		},
+	}
+	messenger, _ := libp2p.NewMockMessenger(createMockNetworkArgs(), netw)
+	defer closeMessengers(messenger)
+
+	messenger.SetPeerDiscoverer(pdm)
+
+	_ = messenger.Bootstrap()
+
+	assert.True(t, wasCalled)
+}
+
+func TestNetworkMessenger_ConnectionGaterShouldRefuseTheDeniedPeersAndAddresses(t *testing.T) {
+	t.Parallel()
+
+	t.Run("denied peer should not connect", func(t *testing.T) {
+		t.Parallel()
+
+		messenger1, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger1, messenger2)
+
+		deniedPid := messenger2.ID()
+		err := messenger1.SetPeerDenialEvaluator(&mock.PeerDenialEvaluatorStub{
+			IsDeniedCalled: func(pid core.PeerID) bool {
+				return pid == deniedPid
+			},
+		})
+		require.Nil(t, err)
+
+		err = messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+		assert.NotNil(t, err)
+
+		// the inbound connection is closed by messenger1 after the handshake, the dialer might not get an error
+		_ = messenger2.ConnectToPeer(getConnectableAddress(messenger1))
+		assert.Eventually(t, func() bool {
+			return !messenger2.IsConnected(messenger1.ID())
+		}, time.Second, time.Millisecond*10)
+		assert.False(t, messenger1.IsConnected(messenger2.ID()))
+	})
+	t.Run("denied IP range should not connect", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockNetworkArgs()
+		args.P2pConfig.ConnectionGater.DenyCIDRs = []string{"127.0.0.0/8"}
+		messenger1, _ := libp2p.NewNetworkMessenger(args)
+		messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger1, messenger2)
+
+		err := messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+		assert.NotNil(t, err)
+		err = messenger2.ConnectToPeer(getConnectableAddress(messenger1))
+		assert.NotNil(t, err)
+		assert.False(t, messenger1.IsConnected(messenger2.ID()))
+	})
+	t.Run("allowed IP range should connect", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockNetworkArgs()
+		args.P2pConfig.ConnectionGater = config.ConnectionGaterConfig{
+			AllowCIDRs: []string{"127.0.0.1/32"},
+			DenyCIDRs:  []string{"127.0.0.0/8"},
+		}
+		messenger1, _ := libp2p.NewNetworkMessenger(args)
+		messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger1, messenger2)
+
+		err := messenger2.ConnectToPeer(getConnectableAddress(messenger1))
+		assert.Nil(t, err)
+		assert.True(t, messenger2.IsConnected(messenger1.ID()))
+	})
+}
+
+func TestNetworkMessenger_SeedersStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without seeders should return empty", func(t *testing.T) {
+		t.Parallel()
+
+		messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger)
+
+		assert.Equal(t, 0, len(messenger.SeedersStatus()))
+	})
+	t.Run("should report the configured seeders", func(t *testing.T) {
+		t.Parallel()
+
+		seederAddress := "/ip4/127.0.0.1/tcp/9999/p2p/16Uiu2HAkw5SNNtSvH1zJiQ6Gc3WoGNSxiyNueRKe6fuAuh57G3Bk"
+		arg := createMockNetworkArgs()
+		arg.P2pConfig.KadDhtPeerDiscovery = config.KadDhtPeerDiscoveryConfig{
+			Enabled:                          true,
+			Type:                             "optimized",
+			RefreshIntervalInSec:             10,
+			ProtocolID:                       "/drt/kad/1.0.0",
+			InitialPeerList:                  []string{seederAddress},
+			BucketSize:                       100,
+			RoutingTableRefreshIntervalInSec: 10,
+		}
+		messenger, _ := libp2p.NewNetworkMessenger(arg)
+		defer closeMessengers(messenger)
+
+		status := messenger.SeedersStatus()
+		require.Equal(t, 1, len(status))
+		assert.Equal(t, seederAddress, status[0].Address)
+		assert.False(t, status[0].IsConnected)
+		assert.Equal(t, uint64(0), status[0].NumFailures)
+	})
+}
+
+func TestNetworkMessenger_ExplainEviction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sharder not able to explain should return empty", func(t *testing.T) {
+		t.Parallel()
+
+		messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger)
+
+		assert.Equal(t, 0, len(messenger.ExplainEviction()))
+	})
+	t.Run("should explain the connected peers", func(t *testing.T) {
+		t.Parallel()
+
+		messenger1, _ := libp2p.NewNetworkMessenger(createMockNetworkArgsWithListsSharder())
+		messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger1, messenger2)
+
+		err := messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+		require.Nil(t, err)
+
+		explanations := messenger1.ExplainEviction()
+		require.Equal(t, 1, len(explanations))
+		assert.Equal(t, messenger2.ID(), explanations[0].Peer)
+		assert.Equal(t, p2p.UnknownPeersCategory, explanations[0].Category)
+		assert.False(t, explanations[0].IsEvicted)
+		assert.True(t, messenger1.IsConnected(messenger2.ID()))
+	})
+}
+
+func TestNetworkMessenger_ResourceUsage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	args := createMockNetworkArgs()
+	args.P2pConfig.ResourceLimits.DirectSend = config.ScopeLimitsConfig{
+		StreamsInbound: 10,
+	}
+	messenger1, _ := libp2p.NewNetworkMessenger(args)
+	messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+	defer closeMessengers(messenger1, messenger2)
+
+	err := messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+	require.Nil(t, err)
+
+	topic := "test topic"
+	_ = messenger1.CreateTopic(topic, true)
+	_ = messenger2.CreateTopic(topic, true)
+	_ = messenger1.RegisterMessageProcessor(topic, "identifier", &mock.MessageProcessorStub{})
+	err = messenger2.SendToConnectedPeer(topic, []byte("payload"), messenger1.ID())
+	require.Nil(t, err)
+
+	assert.Eventually(t, func() bool {
+		usage := messenger1.ResourceUsage()
+		return usage.Protocols[string(libp2p.DirectSendID)].NumStreamsInbound == 1
+	}, time.Second*5, time.Millisecond*50)
+
+	usage := messenger1.ResourceUsage()
+	assert.True(t, usage.System.NumConnsInbound+usage.System.NumConnsOutbound > 0)
+	assert.Equal(t, 5, len(usage.Protocols))
+	_, found := usage.Protocols["/meshsub/1.1.0"]
+	assert.True(t, found)
+}
+
+func TestNetworkMessenger_BootstrapShouldRedialThePeersSavedInThePersistentPeerstore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	args := createMockNetworkArgs()
+	args.P2pConfig.Peerstore = config.PeerstoreConfig{
+		Enabled:               true,
+		DatabasePath:          t.TempDir(),
+		RecordTTLInSec:        3600,
+		MaxPeers:              10,
+		GCIntervalInSec:       60,
+		WarmStartMaxPeers:     10,
+		WarmStartTimeoutInSec: 5,
+	}
+	messenger1, err := libp2p.NewNetworkMessenger(args)
+	require.Nil(t, err)
+	messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+	defer closeMessengers(messenger2)
+
+	err = messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+	require.Nil(t, err)
+	// wait for the identify protocol to complete
+	time.Sleep(time.Second)
+	_ = messenger1.Close()
+
+	restartedMessenger, err := libp2p.NewNetworkMessenger(args)
+	require.Nil(t, err)
+	defer closeMessengers(restartedMessenger)
+	assert.False(t, restartedMessenger.IsConnected(messenger2.ID()))
+
+	err = restartedMessenger.Bootstrap()
+	assert.Nil(t, err)
+	assert.True(t, restartedMessenger.IsConnected(messenger2.ID()))
+}
+
+func TestComputeWarmStartThreshold(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, uint32(5), libp2p.ComputeWarmStartThreshold(0, 5))
+	assert.Equal(t, uint32(5), libp2p.ComputeWarmStartThreshold(-1, 5))
+	assert.Equal(t, uint32(5), libp2p.ComputeWarmStartThreshold(10, 5))
+	assert.Equal(t, uint32(3), libp2p.ComputeWarmStartThreshold(3, 5))
+	assert.Equal(t, uint32(5), libp2p.ComputeWarmStartThreshold(5, 5))
+}
+
+func TestNewNetworkMessenger_FailedConstructionShouldReleaseThePersistentPeerstore(t *testing.T) {
+	args := createMockNetworkArgs()
+	args.P2pConfig.Peerstore = config.PeerstoreConfig{
+		Enabled:         true,
+		DatabasePath:    t.TempDir(),
+		RecordTTLInSec:  3600,
+		MaxPeers:        10,
+		GCIntervalInSec: 60,
+	}
+	args.NodeOperationMode = p2p.NormalOperation
+	// the sharder is created after the persistent peerstore and fails as the ASN prefixes file does not exist
+	args.P2pConfig.Sharding = config.ShardingConfig{
+		Type:                    p2p.DiversityListsSharder,
+		TargetPeerCount:         10,
+		MaxIntraShardValidators: 2,
+		MaxCrossShardValidators: 2,
+		MaxIntraShardObservers:  2,
+		MaxCrossShardObservers:  2,
+		NetworkDiversity: config.NetworkDiversityConfig{
+			ASNPrefixFilePath: filepath.Join(t.TempDir(), "missing.txt"),
+		},
+	}
+	messenger, err := libp2p.NewNetworkMessenger(args)
+	require.NotNil(t, err)
+	require.True(t, check.IfNil(messenger))
+
+	// the database should have been closed so a new messenger can open it
+	args.P2pConfig.Sharding = config.ShardingConfig{
+		Type: p2p.NilListSharder,
+	}
+	messenger, err = libp2p.NewNetworkMessenger(args)
+	require.Nil(t, err)
+	defer closeMessengers(messenger)
+}
+
+// ------- SetThresholdMinConnectedPeers
+
+func TestNetworkMessenger_SetThresholdMinConnectedPeersInvalidValueShouldErr(t *testing.T) {
+	messenger := createMockMessenger()
+	defer closeMessengers(messenger)
+
+	err := messenger.SetThresholdMinConnectedPeers(-1)
+
+	assert.Equal(t, p2p.ErrInvalidValue, err)
+}
+
+func TestNetworkMessenger_SetThresholdMinConnectedPeersShouldWork(t *testing.T) {
+	messenger := createMockMessenger()
+	defer closeMessengers(messenger)
+
+	minConnectedPeers := 56
+	err := messenger.SetThresholdMinConnectedPeers(minConnectedPeers)
+
+	assert.Nil(t, err)
+	assert.Equal(t, minConnectedPeers, messenger.ThresholdMinConnectedPeers())
+}
+
+// ------- IsConnectedToTheNetwork
+
+func TestNetworkMessenger_IsConnectedToTheNetworkRetFalse(t *testing.T) {
+	messenger := createMockMessenger()
+	defer closeMessengers(messenger)
+
+	minConnectedPeers := 56
+	_ = messenger.SetThresholdMinConnectedPeers(minConnectedPeers)
 
 	assert.False(t, messenger.IsConnectedToTheNetwork())
 }
@@ -1458,6 +2216,259 @@ func TestNetworkMessenger_DoubleCloseShouldWork(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestNetworkMessenger_CloseWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	topic := "test"
+	buff := []byte("buff")
+
+	t.Run("negative timeout should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger := createMockMessenger()
+		defer closeMessengers(messenger)
+
+		_, err := messenger.CloseWithTimeout(-time.Second)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidDurationProvided))
+	})
+	t.Run("queued messages should be flushed", func(t *testing.T) {
+		t.Parallel()
+
+		messenger := createMockMessenger()
+		_ = messenger.CreateTopic(topic, true)
+
+		numMessages := 50
+		numPublished := uint32(0)
+		for i := 0; i < numMessages; i++ {
+			err := messenger.BroadcastContextWithResult(context.Background(), topic, topic, buff, func(err error) {
+				if err == nil {
+					atomic.AddUint32(&numPublished, 1)
+				}
+			})
+			require.Nil(t, err)
+		}
+
+		stats, err := messenger.CloseWithTimeout(time.Second * 5)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, stats.NumDiscarded)
+		assert.LessOrEqual(t, stats.NumFlushed, numMessages)
+		assert.Equal(t, uint32(numMessages), atomic.LoadUint32(&numPublished))
+
+		err = messenger.BroadcastContext(context.Background(), topic, topic, buff)
+		assert.Equal(t, p2p.ErrMessengerClosed, err)
+		err = messenger.BroadcastOnChannelBlocking(topic, topic, buff)
+		assert.Equal(t, p2p.ErrMessengerClosed, err)
+	})
+	t.Run("messages not sent until the timeout should be discarded", func(t *testing.T) {
+		t.Parallel()
+
+		messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		_ = messenger.CreateTopic(topic, true)
+		// the send go routine does not collect from this load balancer
+		messenger.SetLoadBalancer(libp2p.NewOutgoingChannelLoadBalancer())
+
+		numMessages := 3
+		numDiscarded := uint32(0)
+		for i := 0; i < numMessages; i++ {
+			err := messenger.BroadcastContextWithResult(context.Background(), topic, topic, buff, func(err error) {
+				if err == p2p.ErrMessengerClosed {
+					atomic.AddUint32(&numDiscarded, 1)
+				}
+			})
+			require.Nil(t, err)
+		}
+
+		stats, err := messenger.CloseWithTimeout(time.Millisecond * 100)
+		assert.Nil(t, err)
+		assert.Equal(t, p2p.DrainStats{NumDiscarded: numMessages}, stats)
+		assert.Equal(t, uint32(numMessages), atomic.LoadUint32(&numDiscarded))
+	})
+}
+
+func createMockNetworkArgsWithListsSharder() libp2p.ArgsNetworkMessenger {
+	args := createMockNetworkArgs()
+	args.NodeOperationMode = p2p.NormalOperation
+	args.P2pConfig.Sharding = config.ShardingConfig{
+		Type:                    p2p.ListsSharder,
+		TargetPeerCount:         10,
+		MaxIntraShardValidators: 1,
+		MaxCrossShardValidators: 1,
+		MaxIntraShardObservers:  1,
+		MaxCrossShardObservers:  1,
+	}
+
+	return args
+}
+
+func TestNetworkMessenger_ApplyConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("closed messenger should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockNetworkArgsWithListsSharder()
+		messenger, _ := libp2p.NewMockMessenger(args, mocknet.New())
+		_ = messenger.Close()
+
+		err := messenger.ApplyConfig(args.P2pConfig)
+		assert.Equal(t, p2p.ErrMessengerClosed, err)
+	})
+	t.Run("non reloadable fields changed should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockNetworkArgsWithListsSharder()
+		messenger, _ := libp2p.NewMockMessenger(args, mocknet.New())
+		defer closeMessengers(messenger)
+
+		newConfig := args.P2pConfig
+		newConfig.Node.Port = "10000"
+		err := messenger.ApplyConfig(newConfig)
+		assert.True(t, errors.Is(err, p2p.ErrNonReloadableConfigField))
+		assert.True(t, strings.Contains(err.Error(), "Node.Port"))
+
+		newConfig = args.P2pConfig
+		newConfig.Sharding.Type = p2p.OneListSharder
+		err = messenger.ApplyConfig(newConfig)
+		assert.True(t, errors.Is(err, p2p.ErrNonReloadableConfigField))
+		assert.True(t, strings.Contains(err.Error(), "Sharding.Type"))
+
+		newConfig = args.P2pConfig
+		newConfig.KadDhtPeerDiscovery.InitialPeerList = []string{"seeder"}
+		err = messenger.ApplyConfig(newConfig)
+		assert.True(t, errors.Is(err, p2p.ErrNonReloadableConfigField))
+		assert.True(t, strings.Contains(err.Error(), "KadDhtPeerDiscovery.InitialPeerList"))
+
+		newConfig = args.P2pConfig
+		newConfig.InboundRateLimiter.Enabled = true
+		err = messenger.ApplyConfig(newConfig)
+		assert.True(t, errors.Is(err, p2p.ErrNonReloadableConfigField))
+		assert.True(t, strings.Contains(err.Error(), "InboundRateLimiter.Enabled"))
+	})
+	t.Run("invalid sharding config should error and keep the old config", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockNetworkArgsWithListsSharder()
+		args.P2pConfig.Node.ThresholdMinConnectedPeers = 2
+		messenger, _ := libp2p.NewMockMessenger(args, mocknet.New())
+		defer closeMessengers(messenger)
+
+		newConfig := args.P2pConfig
+		newConfig.Node.ThresholdMinConnectedPeers = 5
+		newConfig.Sharding.MaxIntraShardValidators = 0
+		err := messenger.ApplyConfig(newConfig)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Equal(t, 2, messenger.ThresholdMinConnectedPeers())
+	})
+	t.Run("peers refresh interval failure should restore the sharding config", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockNetworkArgsWithListsSharder()
+		args.P2pConfig.Node.ThresholdMinConnectedPeers = 2
+		args.P2pConfig.KadDhtPeerDiscovery = config.KadDhtPeerDiscoveryConfig{
+			Enabled:                          true,
+			Type:                             "optimized",
+			RefreshIntervalInSec:             10,
+			ProtocolID:                       "/drt/kad/1.0.0",
+			BucketSize:                       100,
+			RoutingTableRefreshIntervalInSec: 10,
+		}
+		messenger, err := libp2p.NewMockMessenger(args, mocknet.New())
+		require.Nil(t, err)
+		defer closeMessengers(messenger)
+
+		appliedConfigs := make([]config.ShardingConfig, 0)
+		messenger.SetSharder(&mock.SharderStub{
+			UpdateShardingConfigCalled: func(shardingConfig config.ShardingConfig) error {
+				appliedConfigs = append(appliedConfigs, shardingConfig)
+				return nil
+			},
+		})
+		expectedErr := errors.New("expected error")
+		messenger.SetPeerDiscoverer(&mock.PeerDiscovererStub{
+			BootstrapCalled: func() error {
+				return nil
+			},
+			SetPeersRefreshIntervalCalled: func(interval time.Duration) error {
+				return expectedErr
+			},
+		})
+
+		newConfig := args.P2pConfig
+		newConfig.Node.ThresholdMinConnectedPeers = 5
+		newConfig.Sharding.TargetPeerCount = 20
+		err = messenger.ApplyConfig(newConfig)
+		assert.True(t, errors.Is(err, expectedErr))
+		assert.Equal(t, 2, messenger.ThresholdMinConnectedPeers())
+		require.Equal(t, 2, len(appliedConfigs))
+		assert.Equal(t, newConfig.Sharding, appliedConfigs[0])
+		assert.Equal(t, args.P2pConfig.Sharding, appliedConfigs[1])
+	})
+	t.Run("should apply the new limits and trim the connections", func(t *testing.T) {
+		t.Parallel()
+
+		netw := mocknet.New()
+		args := createMockNetworkArgsWithListsSharder()
+		messenger, _ := libp2p.NewMockMessenger(args, netw)
+		numPeers := 7
+		peers := make([]p2p.Messenger, 0, numPeers)
+		for i := 0; i < numPeers; i++ {
+			peerMessenger, _ := libp2p.NewMockMessenger(createMockNetworkArgs(), netw)
+			peers = append(peers, peerMessenger)
+		}
+		defer func() {
+			closeMessengers(peers...)
+			closeMessengers(messenger)
+		}()
+
+		_ = netw.LinkAll()
+		for _, peerMessenger := range peers {
+			err := messenger.ConnectToPeer(getConnectableAddress(peerMessenger))
+			require.Nil(t, err)
+		}
+		require.Equal(t, numPeers, len(messenger.ConnectedPeers()))
+
+		newConfig := args.P2pConfig
+		newConfig.Node.ThresholdMinConnectedPeers = 1
+		newConfig.Sharding.TargetPeerCount = 5
+		err := messenger.ApplyConfig(newConfig)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, messenger.ThresholdMinConnectedPeers())
+
+		// all peers are unknown, as no peer shard resolver was set, so they fill all the unused slots
+		time.Sleep(time.Millisecond * 100)
+		assert.Equal(t, 5, len(messenger.ConnectedPeers()))
+
+		err = messenger.ApplyConfig(newConfig)
+		assert.Nil(t, err)
+	})
+	t.Run("concurrent calls with ResourceUsage should not race", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockNetworkArgsWithListsSharder()
+		messenger, _ := libp2p.NewMockMessenger(args, mocknet.New())
+		defer closeMessengers(messenger)
+
+		numCalls := 100
+		wg := sync.WaitGroup{}
+		wg.Add(numCalls * 2)
+		for i := 0; i < numCalls; i++ {
+			go func(index int) {
+				newConfig := args.P2pConfig
+				newConfig.Node.ThresholdMinConnectedPeers = uint32(index)
+				_ = messenger.ApplyConfig(newConfig)
+				wg.Done()
+			}(i)
+
+			go func() {
+				_ = messenger.ResourceUsage()
+				wg.Done()
+			}()
+		}
+
+		wg.Wait()
+	})
+}
+
 func TestNetworkMessenger_PreventReprocessingShouldWork(t *testing.T) {
 	args := libp2p.ArgsNetworkMessenger{
 		Marshalizer: &mock.ProtoMarshallerMock{},
@@ -1521,8 +2532,8 @@ func TestNetworkMessenger_PreventReprocessingShouldWork(t *testing.T) {
 		ValidatorData: nil,
 	}
 
-	assert.False(t, callBackFunc(ctx, pid, msg)) // this will not call
-	assert.False(t, callBackFunc(ctx, pid, msg)) // this will not call
+	assert.Equal(t, pubsub.ValidationReject, callBackFunc(ctx, pid, msg)) // this will not call
+	assert.Equal(t, pubsub.ValidationReject, callBackFunc(ctx, pid, msg)) // this will not call
 	assert.Equal(t, uint32(0), atomic.LoadUint32(&numCalled))
 }
 
@@ -1597,12 +2608,12 @@ func TestNetworkMessenger_PubsubCallbackNotMessageNotValidShouldNotCallHandler(t
 		ValidatorData: nil,
 	}
 
-	assert.False(t, callBackFunc(ctx, pid, msg))
+	assert.Equal(t, pubsub.ValidationReject, callBackFunc(ctx, pid, msg))
 	assert.Equal(t, uint32(0), atomic.LoadUint32(&numCalled))
 	assert.Equal(t, int32(2), atomic.LoadInt32(&numUpserts))
 }
 
-func TestNetworkMessenger_PubsubCallbackReturnsFalseIfHandlerErrors(t *testing.T) {
+func TestNetworkMessenger_PubsubCallbackReturnsRejectIfHandlerErrors(t *testing.T) {
 	args := libp2p.ArgsNetworkMessenger{
 		Marshalizer: &mock.ProtoMarshallerMock{},
 		P2pConfig: config.P2PConfig{
@@ -1664,10 +2675,105 @@ func TestNetworkMessenger_PubsubCallbackReturnsFalseIfHandlerErrors(t *testing.T
 		ValidatorData: nil,
 	}
 
-	assert.False(t, callBackFunc(ctx, pid, msg))
+	assert.Equal(t, pubsub.ValidationReject, callBackFunc(ctx, pid, msg))
 	assert.Equal(t, uint32(1), atomic.LoadUint32(&numCalled))
 }
 
+func TestNetworkMessenger_PubsubCallbackValidationResults(t *testing.T) {
+	t.Parallel()
+
+	createMessage := func(messenger p2p.Messenger, marshalizer p2p.Marshalizer) *pubsub.Message {
+		innerMessage := &data.TopicMessage{
+			Payload:   []byte("data"),
+			Timestamp: time.Now().Unix(),
+			Version:   libp2p.CurrentTopicMessageVersion,
+		}
+		buff, _ := marshalizer.Marshal(innerMessage)
+		topic := "topic"
+
+		return &pubsub.Message{
+			Message: &pb.Message{
+				From:  []byte(messenger.ID()),
+				Data:  buff,
+				Seqno: []byte{0, 0, 0, 1},
+				Topic: &topic,
+			},
+		}
+	}
+
+	testValidationResult := func(processorErr error, expectedResult pubsub.ValidationResult, expectedIncreases int32, expectedDecreases int32) {
+		args := createMockNetworkArgs()
+		numIncreases := int32(0)
+		numDecreases := int32(0)
+		args.PeersRatingHandler = &mock.PeersRatingHandlerStub{
+			IncreaseRatingCalled: func(pid core.PeerID) {
+				atomic.AddInt32(&numIncreases, 1)
+			},
+			DecreaseRatingCalled: func(pid core.PeerID) {
+				atomic.AddInt32(&numDecreases, 1)
+			},
+		}
+		messenger, _ := libp2p.NewNetworkMessenger(args)
+		defer closeMessengers(messenger)
+
+		handler := &mock.MessageProcessorStub{
+			ProcessMessageCalled: func(message p2p.MessageP2P, fromConnectedPeer core.PeerID) error {
+				return processorErr
+			},
+		}
+
+		callBackFunc := messenger.PubsubCallback(handler, "topic")
+		msg := createMessage(messenger, args.Marshalizer)
+		assert.Equal(t, expectedResult, callBackFunc(context.Background(), peer.ID(messenger.ID()), msg))
+		assert.Equal(t, expectedIncreases, atomic.LoadInt32(&numIncreases))
+		assert.Equal(t, expectedDecreases, atomic.LoadInt32(&numDecreases))
+	}
+
+	t.Run("no error should accept and increase rating", func(t *testing.T) {
+		t.Parallel()
+
+		testValidationResult(nil, pubsub.ValidationAccept, 1, 0)
+	})
+	t.Run("ignore error should ignore without changing the rating", func(t *testing.T) {
+		t.Parallel()
+
+		testValidationResult(fmt.Errorf("%w, already processed", p2p.ErrMessageIgnored), pubsub.ValidationIgnore, 0, 0)
+	})
+	t.Run("already seen error should ignore without changing the rating", func(t *testing.T) {
+		t.Parallel()
+
+		testValidationResult(p2p.ErrAlreadySeenMessage, pubsub.ValidationIgnore, 0, 0)
+	})
+	t.Run("other error should reject and decrease rating", func(t *testing.T) {
+		t.Parallel()
+
+		testValidationResult(errors.New("expected error"), pubsub.ValidationReject, 0, 1)
+	})
+	t.Run("reject should prevail over ignore", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockNetworkArgs()
+		messenger, _ := libp2p.NewNetworkMessenger(args)
+		defer closeMessengers(messenger)
+
+		topicProcs := libp2p.NewTopicProcessors()
+		_ = topicProcs.AddTopicProcessor("ignore", &mock.MessageProcessorStub{
+			ProcessMessageCalled: func(message p2p.MessageP2P, fromConnectedPeer core.PeerID) error {
+				return p2p.ErrMessageIgnored
+			},
+		})
+		_ = topicProcs.AddTopicProcessor("reject", &mock.MessageProcessorStub{
+			ProcessMessageCalled: func(message p2p.MessageP2P, fromConnectedPeer core.PeerID) error {
+				return errors.New("expected error")
+			},
+		})
+
+		callBackFunc := messenger.PubsubCallbackWithTopicProcessors(topicProcs, "topic")
+		msg := createMessage(messenger, args.Marshalizer)
+		assert.Equal(t, pubsub.ValidationReject, callBackFunc(context.Background(), peer.ID(messenger.ID()), msg))
+	})
+}
+
 func TestNetworkMessenger_UnjoinAllTopicsShouldWork(t *testing.T) {
 	args := libp2p.ArgsNetworkMessenger{
 		Marshalizer: &mock.ProtoMarshallerMock{},
@@ -1775,6 +2881,205 @@ func TestNetworkMessenger_ValidMessageByTimestampMessageAtUpperLimitShouldWork(t
 	assert.Nil(t, err)
 }
 
+func TestNetworkMessenger_ValidMessageByTimestampWithTopicOptions(t *testing.T) {
+	args := createMockNetworkArgs()
+	now := time.Now()
+	args.SyncTimer = &mock.SyncTimerStub{
+		CurrentTimeCalled: func() time.Time {
+			return now
+		},
+	}
+	messenger, _ := libp2p.NewNetworkMessenger(args)
+	defer closeMessengers(messenger)
+
+	topic := "consensus"
+	err := messenger.CreateTopicWithOptions(topic, p2p.TopicOptions{
+		MaxMessageAge: time.Second * 5,
+		MaxClockSkew:  time.Second,
+	})
+	require.Nil(t, err)
+
+	err = messenger.ValidMessageByTimestamp(&message.Message{TopicField: topic, TimestampField: now.Unix() - 6})
+	assert.True(t, errors.Is(err, p2p.ErrMessageTooOld))
+
+	err = messenger.ValidMessageByTimestamp(&message.Message{TopicField: topic, TimestampField: now.Unix() - 5})
+	assert.Nil(t, err)
+
+	err = messenger.ValidMessageByTimestamp(&message.Message{TopicField: topic, TimestampField: now.Unix() + 2})
+	assert.True(t, errors.Is(err, p2p.ErrMessageTooNew))
+
+	err = messenger.ValidMessageByTimestamp(&message.Message{TopicField: topic, TimestampField: now.Unix() + 1})
+	assert.Nil(t, err)
+
+	// other topics use the default limits
+	err = messenger.ValidMessageByTimestamp(&message.Message{TopicField: "other topic", TimestampField: now.Unix() - 6})
+	assert.Nil(t, err)
+}
+
+func TestNetworkMessenger_PubsubCallbackMessageLargerThanTopicMaxSizeShouldReject(t *testing.T) {
+	args := createMockNetworkArgs()
+	messenger, _ := libp2p.NewNetworkMessenger(args)
+	defer closeMessengers(messenger)
+
+	topic := "topic"
+	err := messenger.CreateTopicWithOptions(topic, p2p.TopicOptions{MaxMessageSize: 3})
+	require.Nil(t, err)
+
+	innerMessage := &data.TopicMessage{
+		Payload:   []byte("data"),
+		Timestamp: time.Now().Unix(),
+		Version:   libp2p.CurrentTopicMessageVersion,
+	}
+	buff, _ := args.Marshalizer.Marshal(innerMessage)
+	msg := &pubsub.Message{
+		Message: &pb.Message{
+			From:  []byte(messenger.ID()),
+			Data:  buff,
+			Seqno: []byte{0, 0, 0, 1},
+			Topic: &topic,
+		},
+	}
+
+	wasCalled := false
+	handler := &mock.MessageProcessorStub{
+		ProcessMessageCalled: func(message p2p.MessageP2P, fromConnectedPeer core.PeerID) error {
+			wasCalled = true
+			return nil
+		},
+	}
+
+	callBackFunc := messenger.PubsubCallback(handler, topic)
+	assert.Equal(t, pubsub.ValidationReject, callBackFunc(context.Background(), peer.ID(messenger.ID()), msg))
+	assert.False(t, wasCalled)
+}
+
+func createRateLimitedMessengerArgs() libp2p.ArgsNetworkMessenger {
+	args := createMockNetworkArgs()
+	args.P2pConfig.InboundRateLimiter = config.InboundRateLimiterConfig{
+		Enabled: true,
+		Topics: []config.TopicRateLimitConfig{
+			{
+				Name:              "topic",
+				MessagesPerSecond: 1,
+				Burst:             1,
+			},
+		},
+		MaxViolations:         2,
+		ViolationsWindowInSec: 60,
+		BanDurationInSec:      30,
+	}
+
+	return args
+}
+
+func TestNetworkMessenger_RateLimitedMessages(t *testing.T) {
+	t.Parallel()
+
+	createMessage := func(messenger p2p.Messenger, marshalizer p2p.Marshalizer) *pubsub.Message {
+		innerMessage := &data.TopicMessage{
+			Payload:   []byte("data"),
+			Timestamp: time.Now().Unix(),
+			Version:   libp2p.CurrentTopicMessageVersion,
+		}
+		buff, _ := marshalizer.Marshal(innerMessage)
+		topic := "topic"
+
+		return &pubsub.Message{
+			Message: &pb.Message{
+				From:  []byte(messenger.ID()),
+				Data:  buff,
+				Seqno: []byte{0, 0, 0, 1},
+				Topic: &topic,
+			},
+		}
+	}
+
+	t.Run("invalid config should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createRateLimitedMessengerArgs()
+		args.P2pConfig.InboundRateLimiter.BanDurationInSec = 0
+		messenger, err := libp2p.NewNetworkMessenger(args)
+		assert.Nil(t, messenger)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("pubsub messages over the limit should be ignored and the peer denied", func(t *testing.T) {
+		t.Parallel()
+
+		args := createRateLimitedMessengerArgs()
+		messenger, _ := libp2p.NewNetworkMessenger(args)
+		defer closeMessengers(messenger)
+
+		remotePid := core.PeerID("remote peer")
+		var deniedPid core.PeerID
+		var banDuration time.Duration
+		_ = messenger.SetPeerDenialEvaluator(&mock.PeerDenialEvaluatorStub{
+			UpsertPeerIDCalled: func(pid core.PeerID, duration time.Duration) error {
+				deniedPid = pid
+				banDuration = duration
+				return nil
+			},
+			IsDeniedCalled: func(pid core.PeerID) bool {
+				return false
+			},
+		})
+
+		numCalled := uint32(0)
+		handler := &mock.MessageProcessorStub{
+			ProcessMessageCalled: func(message p2p.MessageP2P, fromConnectedPeer core.PeerID) error {
+				atomic.AddUint32(&numCalled, 1)
+				return nil
+			},
+		}
+
+		callBackFunc := messenger.PubsubCallback(handler, "topic")
+		msg := createMessage(messenger, args.Marshalizer)
+		assert.Equal(t, pubsub.ValidationAccept, callBackFunc(context.Background(), peer.ID(remotePid), msg))
+		assert.Equal(t, pubsub.ValidationIgnore, callBackFunc(context.Background(), peer.ID(remotePid), msg))
+		assert.Equal(t, core.PeerID(""), deniedPid)
+		assert.Equal(t, pubsub.ValidationIgnore, callBackFunc(context.Background(), peer.ID(remotePid), msg))
+		assert.Equal(t, remotePid, deniedPid)
+		assert.Equal(t, time.Second*30, banDuration)
+		assert.Equal(t, uint32(1), atomic.LoadUint32(&numCalled))
+
+		// self messages are never limited
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, pubsub.ValidationAccept, callBackFunc(context.Background(), peer.ID(messenger.ID()), msg))
+		}
+	})
+	t.Run("direct messages over the limit should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createRateLimitedMessengerArgs()
+		messenger, _ := libp2p.NewNetworkMessenger(args)
+		defer closeMessengers(messenger)
+
+		_ = messenger.RegisterMessageProcessor("topic", "identifier", &mock.MessageProcessorStub{})
+		msg := createMessage(messenger, args.Marshalizer)
+		err := messenger.DirectMessageHandler(msg, "remote peer")
+		assert.Nil(t, err)
+		err = messenger.DirectMessageHandler(msg, "remote peer")
+		assert.True(t, errors.Is(err, p2p.ErrRateLimitExceeded))
+	})
+	t.Run("direct messages on unregistered topics should error before reaching the rate limiter", func(t *testing.T) {
+		t.Parallel()
+
+		args := createRateLimitedMessengerArgs()
+		args.P2pConfig.InboundRateLimiter.DefaultMessagesPerSecond = 1
+		args.P2pConfig.InboundRateLimiter.DefaultBurst = 1
+		messenger, _ := libp2p.NewNetworkMessenger(args)
+		defer closeMessengers(messenger)
+
+		msg := createMessage(messenger, args.Marshalizer)
+		unregisteredTopic := "unregistered topic"
+		msg.Topic = &unregisteredTopic
+		for i := 0; i < 3; i++ {
+			err := messenger.DirectMessageHandler(msg, "remote peer")
+			assert.True(t, errors.Is(err, p2p.ErrNilValidator))
+		}
+	})
+}
+
 func TestNetworkMessenger_GetConnectedPeersInfo(t *testing.T) {
 	netw := mocknet.New()
 
@@ -2175,6 +3480,67 @@ func TestNetworkMessenger_AddPeerTopicNotifier(t *testing.T) {
 	})
 }
 
+func TestNetworkMessenger_AnnouncedAddresses(t *testing.T) {
+	t.Parallel()
+
+	t.Run("announced addresses should replace the listen addresses", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockNetworkArgs()
+		args.P2pConfig.Node.AnnouncedAddresses.Addresses = []string{
+			"/ip4/1.2.3.4/tcp/%d",
+			"/dns4/seeder.example.com/tcp/37373",
+		}
+		messenger, err := libp2p.NewNetworkMessenger(args)
+		require.Nil(t, err)
+		defer closeMessengers(messenger)
+
+		addresses := messenger.Addresses()
+		require.Equal(t, 2, len(addresses))
+		assert.True(t, strings.HasPrefix(addresses[0], "/ip4/1.2.3.4/tcp/"))
+		assert.False(t, strings.HasPrefix(addresses[0], "/ip4/1.2.3.4/tcp/0/"))
+		assert.True(t, strings.HasPrefix(addresses[1], "/dns4/seeder.example.com/tcp/37373/p2p/"))
+	})
+	t.Run("private addresses should not be announced", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockNetworkArgs()
+		args.P2pConfig.Node.AnnouncedAddresses.DenyPrivateAddresses = true
+		messenger, err := libp2p.NewNetworkMessenger(args)
+		require.Nil(t, err)
+		defer closeMessengers(messenger)
+
+		assert.Equal(t, 0, len(messenger.Addresses()))
+	})
+}
+
+func TestNetworkMessenger_Reachability(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not yet detected reachability should return unknown", func(t *testing.T) {
+		t.Parallel()
+
+		messenger := createMockMessenger()
+		defer closeMessengers(messenger)
+
+		assert.Equal(t, p2p.ReachabilityUnknown, messenger.Reachability())
+	})
+	t.Run("relay service should force the public reachability", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockNetworkArgs()
+		args.P2pConfig.Relay.Enabled = true
+		args.P2pConfig.Relay.Service.Enabled = true
+		messenger, err := libp2p.NewNetworkMessenger(args)
+		require.Nil(t, err)
+		defer closeMessengers(messenger)
+
+		assert.Eventually(t, func() bool {
+			return messenger.Reachability() == p2p.ReachabilityPublic
+		}, time.Second*5, time.Millisecond*10)
+	})
+}
+
 func TestParseTransportOptions(t *testing.T) {
 	t.Parallel()
 
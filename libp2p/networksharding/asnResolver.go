@@ -0,0 +1,136 @@
+package networksharding
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+)
+
+const asnCommentPrefix = "#"
+const asnPrefix = "AS"
+
+// asnPrefixes holds, for one IP family, the ASN of each network prefix grouped by the prefix length
+type asnPrefixes struct {
+	bits           int
+	prefixLengths  []int
+	asnsByPrefixes map[int]map[string]uint32
+}
+
+func newASNPrefixes(bits int) *asnPrefixes {
+	return &asnPrefixes{
+		bits:           bits,
+		prefixLengths:  make([]int, 0),
+		asnsByPrefixes: make(map[int]map[string]uint32),
+	}
+}
+
+func (prefixes *asnPrefixes) add(ipNet *net.IPNet, asn uint32) {
+	prefixLength, _ := ipNet.Mask.Size()
+	asns, found := prefixes.asnsByPrefixes[prefixLength]
+	if !found {
+		asns = make(map[string]uint32)
+		prefixes.asnsByPrefixes[prefixLength] = asns
+		prefixes.prefixLengths = append(prefixes.prefixLengths, prefixLength)
+		// the longest prefix is checked first
+		sort.Sort(sort.Reverse(sort.IntSlice(prefixes.prefixLengths)))
+	}
+
+	asns[ipNet.IP.String()] = asn
+}
+
+func (prefixes *asnPrefixes) get(ip net.IP) (uint32, bool) {
+	for _, prefixLength := range prefixes.prefixLengths {
+		maskedIP := ip.Mask(net.CIDRMask(prefixLength, prefixes.bits))
+		asn, found := prefixes.asnsByPrefixes[prefixLength][maskedIP.String()]
+		if found {
+			return asn, true
+		}
+	}
+
+	return 0, false
+}
+
+// asnResolver tells the autonomous system of an IP using the longest matching prefix loaded from a local file
+type asnResolver struct {
+	ipv4Prefixes *asnPrefixes
+	ipv6Prefixes *asnPrefixes
+}
+
+// newASNResolver loads the prefixes file. Each line holds a CIDR followed by its ASN, optionally written with the AS
+// prefix, separated by spaces or tabs. The empty lines and the lines starting with # are ignored
+func newASNResolver(filePath string) (*asnResolver, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("%w while opening the ASN prefixes file", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	resolver := &asnResolver{
+		ipv4Prefixes: newASNPrefixes(8 * net.IPv4len),
+		ipv6Prefixes: newASNPrefixes(8 * net.IPv6len),
+	}
+
+	lineNumber := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, asnCommentPrefix) {
+			continue
+		}
+
+		err = resolver.addLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w, ASN prefixes file line %d", err, lineNumber)
+		}
+	}
+	err = scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("%w while reading the ASN prefixes file", err)
+	}
+
+	return resolver, nil
+}
+
+func (resolver *asnResolver) addLine(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) != 2 {
+		return fmt.Errorf("%w, expected a CIDR and an ASN, got `%s`", p2p.ErrInvalidValue, line)
+	}
+
+	_, ipNet, err := net.ParseCIDR(fields[0])
+	if err != nil {
+		return fmt.Errorf("%w, CIDR %s: %s", p2p.ErrInvalidValue, fields[0], err.Error())
+	}
+	asn, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(fields[1]), asnPrefix), 10, 32)
+	if err != nil {
+		return fmt.Errorf("%w, ASN %s: %s", p2p.ErrInvalidValue, fields[1], err.Error())
+	}
+
+	if ipNet.IP.To4() != nil {
+		resolver.ipv4Prefixes.add(ipNet, uint32(asn))
+		return nil
+	}
+
+	resolver.ipv6Prefixes.add(ipNet, uint32(asn))
+
+	return nil
+}
+
+// getASN returns the ASN of the longest loaded prefix containing the provided IP
+func (resolver *asnResolver) getASN(ip net.IP) (uint32, bool) {
+	ipv4 := ip.To4()
+	if ipv4 != nil {
+		return resolver.ipv4Prefixes.get(ipv4)
+	}
+
+	return resolver.ipv6Prefixes.get(ip)
+}
@@ -0,0 +1,89 @@
+package networksharding_test
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/networksharding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createASNPrefixesFile(t *testing.T, lines ...string) string {
+	filePath := filepath.Join(t.TempDir(), "asn.txt")
+	err := os.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0644)
+	require.Nil(t, err)
+
+	return filePath
+}
+
+func TestNewASNResolver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file should error", func(t *testing.T) {
+		t.Parallel()
+
+		resolver, err := networksharding.NewASNResolver(filepath.Join(t.TempDir(), "missing.txt"))
+		assert.NotNil(t, err)
+		assert.Nil(t, resolver)
+	})
+	t.Run("invalid CIDR should error", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := createASNPrefixesFile(t, "10.0.0.0/8 64496", "10.0.0.0/33 64497")
+		resolver, err := networksharding.NewASNResolver(filePath)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, strings.Contains(err.Error(), "line 2"))
+		assert.Nil(t, resolver)
+	})
+	t.Run("invalid ASN should error", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := createASNPrefixesFile(t, "10.0.0.0/8 ASX")
+		resolver, err := networksharding.NewASNResolver(filePath)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Nil(t, resolver)
+	})
+	t.Run("missing ASN should error", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := createASNPrefixesFile(t, "10.0.0.0/8")
+		resolver, err := networksharding.NewASNResolver(filePath)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Nil(t, resolver)
+	})
+}
+
+func TestASNResolver_GetASN(t *testing.T) {
+	t.Parallel()
+
+	filePath := createASNPrefixesFile(t,
+		"# test prefixes",
+		"",
+		"10.0.0.0/8 64496",
+		"10.1.0.0/16\tAS64497",
+		"2001:db8::/32 as64498",
+	)
+	resolver, err := networksharding.NewASNResolver(filePath)
+	require.Nil(t, err)
+
+	asn, found := resolver.GetASN(net.ParseIP("10.2.3.4"))
+	assert.True(t, found)
+	assert.Equal(t, uint32(64496), asn)
+
+	asn, found = resolver.GetASN(net.ParseIP("10.1.3.4"))
+	assert.True(t, found)
+	assert.Equal(t, uint32(64497), asn)
+
+	asn, found = resolver.GetASN(net.ParseIP("2001:db8:1::1"))
+	assert.True(t, found)
+	assert.Equal(t, uint32(64498), asn)
+
+	_, found = resolver.GetASN(net.ParseIP("192.0.2.1"))
+	assert.False(t, found)
+}
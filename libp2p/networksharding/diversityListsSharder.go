@@ -0,0 +1,206 @@
+package networksharding
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/networksharding/sorting"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const defaultIPv4PrefixLength = 24
+const defaultIPv6PrefixLength = 48
+
+// ArgDiversityListsSharder represents the argument structure used in the initialization of a lists sharder that
+// prefers the peers from distinct networks
+type ArgDiversityListsSharder struct {
+	ArgListsSharder
+	PeersIPResolver p2p.PeersIPResolver
+}
+
+// NewDiversityListsSharder creates a lists sharder that keeps the same quotas for each category but, within a
+// category, prefers keeping the peers from distinct IP prefixes and autonomous systems. The peers over the configured
+// caps per prefix or per autonomous system are evicted even if the category's quota is not reached
+func NewDiversityListsSharder(arg ArgDiversityListsSharder) (*listsSharder, error) {
+	if check.IfNil(arg.PeersIPResolver) {
+		return nil, p2p.ErrNilPeersIPResolver
+	}
+
+	ranker, err := newDiversityRanker(arg.P2pConfig.Sharding.NetworkDiversity, arg.PeersIPResolver)
+	if err != nil {
+		return nil, err
+	}
+
+	ls, err := NewListsSharder(arg.ArgListsSharder)
+	if err != nil {
+		return nil, err
+	}
+	ls.ranker = ranker
+
+	return ls, nil
+}
+
+// diversityRanker spreads the kept peers over as many networks as possible: the closest peer of each network prefix
+// and autonomous system is ranked first, then the second closest one and so on. The peers without a known IP are
+// ranked together as if they shared the same network
+type diversityRanker struct {
+	peersIPResolver   p2p.PeersIPResolver
+	asnResolver       *asnResolver
+	ipv4PrefixLength  int
+	ipv6PrefixLength  int
+	maxPeersPerPrefix int
+	maxPeersPerASN    int
+}
+
+type peerNetwork struct {
+	pd          *sorting.PeerDistance
+	prefix      string
+	asn         uint32
+	hasASN      bool
+	prefixIndex int
+	asnIndex    int
+	note        string
+	isOverCap   bool
+}
+
+func newDiversityRanker(diversityConfig config.NetworkDiversityConfig, peersIPResolver p2p.PeersIPResolver) (*diversityRanker, error) {
+	ranker := &diversityRanker{
+		peersIPResolver:   peersIPResolver,
+		ipv4PrefixLength:  int(diversityConfig.IPv4PrefixLength),
+		ipv6PrefixLength:  int(diversityConfig.IPv6PrefixLength),
+		maxPeersPerPrefix: int(diversityConfig.MaxPeersPerPrefix),
+		maxPeersPerASN:    int(diversityConfig.MaxPeersPerASN),
+	}
+	if ranker.ipv4PrefixLength == 0 {
+		ranker.ipv4PrefixLength = defaultIPv4PrefixLength
+	}
+	if ranker.ipv6PrefixLength == 0 {
+		ranker.ipv6PrefixLength = defaultIPv6PrefixLength
+	}
+	if ranker.ipv4PrefixLength > 8*net.IPv4len {
+		return nil, fmt.Errorf("%w, IPv4PrefixLength should not exceed %d", p2p.ErrInvalidValue, 8*net.IPv4len)
+	}
+	if ranker.ipv6PrefixLength > 8*net.IPv6len {
+		return nil, fmt.Errorf("%w, IPv6PrefixLength should not exceed %d", p2p.ErrInvalidValue, 8*net.IPv6len)
+	}
+
+	if len(diversityConfig.ASNPrefixFilePath) == 0 {
+		if ranker.maxPeersPerASN > 0 {
+			return nil, fmt.Errorf("%w, MaxPeersPerASN requires the ASN prefixes file", p2p.ErrInvalidValue)
+		}
+
+		return ranker, nil
+	}
+
+	var err error
+	ranker.asnResolver, err = newASNResolver(diversityConfig.ASNPrefixFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return ranker, nil
+}
+
+func (ranker *diversityRanker) rankPeers(distances sorting.PeerDistances, _ int) rankedPeers {
+	sort.Sort(distances)
+
+	numPeersPerPrefix := make(map[string]int)
+	numPeersPerASN := make(map[uint32]int)
+	networks := make([]*peerNetwork, 0, len(distances))
+	for _, pd := range distances {
+		pn := ranker.resolvePeerNetwork(pd)
+		pn.prefixIndex = numPeersPerPrefix[pn.prefix]
+		numPeersPerPrefix[pn.prefix]++
+		if pn.hasASN {
+			pn.asnIndex = numPeersPerASN[pn.asn]
+			numPeersPerASN[pn.asn]++
+		}
+
+		ranker.explain(pn)
+		networks = append(networks, pn)
+	}
+
+	// the peers are already sorted by distance so the stable sort keeps the closest peers first on equal ranks
+	sort.SliceStable(networks, func(i, j int) bool {
+		if networks[i].isOverCap != networks[j].isOverCap {
+			return !networks[i].isOverCap
+		}
+
+		return diversityIndex(networks[i]) < diversityIndex(networks[j])
+	})
+
+	ranked := rankedPeers{
+		peers: make(sorting.PeerDistances, 0, len(networks)),
+		notes: make(map[peer.ID]string),
+	}
+	for _, pn := range networks {
+		ranked.peers = append(ranked.peers, pn.pd)
+		if !pn.isOverCap {
+			ranked.numAllowed++
+		}
+		if len(pn.note) > 0 {
+			ranked.notes[pn.pd.ID] = pn.note
+		}
+	}
+
+	return ranked
+}
+
+func (ranker *diversityRanker) resolvePeerNetwork(pd *sorting.PeerDistance) *peerNetwork {
+	pn := &peerNetwork{
+		pd: pd,
+	}
+
+	ip, found := ranker.peersIPResolver.GetPeerIP(core.PeerID(pd.ID))
+	if !found {
+		return pn
+	}
+
+	pn.prefix = prefixKey(ip, ranker.ipv4PrefixLength, ranker.ipv6PrefixLength)
+	if ranker.asnResolver != nil {
+		pn.asn, pn.hasASN = ranker.asnResolver.getASN(ip)
+	}
+
+	return pn
+}
+
+func (ranker *diversityRanker) explain(pn *peerNetwork) {
+	hasPrefix := len(pn.prefix) > 0
+	switch {
+	case hasPrefix && ranker.maxPeersPerPrefix > 0 && pn.prefixIndex >= ranker.maxPeersPerPrefix:
+		pn.isOverCap = true
+		pn.note = fmt.Sprintf("the network prefix %s is over the cap of %d peers", pn.prefix, ranker.maxPeersPerPrefix)
+	case pn.hasASN && ranker.maxPeersPerASN > 0 && pn.asnIndex >= ranker.maxPeersPerASN:
+		pn.isOverCap = true
+		pn.note = fmt.Sprintf("the AS%d is over the cap of %d peers", pn.asn, ranker.maxPeersPerASN)
+	case !hasPrefix && pn.prefixIndex > 0:
+		pn.note = fmt.Sprintf("%d closer peers without a known IP rank first", pn.prefixIndex)
+	case pn.prefixIndex >= pn.asnIndex && pn.prefixIndex > 0:
+		pn.note = fmt.Sprintf("%d closer peers share the network prefix %s", pn.prefixIndex, pn.prefix)
+	case pn.asnIndex > 0:
+		pn.note = fmt.Sprintf("%d closer peers share the AS%d", pn.asnIndex, pn.asn)
+	}
+}
+
+func diversityIndex(pn *peerNetwork) int {
+	if pn.prefixIndex > pn.asnIndex {
+		return pn.prefixIndex
+	}
+
+	return pn.asnIndex
+}
+
+// prefixKey returns the network prefix of the provided IP, using the IPv4 or the IPv6 prefix length
+func prefixKey(ip net.IP, ipv4PrefixLength int, ipv6PrefixLength int) string {
+	ipv4 := ip.To4()
+	if ipv4 != nil {
+		return fmt.Sprintf("%s/%d", ipv4.Mask(net.CIDRMask(ipv4PrefixLength, 8*net.IPv4len)), ipv4PrefixLength)
+	}
+
+	return fmt.Sprintf("%s/%d", ip.Mask(net.CIDRMask(ipv6PrefixLength, 8*net.IPv6len)), ipv6PrefixLength)
+}
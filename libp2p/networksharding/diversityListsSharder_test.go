@@ -0,0 +1,218 @@
+package networksharding_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/networksharding"
+	"github.com/TerraDharitri/drt-go-chain-p2p/mock"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockDiversityListsSharderArguments(peersIPs map[peer.ID]string) networksharding.ArgDiversityListsSharder {
+	arg := networksharding.ArgDiversityListsSharder{
+		ArgListsSharder: createMockListSharderArguments(),
+		PeersIPResolver: &mock.PeersIPResolverStub{
+			GetPeerIPCalled: func(pid core.PeerID) (net.IP, bool) {
+				ip, found := peersIPs[peer.ID(pid)]
+				return net.ParseIP(ip), found
+			},
+		},
+	}
+	arg.P2pConfig.Sharding.TargetPeerCount = 10
+	arg.P2pConfig.Sharding.MaxIntraShardValidators = 2
+
+	return arg
+}
+
+// createIntraShardValidatorsByDistance returns intra shard validators sorted by their distance to the current peer
+func createIntraShardValidatorsByDistance(t *testing.T, numPeers int) []peer.ID {
+	pids := make([]peer.ID, 0, numPeers)
+	for i := 0; i < numPeers; i++ {
+		pids = append(pids, peer.ID(fmt.Sprintf("%d - %d - %s", crtShardId, i, validatorMarker)))
+	}
+	sort.Slice(pids, func(i, j int) bool {
+		return networksharding.ComputeDistanceByCountingBits(pids[i], crtPid).Cmp(
+			networksharding.ComputeDistanceByCountingBits(pids[j], crtPid)) < 0
+	})
+	for i := 1; i < numPeers; i++ {
+		distance := networksharding.ComputeDistanceByCountingBits(pids[i], crtPid)
+		previousDistance := networksharding.ComputeDistanceByCountingBits(pids[i-1], crtPid)
+		require.NotEqual(t, 0, distance.Cmp(previousDistance), "the test requires distinct distances")
+	}
+
+	return pids
+}
+
+func TestNewDiversityListsSharder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil peers IP resolver should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockDiversityListsSharderArguments(nil)
+		arg.PeersIPResolver = nil
+		ls, err := networksharding.NewDiversityListsSharder(arg)
+
+		assert.True(t, check.IfNil(ls))
+		assert.Equal(t, p2p.ErrNilPeersIPResolver, err)
+	})
+	t.Run("invalid prefix length should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockDiversityListsSharderArguments(nil)
+		arg.P2pConfig.Sharding.NetworkDiversity.IPv4PrefixLength = 33
+		ls, err := networksharding.NewDiversityListsSharder(arg)
+
+		assert.True(t, check.IfNil(ls))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("ASN cap without the prefixes file should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockDiversityListsSharderArguments(nil)
+		arg.P2pConfig.Sharding.NetworkDiversity.MaxPeersPerASN = 1
+		ls, err := networksharding.NewDiversityListsSharder(arg)
+
+		assert.True(t, check.IfNil(ls))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("invalid lists sharder config should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockDiversityListsSharderArguments(nil)
+		arg.P2pConfig.Sharding.TargetPeerCount = 0
+		ls, err := networksharding.NewDiversityListsSharder(arg)
+
+		assert.True(t, check.IfNil(ls))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockDiversityListsSharderArguments(nil)
+		arg.P2pConfig.Sharding.NetworkDiversity.ASNPrefixFilePath = createASNPrefixesFile(t, "10.0.0.0/8 64496")
+		arg.P2pConfig.Sharding.NetworkDiversity.MaxPeersPerASN = 1
+		ls, err := networksharding.NewDiversityListsSharder(arg)
+
+		assert.False(t, check.IfNil(ls))
+		assert.Nil(t, err)
+	})
+}
+
+func TestDiversityListsSharder_ComputeEvictionListShouldPreferDistinctPrefixes(t *testing.T) {
+	t.Parallel()
+
+	pids := createIntraShardValidatorsByDistance(t, 3)
+	peersIPs := map[peer.ID]string{
+		pids[0]: "10.0.0.1",
+		pids[1]: "10.0.0.2",
+		pids[2]: "10.0.1.1",
+	}
+
+	ls, _ := networksharding.NewListsSharder(createMockDiversityListsSharderArguments(peersIPs).ArgListsSharder)
+	evictList := ls.ComputeEvictionList(pids)
+	assert.Equal(t, []peer.ID{pids[2]}, evictList)
+
+	ds, err := networksharding.NewDiversityListsSharder(createMockDiversityListsSharderArguments(peersIPs))
+	require.Nil(t, err)
+	evictList = ds.ComputeEvictionList(pids)
+	assert.Equal(t, []peer.ID{pids[1]}, evictList)
+
+	explanations := ds.ExplainEviction(pids)
+	require.Equal(t, 3, len(explanations))
+	assert.False(t, explanations[0].IsEvicted)
+	assert.True(t, explanations[1].IsEvicted)
+	assert.Equal(t, 2, explanations[1].Rank)
+	assert.True(t, strings.Contains(explanations[1].Reason, "share the network prefix 10.0.0.0/24"))
+	assert.False(t, explanations[2].IsEvicted)
+	assert.Equal(t, 1, explanations[2].Rank)
+}
+
+func TestDiversityListsSharder_ComputeEvictionListShouldEvictThePeersOverTheCaps(t *testing.T) {
+	t.Parallel()
+
+	t.Run("prefix cap", func(t *testing.T) {
+		t.Parallel()
+
+		pids := createIntraShardValidatorsByDistance(t, 2)
+		peersIPs := map[peer.ID]string{
+			pids[0]: "10.0.0.1",
+			pids[1]: "10.0.0.2",
+		}
+		arg := createMockDiversityListsSharderArguments(peersIPs)
+		arg.P2pConfig.Sharding.NetworkDiversity.MaxPeersPerPrefix = 1
+		ds, err := networksharding.NewDiversityListsSharder(arg)
+		require.Nil(t, err)
+
+		// the quota of 2 intra shard validators is not reached
+		evictList := ds.ComputeEvictionList(pids)
+		assert.Equal(t, []peer.ID{pids[1]}, evictList)
+
+		explanations := ds.ExplainEviction(pids)
+		assert.True(t, explanations[1].IsEvicted)
+		assert.Equal(t, "evicted, the network prefix 10.0.0.0/24 is over the cap of 1 peers", explanations[1].Reason)
+	})
+	t.Run("ASN cap", func(t *testing.T) {
+		t.Parallel()
+
+		pids := createIntraShardValidatorsByDistance(t, 2)
+		peersIPs := map[peer.ID]string{
+			pids[0]: "10.0.0.1",
+			pids[1]: "10.1.0.1",
+		}
+		arg := createMockDiversityListsSharderArguments(peersIPs)
+		arg.P2pConfig.Sharding.NetworkDiversity.ASNPrefixFilePath = createASNPrefixesFile(t, "10.0.0.0/8 64496")
+		arg.P2pConfig.Sharding.NetworkDiversity.MaxPeersPerASN = 1
+		ds, err := networksharding.NewDiversityListsSharder(arg)
+		require.Nil(t, err)
+
+		evictList := ds.ComputeEvictionList(pids)
+		assert.Equal(t, []peer.ID{pids[1]}, evictList)
+
+		explanations := ds.ExplainEviction(pids)
+		assert.Equal(t, "evicted, the AS64496 is over the cap of 1 peers", explanations[1].Reason)
+	})
+	t.Run("peers without a known IP should not be capped", func(t *testing.T) {
+		t.Parallel()
+
+		pids := createIntraShardValidatorsByDistance(t, 2)
+		arg := createMockDiversityListsSharderArguments(nil)
+		arg.P2pConfig.Sharding.NetworkDiversity.MaxPeersPerPrefix = 1
+		ds, err := networksharding.NewDiversityListsSharder(arg)
+		require.Nil(t, err)
+
+		evictList := ds.ComputeEvictionList(pids)
+		assert.Equal(t, 0, len(evictList))
+	})
+}
+
+func TestDiversityListsSharder_ComputeEvictionListShouldPreferDistinctASNs(t *testing.T) {
+	t.Parallel()
+
+	pids := createIntraShardValidatorsByDistance(t, 3)
+	peersIPs := map[peer.ID]string{
+		pids[0]: "10.0.0.1",
+		pids[1]: "10.1.0.1",
+		pids[2]: "192.0.2.1",
+	}
+	arg := createMockDiversityListsSharderArguments(peersIPs)
+	arg.P2pConfig.Sharding.NetworkDiversity.ASNPrefixFilePath = createASNPrefixesFile(t, "10.0.0.0/8 64496")
+	ds, err := networksharding.NewDiversityListsSharder(arg)
+	require.Nil(t, err)
+
+	evictList := ds.ComputeEvictionList(pids)
+	assert.Equal(t, []peer.ID{pids[1]}, evictList)
+
+	explanations := ds.ExplainEviction(pids)
+	assert.True(t, strings.Contains(explanations[1].Reason, "share the AS64496"))
+}
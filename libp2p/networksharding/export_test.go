@@ -2,6 +2,7 @@ package networksharding
 
 import (
 	"math/big"
+	"net"
 
 	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -11,37 +12,38 @@ const MinAllowedConnectedPeersListSharder = minAllowedConnectedPeersListSharder
 const MinAllowedValidators = minAllowedValidators
 const MinAllowedObservers = minAllowedObservers
 const MinAllowedConnectedPeersOneSharder = minAllowedConnectedPeersOneSharder
+const RelayedPeerDistanceOffset = relayedPeerDistanceOffset
 
 func (ls *listsSharder) GetMaxPeerCount() int {
-	return ls.maxPeerCount
+	return ls.getPeersConnections().maxPeerCount
 }
 
 func (ls *listsSharder) GetMaxIntraShardValidators() int {
-	return ls.maxIntraShardValidators
+	return ls.getPeersConnections().intraShardValidators
 }
 
 func (ls *listsSharder) GetMaxCrossShardValidators() int {
-	return ls.maxCrossShardValidators
+	return ls.getPeersConnections().crossShardValidators
 }
 
 func (ls *listsSharder) GetMaxIntraShardObservers() int {
-	return ls.maxIntraShardObservers
+	return ls.getPeersConnections().intraShardObservers
 }
 
 func (ls *listsSharder) GetMaxCrossShardObservers() int {
-	return ls.maxCrossShardObservers
+	return ls.getPeersConnections().crossShardObservers
 }
 
 func (ls *listsSharder) GetMaxSeeders() int {
-	return ls.maxSeeders
+	return ls.getPeersConnections().seeders
 }
 
 func (ls *listsSharder) GetMaxFullHistoryObservers() int {
-	return ls.maxFullHistoryObservers
+	return ls.getPeersConnections().fullHistoryObservers
 }
 
 func (ls *listsSharder) GetMaxUnknown() int {
-	return ls.maxUnknown
+	return ls.getPeersConnections().unknown
 }
 
 func ComputeDistanceByCountingBits(src peer.ID, dest peer.ID) *big.Int {
@@ -55,3 +57,11 @@ func ComputeDistanceLog2Based(src peer.ID, dest peer.ID) *big.Int {
 func (ls *listsSharder) GetPeerShardResolver() p2p.PeerShardResolver {
 	return ls.peerShardResolver
 }
+
+func NewASNResolver(filePath string) (*asnResolver, error) {
+	return newASNResolver(filePath)
+}
+
+func (resolver *asnResolver) GetASN(ip net.IP) (uint32, bool) {
+	return resolver.getASN(ip)
+}
@@ -14,11 +14,14 @@ var log = logger.GetOrCreate("p2p/networksharding/factory")
 
 // ArgsSharderFactory represents the argument for the sharder factory
 type ArgsSharderFactory struct {
-	PeerShardResolver    p2p.PeerShardResolver
-	Pid                  peer.ID
-	P2pConfig            config.P2PConfig
-	PreferredPeersHolder p2p.PreferredPeersHolderHandler
-	NodeOperationMode    p2p.NodeOperation
+	PeerShardResolver         p2p.PeerShardResolver
+	Pid                       peer.ID
+	P2pConfig                 config.P2PConfig
+	PreferredPeersHolder      p2p.PreferredPeersHolderHandler
+	NodeOperationMode         p2p.NodeOperation
+	RelayedConnectionsChecker p2p.RelayedConnectionsChecker
+	PeersIPResolver           p2p.PeersIPResolver
+	PeersLatencyProvider      p2p.PeersLatencyProvider
 }
 
 // NewSharder creates new Sharder instances
@@ -31,16 +34,19 @@ func NewSharder(arg ArgsSharderFactory) (p2p.Sharder, error) {
 		return oneListSharder(arg)
 	case p2p.NilListSharder:
 		return nilListSharder()
+	case p2p.DiversityListsSharder:
+		return diversityListsSharder(arg)
+	case p2p.LatencyListsSharder:
+		return latencyListsSharder(arg)
 	default:
 		return nil, fmt.Errorf("%w when selecting sharder: unknown %s value", p2p.ErrInvalidValue, shardingType)
 	}
 }
 
 func listSharder(arg ArgsSharderFactory) (p2p.Sharder, error) {
-	switch arg.NodeOperationMode {
-	case p2p.NormalOperation, p2p.FullArchiveMode:
-	default:
-		return nil, fmt.Errorf("%w unknown node operation mode %s", p2p.ErrInvalidValue, arg.NodeOperationMode)
+	err := checkNodeOperationMode(arg.NodeOperationMode)
+	if err != nil {
+		return nil, err
 	}
 
 	log.Debug("using lists sharder",
@@ -53,14 +59,69 @@ func listSharder(arg ArgsSharderFactory) (p2p.Sharder, error) {
 		"MaxSeeders", arg.P2pConfig.Sharding.MaxSeeders,
 		"node operation", arg.NodeOperationMode,
 	)
-	argListsSharder := networksharding.ArgListsSharder{
-		PeerResolver:         arg.PeerShardResolver,
-		SelfPeerId:           arg.Pid,
-		P2pConfig:            arg.P2pConfig,
-		PreferredPeersHolder: arg.PreferredPeersHolder,
-		NodeOperationMode:    arg.NodeOperationMode,
+	return networksharding.NewListsSharder(createArgListsSharder(arg))
+}
+
+func diversityListsSharder(arg ArgsSharderFactory) (p2p.Sharder, error) {
+	err := checkNodeOperationMode(arg.NodeOperationMode)
+	if err != nil {
+		return nil, err
+	}
+
+	diversityConfig := arg.P2pConfig.Sharding.NetworkDiversity
+	log.Debug("using diversity lists sharder",
+		"MaxConnectionCount", arg.P2pConfig.Sharding.TargetPeerCount,
+		"IPv4PrefixLength", diversityConfig.IPv4PrefixLength,
+		"IPv6PrefixLength", diversityConfig.IPv6PrefixLength,
+		"MaxPeersPerPrefix", diversityConfig.MaxPeersPerPrefix,
+		"MaxPeersPerASN", diversityConfig.MaxPeersPerASN,
+		"ASNPrefixFilePath", diversityConfig.ASNPrefixFilePath,
+		"node operation", arg.NodeOperationMode,
+	)
+	argDiversityListsSharder := networksharding.ArgDiversityListsSharder{
+		ArgListsSharder: createArgListsSharder(arg),
+		PeersIPResolver: arg.PeersIPResolver,
+	}
+	return networksharding.NewDiversityListsSharder(argDiversityListsSharder)
+}
+
+func latencyListsSharder(arg ArgsSharderFactory) (p2p.Sharder, error) {
+	err := checkNodeOperationMode(arg.NodeOperationMode)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("using latency lists sharder",
+		"MaxConnectionCount", arg.P2pConfig.Sharding.TargetPeerCount,
+		"PingIntervalInSec", arg.P2pConfig.Sharding.Latency.PingIntervalInSec,
+		"RandomPeersFraction", arg.P2pConfig.Sharding.Latency.RandomPeersFraction,
+		"node operation", arg.NodeOperationMode,
+	)
+	argLatencyListsSharder := networksharding.ArgLatencyListsSharder{
+		ArgListsSharder:      createArgListsSharder(arg),
+		PeersLatencyProvider: arg.PeersLatencyProvider,
+	}
+	return networksharding.NewLatencyListsSharder(argLatencyListsSharder)
+}
+
+func checkNodeOperationMode(nodeOperationMode p2p.NodeOperation) error {
+	switch nodeOperationMode {
+	case p2p.NormalOperation, p2p.FullArchiveMode:
+		return nil
+	default:
+		return fmt.Errorf("%w unknown node operation mode %s", p2p.ErrInvalidValue, nodeOperationMode)
+	}
+}
+
+func createArgListsSharder(arg ArgsSharderFactory) networksharding.ArgListsSharder {
+	return networksharding.ArgListsSharder{
+		PeerResolver:              arg.PeerShardResolver,
+		SelfPeerId:                arg.Pid,
+		P2pConfig:                 arg.P2pConfig,
+		PreferredPeersHolder:      arg.PreferredPeersHolder,
+		NodeOperationMode:         arg.NodeOperationMode,
+		RelayedConnectionsChecker: arg.RelayedConnectionsChecker,
 	}
-	return networksharding.NewListsSharder(argListsSharder)
 }
 
 func oneListSharder(arg ArgsSharderFactory) (p2p.Sharder, error) {
@@ -70,6 +131,7 @@ func oneListSharder(arg ArgsSharderFactory) (p2p.Sharder, error) {
 	return networksharding.NewOneListSharder(
 		arg.Pid,
 		int(arg.P2pConfig.Sharding.TargetPeerCount),
+		arg.RelayedConnectionsChecker,
 	)
 }
 
@@ -18,9 +18,10 @@ import (
 func createMockArg() factory.ArgsSharderFactory {
 	return factory.ArgsSharderFactory{
 
-		PeerShardResolver:    &mock.PeerShardResolverStub{},
-		Pid:                  "",
-		PreferredPeersHolder: &mock.PeersHolderStub{},
+		PeerShardResolver:         &mock.PeerShardResolverStub{},
+		Pid:                       "",
+		PreferredPeersHolder:      &mock.PeersHolderStub{},
+		RelayedConnectionsChecker: &mock.RelayedConnectionsCheckerStub{},
 		P2pConfig: config.P2PConfig{
 			Sharding: config.ShardingConfig{
 				Type:                    "unknown",
@@ -80,6 +81,84 @@ func TestNewSharder_CreateListsSharderShouldWork(t *testing.T) {
 	assert.IsType(t, reflect.TypeOf(expectedSharder), reflect.TypeOf(sharder))
 }
 
+func TestNewSharder_CreateDiversityListsSharder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown node operation should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockArg()
+		arg.P2pConfig.Sharding.Type = p2p.DiversityListsSharder
+		arg.PeersIPResolver = &mock.PeersIPResolverStub{}
+		arg.NodeOperationMode = ""
+		sharder, err := factory.NewSharder(arg)
+
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, strings.Contains(err.Error(), "unknown node operation mode"))
+		assert.True(t, check.IfNil(sharder))
+	})
+	t.Run("nil peers IP resolver should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockArg()
+		arg.P2pConfig.Sharding.Type = p2p.DiversityListsSharder
+		sharder, err := factory.NewSharder(arg)
+
+		assert.Equal(t, p2p.ErrNilPeersIPResolver, err)
+		assert.True(t, check.IfNil(sharder))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockArg()
+		arg.P2pConfig.Sharding.Type = p2p.DiversityListsSharder
+		arg.PeersIPResolver = &mock.PeersIPResolverStub{}
+		sharder, err := factory.NewSharder(arg)
+
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(sharder))
+	})
+}
+
+func TestNewSharder_CreateLatencyListsSharder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown node operation should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockArg()
+		arg.P2pConfig.Sharding.Type = p2p.LatencyListsSharder
+		arg.PeersLatencyProvider = &mock.PeersLatencyProviderStub{}
+		arg.NodeOperationMode = ""
+		sharder, err := factory.NewSharder(arg)
+
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, strings.Contains(err.Error(), "unknown node operation mode"))
+		assert.True(t, check.IfNil(sharder))
+	})
+	t.Run("nil peers latency provider should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockArg()
+		arg.P2pConfig.Sharding.Type = p2p.LatencyListsSharder
+		sharder, err := factory.NewSharder(arg)
+
+		assert.Equal(t, p2p.ErrNilPeersLatencyProvider, err)
+		assert.True(t, check.IfNil(sharder))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockArg()
+		arg.P2pConfig.Sharding.Type = p2p.LatencyListsSharder
+		arg.PeersLatencyProvider = &mock.PeersLatencyProviderStub{}
+		sharder, err := factory.NewSharder(arg)
+
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(sharder))
+	})
+}
+
 func TestNewSharder_CreateOneListSharderShouldWork(t *testing.T) {
 	t.Parallel()
 
@@ -88,7 +167,7 @@ func TestNewSharder_CreateOneListSharderShouldWork(t *testing.T) {
 	sharder, err := factory.NewSharder(arg)
 	maxPeerCount := 2
 
-	expectedSharder, _ := networksharding.NewOneListSharder("", maxPeerCount)
+	expectedSharder, _ := networksharding.NewOneListSharder("", maxPeerCount, &mock.RelayedConnectionsCheckerStub{})
 	assert.Nil(t, err)
 	assert.IsType(t, reflect.TypeOf(expectedSharder), reflect.TypeOf(sharder))
 }
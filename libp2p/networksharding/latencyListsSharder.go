@@ -0,0 +1,181 @@
+package networksharding
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/networksharding/sorting"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// ArgLatencyListsSharder represents the argument structure used in the initialization of a lists sharder that
+// prefers the peers with the lowest latency
+type ArgLatencyListsSharder struct {
+	ArgListsSharder
+	PeersLatencyProvider p2p.PeersLatencyProvider
+}
+
+// NewLatencyListsSharder creates a lists sharder that keeps the same quotas for each category but, within a category,
+// prefers keeping the peers with the lowest latency. A fraction of each category's quota is kept for randomly picked
+// peers. As for the lists sharder, the preferred peers are never evicted
+func NewLatencyListsSharder(arg ArgLatencyListsSharder) (*listsSharder, error) {
+	if check.IfNil(arg.PeersLatencyProvider) {
+		return nil, p2p.ErrNilPeersLatencyProvider
+	}
+	randomPeersFraction := arg.P2pConfig.Sharding.Latency.RandomPeersFraction
+	if math.IsNaN(randomPeersFraction) || randomPeersFraction < 0 || randomPeersFraction > 1 {
+		return nil, fmt.Errorf("%w, RandomPeersFraction should be between 0 and 1", p2p.ErrInvalidValue)
+	}
+
+	ls, err := NewListsSharder(arg.ArgListsSharder)
+	if err != nil {
+		return nil, err
+	}
+	ls.ranker = &latencyRanker{
+		peersLatencyProvider: arg.PeersLatencyProvider,
+		randomPeersFraction:  randomPeersFraction,
+		randomSeed:           rand.Uint64(),
+	}
+
+	return ls, nil
+}
+
+// latencyRanker ranks first the fastest peers, then the randomly picked peers, then the rest of the peers by their
+// latency. The random picks depend on a seed chosen when the node starts so they do not change between two eviction
+// computations but differ from one node to another. The peers without a measured latency, like the ones that just
+// connected, are ranked as if they had the median latency of the category
+type latencyRanker struct {
+	peersLatencyProvider p2p.PeersLatencyProvider
+	randomPeersFraction  float64
+	randomSeed           uint64
+}
+
+type peerLatency struct {
+	pd         *sorting.PeerDistance
+	latency    time.Duration
+	isMeasured bool
+	isRandom   bool
+}
+
+func (ranker *latencyRanker) rankPeers(distances sorting.PeerDistances, quota int) rankedPeers {
+	sort.Sort(distances)
+
+	latencies := ranker.getLatencies(distances)
+	// the peers are already sorted by distance so the stable sort keeps the closest peers first on equal latencies
+	sort.SliceStable(latencies, func(i, j int) bool {
+		return latencies[i].latency < latencies[j].latency
+	})
+
+	if quota < len(latencies) {
+		latencies = ranker.pickRandomPeers(latencies, quota)
+	}
+
+	ranked := rankedPeers{
+		peers:      make(sorting.PeerDistances, 0, len(latencies)),
+		numAllowed: len(latencies),
+		notes:      make(map[peer.ID]string, len(latencies)),
+	}
+	for _, pl := range latencies {
+		ranked.peers = append(ranked.peers, pl.pd)
+		ranked.notes[pl.pd.ID] = explainLatency(pl)
+	}
+
+	return ranked
+}
+
+func (ranker *latencyRanker) getLatencies(distances sorting.PeerDistances) []*peerLatency {
+	latencies := make([]*peerLatency, 0, len(distances))
+	measured := make([]time.Duration, 0, len(distances))
+	for _, pd := range distances {
+		latency, found := ranker.peersLatencyProvider.GetPeerLatency(core.PeerID(pd.ID))
+		latencies = append(latencies, &peerLatency{
+			pd:         pd,
+			latency:    latency,
+			isMeasured: found,
+		})
+		if found {
+			measured = append(measured, latency)
+		}
+	}
+	if len(measured) == 0 {
+		return latencies
+	}
+
+	sort.Slice(measured, func(i, j int) bool {
+		return measured[i] < measured[j]
+	})
+	medianLatency := measured[len(measured)/2]
+	for _, pl := range latencies {
+		if !pl.isMeasured {
+			pl.latency = medianLatency
+		}
+	}
+
+	return latencies
+}
+
+// pickRandomPeers moves the randomly picked peers right after the fastest ones so they fill the end of the quota
+func (ranker *latencyRanker) pickRandomPeers(latencies []*peerLatency, quota int) []*peerLatency {
+	if quota < 0 {
+		quota = 0
+	}
+	numRandom := int(float64(quota) * ranker.randomPeersFraction)
+	if numRandom == 0 {
+		return latencies
+	}
+
+	numFastest := quota - numRandom
+	candidates := make([]*peerLatency, len(latencies)-numFastest)
+	copy(candidates, latencies[numFastest:])
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return ranker.randomScore(candidates[i].pd.ID) < ranker.randomScore(candidates[j].pd.ID)
+	})
+	for _, pl := range candidates[:numRandom] {
+		pl.isRandom = true
+	}
+
+	result := make([]*peerLatency, 0, len(latencies))
+	result = append(result, latencies[:numFastest]...)
+	result = append(result, candidates[:numRandom]...)
+	for _, pl := range latencies[numFastest:] {
+		if !pl.isRandom {
+			result = append(result, pl)
+		}
+	}
+
+	return result
+}
+
+func (ranker *latencyRanker) randomScore(pid peer.ID) uint64 {
+	seed := make([]byte, 8)
+	binary.BigEndian.PutUint64(seed, ranker.randomSeed)
+
+	hasher := fnv.New64a()
+	_, _ = hasher.Write(seed)
+	_, _ = hasher.Write([]byte(pid))
+
+	return hasher.Sum64()
+}
+
+func explainLatency(pl *peerLatency) string {
+	note := fmt.Sprintf("latency %s", pl.latency)
+	switch {
+	case !pl.isMeasured && pl.latency == 0:
+		note = "no latency measured yet"
+	case !pl.isMeasured:
+		note = fmt.Sprintf("no latency measured yet, ranked with the median latency of %s", pl.latency)
+	}
+	if pl.isRandom {
+		note = "picked as a random peer, " + note
+	}
+
+	return note
+}
@@ -0,0 +1,182 @@
+package networksharding_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/networksharding"
+	"github.com/TerraDharitri/drt-go-chain-p2p/mock"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockLatencyListsSharderArguments(latencies map[peer.ID]time.Duration) networksharding.ArgLatencyListsSharder {
+	arg := networksharding.ArgLatencyListsSharder{
+		ArgListsSharder: createMockListSharderArguments(),
+		PeersLatencyProvider: &mock.PeersLatencyProviderStub{
+			GetPeerLatencyCalled: func(pid core.PeerID) (time.Duration, bool) {
+				latency, found := latencies[peer.ID(pid)]
+				return latency, found
+			},
+		},
+	}
+	arg.P2pConfig.Sharding.TargetPeerCount = 10
+	arg.P2pConfig.Sharding.MaxIntraShardValidators = 2
+
+	return arg
+}
+
+func TestNewLatencyListsSharder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil peers latency provider should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockLatencyListsSharderArguments(nil)
+		arg.PeersLatencyProvider = nil
+		ls, err := networksharding.NewLatencyListsSharder(arg)
+
+		assert.True(t, check.IfNil(ls))
+		assert.Equal(t, p2p.ErrNilPeersLatencyProvider, err)
+	})
+	t.Run("invalid random peers fraction should error", func(t *testing.T) {
+		t.Parallel()
+
+		for _, fraction := range []float64{-0.1, 1.1, math.NaN()} {
+			arg := createMockLatencyListsSharderArguments(nil)
+			arg.P2pConfig.Sharding.Latency.RandomPeersFraction = fraction
+			ls, err := networksharding.NewLatencyListsSharder(arg)
+
+			assert.True(t, check.IfNil(ls))
+			assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		}
+	})
+	t.Run("invalid lists sharder config should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockLatencyListsSharderArguments(nil)
+		arg.P2pConfig.Sharding.TargetPeerCount = 0
+		ls, err := networksharding.NewLatencyListsSharder(arg)
+
+		assert.True(t, check.IfNil(ls))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockLatencyListsSharderArguments(nil)
+		arg.P2pConfig.Sharding.Latency.RandomPeersFraction = 0.5
+		ls, err := networksharding.NewLatencyListsSharder(arg)
+
+		assert.False(t, check.IfNil(ls))
+		assert.Nil(t, err)
+	})
+}
+
+func TestLatencyListsSharder_ComputeEvictionListShouldKeepTheFastestPeers(t *testing.T) {
+	t.Parallel()
+
+	pids := createIntraShardValidatorsByDistance(t, 3)
+	latencies := map[peer.ID]time.Duration{
+		pids[0]: time.Millisecond * 100,
+		pids[1]: time.Millisecond * 10,
+		pids[2]: time.Millisecond * 20,
+	}
+	ls, err := networksharding.NewLatencyListsSharder(createMockLatencyListsSharderArguments(latencies))
+	require.Nil(t, err)
+
+	evictList := ls.ComputeEvictionList(pids)
+	assert.Equal(t, []peer.ID{pids[0]}, evictList)
+
+	explanations := ls.ExplainEviction(pids)
+	require.Equal(t, 3, len(explanations))
+	assert.True(t, explanations[0].IsEvicted)
+	assert.Equal(t, "evicted, ranked 3 over the quota of 2 intra shard validators, latency 100ms", explanations[0].Reason)
+	assert.Equal(t, 0, explanations[1].Rank)
+	assert.Equal(t, "kept, ranked 1 within the quota of 2 intra shard validators, latency 10ms", explanations[1].Reason)
+}
+
+func TestLatencyListsSharder_ComputeEvictionListShouldRankTheUnmeasuredPeersWithTheMedianLatency(t *testing.T) {
+	t.Parallel()
+
+	pids := createIntraShardValidatorsByDistance(t, 4)
+	latencies := map[peer.ID]time.Duration{
+		pids[0]: time.Millisecond * 90,
+		pids[1]: time.Millisecond * 10,
+		pids[3]: time.Millisecond * 20,
+	}
+	arg := createMockLatencyListsSharderArguments(latencies)
+	arg.P2pConfig.Sharding.MaxIntraShardValidators = 3
+	ls, err := networksharding.NewLatencyListsSharder(arg)
+	require.Nil(t, err)
+
+	evictList := ls.ComputeEvictionList(pids)
+	assert.Equal(t, []peer.ID{pids[0]}, evictList)
+
+	explanations := ls.ExplainEviction(pids)
+	assert.False(t, explanations[2].IsEvicted)
+	assert.True(t, strings.Contains(explanations[2].Reason, "ranked with the median latency of 20ms"))
+}
+
+func TestLatencyListsSharder_ComputeEvictionListShouldKeepRandomPeers(t *testing.T) {
+	t.Parallel()
+
+	pids := createIntraShardValidatorsByDistance(t, 6)
+	latencies := make(map[peer.ID]time.Duration)
+	for i, pid := range pids {
+		latencies[pid] = time.Millisecond * time.Duration(10*(i+1))
+	}
+	arg := createMockLatencyListsSharderArguments(latencies)
+	arg.P2pConfig.Sharding.MaxIntraShardValidators = 4
+	arg.P2pConfig.Sharding.Latency.RandomPeersFraction = 0.5
+	ls, err := networksharding.NewLatencyListsSharder(arg)
+	require.Nil(t, err)
+
+	evictList := ls.ComputeEvictionList(pids)
+	require.Equal(t, 2, len(evictList))
+	// the 2 fastest peers are always kept, the 2 random peers are picked from the other 4 peers
+	assert.False(t, ls.Has(pids[0], evictList))
+	assert.False(t, ls.Has(pids[1], evictList))
+
+	// the random picks should not change between two computations
+	assert.Equal(t, evictList, ls.ComputeEvictionList(pids))
+
+	numRandomPeers := 0
+	for _, explanation := range ls.ExplainEviction(pids) {
+		if strings.Contains(explanation.Reason, "picked as a random peer") {
+			numRandomPeers++
+			assert.False(t, explanation.IsEvicted)
+			assert.False(t, ls.Has(peer.ID(explanation.Peer), evictList))
+		}
+	}
+	assert.Equal(t, 2, numRandomPeers)
+}
+
+func TestLatencyListsSharder_ComputeEvictionListShouldNotEvictThePreferredPeers(t *testing.T) {
+	t.Parallel()
+
+	pids := createIntraShardValidatorsByDistance(t, 3)
+	latencies := map[peer.ID]time.Duration{
+		pids[0]: time.Millisecond * 100,
+		pids[1]: time.Millisecond * 10,
+		pids[2]: time.Millisecond * 20,
+	}
+	arg := createMockLatencyListsSharderArguments(latencies)
+	arg.PreferredPeersHolder = &mock.PeersHolderStub{
+		ContainsCalled: func(peerID core.PeerID) bool {
+			return peer.ID(peerID) == pids[0]
+		},
+	}
+	ls, err := networksharding.NewLatencyListsSharder(arg)
+	require.Nil(t, err)
+
+	evictList := ls.ComputeEvictionList(pids)
+	assert.Equal(t, 0, len(evictList))
+}
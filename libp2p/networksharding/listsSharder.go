@@ -33,6 +33,10 @@ const seeders = 40
 const unknown = 50
 const fullHistoryObservers = 60
 
+// relayedPeerDistanceOffset is added to the distance of the peers connected only through circuit relays so they
+// are evicted before any directly connected peer of the same kind
+const relayedPeerDistanceOffset = 1 << 16
+
 var log = logger.GetOrCreate("p2p/libp2p/networksharding")
 
 var leadingZerosCount = []int{
@@ -75,11 +79,12 @@ var _ = leadingZerosCount[255]
 
 // ArgListsSharder represents the argument structure used in the initialization of a listsSharder implementation
 type ArgListsSharder struct {
-	PeerResolver         p2p.PeerShardResolver
-	SelfPeerId           peer.ID
-	P2pConfig            config.P2PConfig
-	PreferredPeersHolder p2p.PreferredPeersHolderHandler
-	NodeOperationMode    p2p.NodeOperation
+	PeerResolver              p2p.PeerShardResolver
+	SelfPeerId                peer.ID
+	P2pConfig                 config.P2PConfig
+	PreferredPeersHolder      p2p.PreferredPeersHolderHandler
+	NodeOperationMode         p2p.NodeOperation
+	RelayedConnectionsChecker p2p.RelayedConnectionsChecker
 }
 
 // listsSharder is the struct able to compute an eviction list of connected peers id according to the
@@ -87,21 +92,43 @@ type ArgListsSharder struct {
 // and unknown peers by the following rule: both intra shard and cross shard lists are upper bounded to provided
 // maximum levels, unknown list is able to fill the gap until maximum peer count value is fulfilled.
 type listsSharder struct {
-	mutResolver             sync.RWMutex
-	peerShardResolver       p2p.PeerShardResolver
-	selfPeerId              peer.ID
-	maxPeerCount            int
-	maxIntraShardValidators int
-	maxCrossShardValidators int
-	maxIntraShardObservers  int
-	maxCrossShardObservers  int
-	maxSeeders              int
-	maxFullHistoryObservers int
-	maxUnknown              int
-	mutSeeders              sync.RWMutex
-	seeders                 []string
-	computeDistance         func(src peer.ID, dest peer.ID) *big.Int
-	preferredPeersHolder    p2p.PreferredPeersHolderHandler
+	mutResolver          sync.RWMutex
+	peerShardResolver    p2p.PeerShardResolver
+	selfPeerId           peer.ID
+	nodeOperationMode    p2p.NodeOperation
+	mutPeersConn         sync.RWMutex
+	peersConn            peersConnections
+	mutSeeders           sync.RWMutex
+	seeders              []string
+	computeDistance      func(src peer.ID, dest peer.ID) *big.Int
+	preferredPeersHolder p2p.PreferredPeersHolderHandler
+	relayedConnsChecker  p2p.RelayedConnectionsChecker
+	ranker               peersRanker
+}
+
+// peersRanker orders the peers of a category, the first quota peers being kept
+type peersRanker interface {
+	rankPeers(distances sorting.PeerDistances, quota int) rankedPeers
+}
+
+// rankedPeers holds the ordered peers of a category. Only the first numAllowed peers can be kept, whatever the
+// category's quota. The optional notes explain why some of the peers were moved from their distance position
+type rankedPeers struct {
+	peers      sorting.PeerDistances
+	numAllowed int
+	notes      map[peer.ID]string
+}
+
+// distanceRanker keeps the closest peers
+type distanceRanker struct{}
+
+func (ranker *distanceRanker) rankPeers(distances sorting.PeerDistances, _ int) rankedPeers {
+	sort.Sort(distances)
+
+	return rankedPeers{
+		peers:      distances,
+		numAllowed: len(distances),
+	}
 }
 
 type peersConnections struct {
@@ -120,59 +147,68 @@ func NewListsSharder(arg ArgListsSharder) (*listsSharder, error) {
 	if check.IfNil(arg.PeerResolver) {
 		return nil, p2p.ErrNilPeerShardResolver
 	}
-	if arg.P2pConfig.Sharding.TargetPeerCount < minAllowedConnectedPeersListSharder {
-		return nil, fmt.Errorf("%w, maxPeerCount should be at least %d", p2p.ErrInvalidValue, minAllowedConnectedPeersListSharder)
-	}
-	if arg.P2pConfig.Sharding.MaxIntraShardValidators < minAllowedValidators {
-		return nil, fmt.Errorf("%w, maxIntraShardValidators should be at least %d", p2p.ErrInvalidValue, minAllowedValidators)
-	}
-	if arg.P2pConfig.Sharding.MaxCrossShardValidators < minAllowedValidators {
-		return nil, fmt.Errorf("%w, maxCrossShardValidators should be at least %d", p2p.ErrInvalidValue, minAllowedValidators)
-	}
-	if arg.P2pConfig.Sharding.MaxIntraShardObservers < minAllowedObservers {
-		return nil, fmt.Errorf("%w, maxIntraShardObservers should be at least %d", p2p.ErrInvalidValue, minAllowedObservers)
-	}
-	if arg.P2pConfig.Sharding.MaxCrossShardObservers < minAllowedObservers {
-		return nil, fmt.Errorf("%w, maxCrossShardObservers should be at least %d", p2p.ErrInvalidValue, minAllowedObservers)
-	}
 	if check.IfNil(arg.PreferredPeersHolder) {
 		return nil, fmt.Errorf("%w while creating a new listsShared", p2p.ErrNilPreferredPeersHolder)
 	}
-	peersConn, err := processNumConnections(arg)
+	if check.IfNil(arg.RelayedConnectionsChecker) {
+		return nil, fmt.Errorf("%w while creating a new listsShared", p2p.ErrNilRelayedConnectionsChecker)
+	}
+	peersConn, err := processNumConnections(arg.P2pConfig.Sharding, arg.NodeOperationMode)
 	if err != nil {
 		return nil, err
 	}
 
 	ls := &listsSharder{
-		peerShardResolver:       arg.PeerResolver,
-		selfPeerId:              arg.SelfPeerId,
-		maxPeerCount:            peersConn.maxPeerCount,
-		computeDistance:         computeDistanceByCountingBits,
-		maxIntraShardValidators: peersConn.intraShardValidators,
-		maxCrossShardValidators: peersConn.crossShardValidators,
-		maxIntraShardObservers:  peersConn.intraShardObservers,
-		maxCrossShardObservers:  peersConn.crossShardObservers,
-		maxSeeders:              peersConn.seeders,
-		maxFullHistoryObservers: peersConn.fullHistoryObservers,
-		maxUnknown:              peersConn.unknown,
-		preferredPeersHolder:    arg.PreferredPeersHolder,
+		peerShardResolver:    arg.PeerResolver,
+		selfPeerId:           arg.SelfPeerId,
+		nodeOperationMode:    arg.NodeOperationMode,
+		peersConn:            peersConn,
+		computeDistance:      computeDistanceByCountingBits,
+		preferredPeersHolder: arg.PreferredPeersHolder,
+		relayedConnsChecker:  arg.RelayedConnectionsChecker,
+		ranker:               &distanceRanker{},
 	}
 
 	return ls, nil
 }
 
-func processNumConnections(arg ArgListsSharder) (peersConnections, error) {
+func checkShardingConfig(shardingConfig config.ShardingConfig) error {
+	if shardingConfig.TargetPeerCount < minAllowedConnectedPeersListSharder {
+		return fmt.Errorf("%w, maxPeerCount should be at least %d", p2p.ErrInvalidValue, minAllowedConnectedPeersListSharder)
+	}
+	if shardingConfig.MaxIntraShardValidators < minAllowedValidators {
+		return fmt.Errorf("%w, maxIntraShardValidators should be at least %d", p2p.ErrInvalidValue, minAllowedValidators)
+	}
+	if shardingConfig.MaxCrossShardValidators < minAllowedValidators {
+		return fmt.Errorf("%w, maxCrossShardValidators should be at least %d", p2p.ErrInvalidValue, minAllowedValidators)
+	}
+	if shardingConfig.MaxIntraShardObservers < minAllowedObservers {
+		return fmt.Errorf("%w, maxIntraShardObservers should be at least %d", p2p.ErrInvalidValue, minAllowedObservers)
+	}
+	if shardingConfig.MaxCrossShardObservers < minAllowedObservers {
+		return fmt.Errorf("%w, maxCrossShardObservers should be at least %d", p2p.ErrInvalidValue, minAllowedObservers)
+	}
+
+	return nil
+}
+
+func processNumConnections(shardingConfig config.ShardingConfig, nodeOperationMode p2p.NodeOperation) (peersConnections, error) {
+	err := checkShardingConfig(shardingConfig)
+	if err != nil {
+		return peersConnections{}, err
+	}
+
 	peersConn := peersConnections{
-		maxPeerCount:         int(arg.P2pConfig.Sharding.TargetPeerCount),
-		intraShardValidators: int(arg.P2pConfig.Sharding.MaxIntraShardValidators),
-		crossShardValidators: int(arg.P2pConfig.Sharding.MaxCrossShardValidators),
-		intraShardObservers:  int(arg.P2pConfig.Sharding.MaxIntraShardObservers),
-		crossShardObservers:  int(arg.P2pConfig.Sharding.MaxCrossShardObservers),
-		seeders:              int(arg.P2pConfig.Sharding.MaxSeeders),
+		maxPeerCount:         int(shardingConfig.TargetPeerCount),
+		intraShardValidators: int(shardingConfig.MaxIntraShardValidators),
+		crossShardValidators: int(shardingConfig.MaxCrossShardValidators),
+		intraShardObservers:  int(shardingConfig.MaxIntraShardObservers),
+		crossShardObservers:  int(shardingConfig.MaxCrossShardObservers),
+		seeders:              int(shardingConfig.MaxSeeders),
 		fullHistoryObservers: 0,
 	}
-	if arg.NodeOperationMode == p2p.FullArchiveMode {
-		peersConn.fullHistoryObservers = int(arg.P2pConfig.Sharding.AdditionalConnections.MaxFullHistoryObservers)
+	if nodeOperationMode == p2p.FullArchiveMode {
+		peersConn.fullHistoryObservers = int(shardingConfig.AdditionalConnections.MaxFullHistoryObservers)
 		peersConn.maxPeerCount += peersConn.fullHistoryObservers
 	}
 
@@ -192,48 +228,203 @@ func processNumConnections(arg ArgListsSharder) (peersConnections, error) {
 	return peersConn, nil
 }
 
+// UpdateShardingConfig validates the provided sharding config and replaces the current connections limits.
+// The new limits are used starting with the next eviction list computation
+func (ls *listsSharder) UpdateShardingConfig(shardingConfig config.ShardingConfig) error {
+	peersConn, err := processNumConnections(shardingConfig, ls.nodeOperationMode)
+	if err != nil {
+		return err
+	}
+
+	ls.mutPeersConn.Lock()
+	ls.peersConn = peersConn
+	ls.mutPeersConn.Unlock()
+
+	return nil
+}
+
+func (ls *listsSharder) getPeersConnections() peersConnections {
+	ls.mutPeersConn.RLock()
+	defer ls.mutPeersConn.RUnlock()
+
+	return ls.peersConn
+}
+
+// evictionOrder holds the categories in the order their evicted peers are appended to the eviction list
+var evictionOrder = []int{
+	intraShardValidators,
+	crossShardValidators,
+	intraShardObservers,
+	crossShardObservers,
+	seeders,
+	fullHistoryObservers,
+	unknown,
+}
+
+var categories = map[int]p2p.PeersCategory{
+	intraShardValidators: p2p.IntraShardValidatorsCategory,
+	intraShardObservers:  p2p.IntraShardObserversCategory,
+	crossShardValidators: p2p.CrossShardValidatorsCategory,
+	crossShardObservers:  p2p.CrossShardObserversCategory,
+	seeders:              p2p.SeedersCategory,
+	fullHistoryObservers: p2p.FullHistoryObserversCategory,
+	unknown:              p2p.UnknownPeersCategory,
+}
+
 // ComputeEvictionList returns the eviction list
 func (ls *listsSharder) ComputeEvictionList(pidList []peer.ID) []peer.ID {
-	peerDistances := ls.splitPeerIds(pidList)
-
-	existingNumIntraShardValidators := len(peerDistances[intraShardValidators])
-	existingNumIntraShardObservers := len(peerDistances[intraShardObservers])
-	existingNumCrossShardValidators := len(peerDistances[crossShardValidators])
-	existingNumCrossShardObservers := len(peerDistances[crossShardObservers])
-	existingNumSeeders := len(peerDistances[seeders])
-	existingNumFullHistoryObservers := len(peerDistances[fullHistoryObservers])
-	existingNumUnknown := len(peerDistances[unknown])
-
-	var numIntraShardValidators, numCrossShardValidators int
-	var numIntraShardObservers, numCrossShardObservers int
-	var numFullHistoryObservers int
-	var numSeeders, numUnknown, remaining int
-
-	numIntraShardValidators, remaining = computeUsedAndSpare(existingNumIntraShardValidators, ls.maxIntraShardValidators)
-	numCrossShardValidators, remaining = computeUsedAndSpare(existingNumCrossShardValidators, ls.maxCrossShardValidators+remaining)
-	numIntraShardObservers, remaining = computeUsedAndSpare(existingNumIntraShardObservers, ls.maxIntraShardObservers+remaining)
-	numCrossShardObservers, remaining = computeUsedAndSpare(existingNumCrossShardObservers, ls.maxCrossShardObservers+remaining)
-	numSeeders, _ = computeUsedAndSpare(existingNumSeeders, ls.maxSeeders) // we are not mixing remaining value. We are strict with the number of seeders
-	numFullHistoryObservers, _ = computeUsedAndSpare(existingNumFullHistoryObservers, ls.maxFullHistoryObservers)
-	numUnknown, _ = computeUsedAndSpare(existingNumUnknown, ls.maxUnknown+remaining)
-
-	evictionProposed := evict(peerDistances[intraShardValidators], numIntraShardValidators)
-	e := evict(peerDistances[crossShardValidators], numCrossShardValidators)
-	evictionProposed = append(evictionProposed, e...)
-	e = evict(peerDistances[intraShardObservers], numIntraShardObservers)
-	evictionProposed = append(evictionProposed, e...)
-	e = evict(peerDistances[crossShardObservers], numCrossShardObservers)
-	evictionProposed = append(evictionProposed, e...)
-	e = evict(peerDistances[seeders], numSeeders)
-	evictionProposed = append(evictionProposed, e...)
-	e = evict(peerDistances[fullHistoryObservers], numFullHistoryObservers)
-	evictionProposed = append(evictionProposed, e...)
-	e = evict(peerDistances[unknown], numUnknown)
-	evictionProposed = append(evictionProposed, e...)
+	peersConn := ls.getPeersConnections()
+	peerDistances := ls.splitPeerIds(pidList, peersConn)
+	quotas := computeQuotas(peerDistances, peersConn)
+
+	evictionProposed := make([]peer.ID, 0)
+	for _, category := range evictionOrder {
+		ranked := ls.ranker.rankPeers(peerDistances[category], quotas[category])
+		e := evictRanked(ranked.peers, minInt(quotas[category], ranked.numAllowed))
+		evictionProposed = append(evictionProposed, e...)
+	}
 
 	return evictionProposed
 }
 
+// computeQuotas returns the number of peers each category can keep. The spare slots of the validators and observers
+// categories are passed along to the next categories, ending with the unknown peers. The seeders and the full history
+// observers are strictly limited to their maximum
+func computeQuotas(peerDistances map[int]sorting.PeerDistances, peersConn peersConnections) map[int]int {
+	quotas := make(map[int]int, len(evictionOrder))
+
+	quotas[intraShardValidators] = peersConn.intraShardValidators
+	_, remaining := computeUsedAndSpare(len(peerDistances[intraShardValidators]), quotas[intraShardValidators])
+	quotas[crossShardValidators] = peersConn.crossShardValidators + remaining
+	_, remaining = computeUsedAndSpare(len(peerDistances[crossShardValidators]), quotas[crossShardValidators])
+	quotas[intraShardObservers] = peersConn.intraShardObservers + remaining
+	_, remaining = computeUsedAndSpare(len(peerDistances[intraShardObservers]), quotas[intraShardObservers])
+	quotas[crossShardObservers] = peersConn.crossShardObservers + remaining
+	_, remaining = computeUsedAndSpare(len(peerDistances[crossShardObservers]), quotas[crossShardObservers])
+	quotas[seeders] = peersConn.seeders
+	quotas[fullHistoryObservers] = peersConn.fullHistoryObservers
+	quotas[unknown] = peersConn.unknown + remaining
+
+	return quotas
+}
+
+// ExplainEviction computes the eviction list without evicting any peer and returns, for each provided peer, its
+// category, its distance, the category's quota and whether the peer would be kept or evicted
+func (ls *listsSharder) ExplainEviction(pidList []peer.ID) []p2p.PeerEvictionExplanation {
+	peersConn := ls.getPeersConnections()
+	peerDistances := ls.splitPeerIds(pidList, peersConn)
+	quotas := computeQuotas(peerDistances, peersConn)
+
+	explanations := make(map[peer.ID]p2p.PeerEvictionExplanation, len(pidList))
+	for _, category := range evictionOrder {
+		quota := quotas[category]
+		ranked := ls.ranker.rankPeers(peerDistances[category], quota)
+		for rank, pd := range ranked.peers {
+			explanation := p2p.PeerEvictionExplanation{
+				Peer:      core.PeerID(pd.ID),
+				Category:  categories[category],
+				Distance:  pd.Distance.Uint64(),
+				IsRelayed: ls.relayedConnsChecker.IsRelayed(core.PeerID(pd.ID)),
+				Quota:     quota,
+				Rank:      rank,
+				IsEvicted: rank >= quota || rank >= ranked.numAllowed,
+			}
+			explanation.Reason = explainDecision(explanation, ranked.notes[pd.ID])
+			explanations[pd.ID] = explanation
+		}
+	}
+
+	result := make([]p2p.PeerEvictionExplanation, 0, len(pidList))
+	for _, pid := range pidList {
+		explanation, found := explanations[pid]
+		if !found {
+			// only the preferred peers are left out when splitting the peers in categories
+			explanation = p2p.PeerEvictionExplanation{
+				Peer:     core.PeerID(pid),
+				Category: p2p.PreferredPeersCategory,
+				Distance: computeDistanceWithRelayOffset(ls.computeDistance, pid, ls.selfPeerId, ls.relayedConnsChecker).Uint64(),
+				Reason:   "kept, the preferred peers are never evicted",
+			}
+			explanation.IsRelayed = ls.relayedConnsChecker.IsRelayed(core.PeerID(pid))
+		}
+
+		result = append(result, explanation)
+	}
+
+	return result
+}
+
+// explainDecision builds the reason of the eviction decision. The note of the ranker, if any, replaces the
+// distance based explanation
+func explainDecision(explanation p2p.PeerEvictionExplanation, note string) string {
+	if !explanation.IsEvicted {
+		reason := fmt.Sprintf("kept, ranked %d within the quota of %d %s", explanation.Rank+1, explanation.Quota, explanation.Category)
+		if len(note) > 0 {
+			reason += ", " + note
+		}
+
+		return reason
+	}
+	if explanation.Quota == 0 {
+		return fmt.Sprintf("evicted, there is no quota for %s", explanation.Category)
+	}
+	if explanation.Rank < explanation.Quota {
+		return "evicted, " + note
+	}
+	if len(note) > 0 {
+		return fmt.Sprintf("evicted, ranked %d over the quota of %d %s, %s",
+			explanation.Rank+1, explanation.Quota, explanation.Category, note)
+	}
+	if explanation.IsRelayed {
+		return fmt.Sprintf("evicted, ranked %d over the quota of %d %s, the peers connected only through relays "+
+			"rank after the directly connected ones", explanation.Rank+1, explanation.Quota, explanation.Category)
+	}
+
+	return fmt.Sprintf("evicted, ranked %d over the quota of %d %s, closer peers fill the quota",
+		explanation.Rank+1, explanation.Quota, explanation.Category)
+}
+
+// ComputeMissingPeers returns, for each validators and observers category, the number of peers that can still be
+// connected before reaching the category's maximum. The categories already full are omitted
+func (ls *listsSharder) ComputeMissingPeers(pidList []peer.ID) []p2p.PeersCategoryQuota {
+	peersConn := ls.getPeersConnections()
+	peerDistances := ls.splitPeerIds(pidList, peersConn)
+
+	categoriesUsage := []struct {
+		category p2p.PeersCategory
+		existing int
+		maximum  int
+	}{
+		{p2p.IntraShardValidatorsCategory, len(peerDistances[intraShardValidators]), peersConn.intraShardValidators},
+		{p2p.IntraShardObserversCategory, len(peerDistances[intraShardObservers]), peersConn.intraShardObservers},
+		{p2p.CrossShardValidatorsCategory, len(peerDistances[crossShardValidators]), peersConn.crossShardValidators},
+		{p2p.CrossShardObserversCategory, len(peerDistances[crossShardObservers]), peersConn.crossShardObservers},
+	}
+
+	quotas := make([]p2p.PeersCategoryQuota, 0, len(categoriesUsage))
+	for _, usage := range categoriesUsage {
+		_, numMissing := computeUsedAndSpare(usage.existing, usage.maximum)
+		if numMissing == 0 {
+			continue
+		}
+
+		quotas = append(quotas, p2p.PeersCategoryQuota{
+			Category:   usage.category,
+			NumMissing: numMissing,
+		})
+	}
+
+	return quotas
+}
+
+// GetSelfPeerInfo returns the shard and the type of the current node, as known by the peer shard resolver
+func (ls *listsSharder) GetSelfPeerInfo() core.P2PPeerInfo {
+	ls.mutResolver.RLock()
+	defer ls.mutResolver.RUnlock()
+
+	return ls.peerShardResolver.GetPeerInfo(core.PeerID(ls.selfPeerId))
+}
+
 // computeUsedAndSpare returns the used and the remaining of the two provided (capacity) values
 // if used > maximum, used will equal to maximum and remaining will be 0
 func computeUsedAndSpare(existing int, maximum int) (int, int) {
@@ -244,6 +435,14 @@ func computeUsedAndSpare(existing int, maximum int) (int, int) {
 	return maximum, 0
 }
 
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
 // Has returns true if provided pid is among the provided list
 func (ls *listsSharder) Has(pid peer.ID, list []peer.ID) bool {
 	return has(pid, list)
@@ -259,7 +458,7 @@ func has(pid peer.ID, list []peer.ID) bool {
 	return false
 }
 
-func (ls *listsSharder) splitPeerIds(peers []peer.ID) map[int]sorting.PeerDistances {
+func (ls *listsSharder) splitPeerIds(peers []peer.ID, peersConn peersConnections) map[int]sorting.PeerDistances {
 	peerDistances := map[int]sorting.PeerDistances{
 		intraShardValidators: {},
 		intraShardObservers:  {},
@@ -277,7 +476,7 @@ func (ls *listsSharder) splitPeerIds(peers []peer.ID) map[int]sorting.PeerDistan
 	for _, p := range peers {
 		pd := &sorting.PeerDistance{
 			ID:       p,
-			Distance: ls.computeDistance(p, ls.selfPeerId),
+			Distance: computeDistanceWithRelayOffset(ls.computeDistance, p, ls.selfPeerId, ls.relayedConnsChecker),
 		}
 		pid := core.PeerID(p)
 		isSeeder := ls.IsSeeder(pid)
@@ -315,7 +514,7 @@ func (ls *listsSharder) splitPeerIds(peers []peer.ID) map[int]sorting.PeerDistan
 		case core.ValidatorPeer:
 			peerDistances[intraShardValidators] = append(peerDistances[intraShardValidators], pd)
 		case core.ObserverPeer:
-			shouldAppendToFullHistory := peerInfo.PeerSubType == core.FullHistoryObserver && ls.maxFullHistoryObservers > 0
+			shouldAppendToFullHistory := peerInfo.PeerSubType == core.FullHistoryObserver && peersConn.fullHistoryObservers > 0
 			if shouldAppendToFullHistory {
 				peerDistances[fullHistoryObservers] = append(peerDistances[fullHistoryObservers], pd)
 			} else {
@@ -328,15 +527,25 @@ func (ls *listsSharder) splitPeerIds(peers []peer.ID) map[int]sorting.PeerDistan
 }
 
 func evict(distances sorting.PeerDistances, numKeep int) []peer.ID {
+	if numKeep >= len(distances) {
+		return make([]peer.ID, 0)
+	}
+
+	sort.Sort(distances)
+
+	return evictRanked(distances, numKeep)
+}
+
+// evictRanked returns the peers ranked after the first numKeep peers
+func evictRanked(ranked sorting.PeerDistances, numKeep int) []peer.ID {
 	if numKeep < 0 {
 		numKeep = 0
 	}
-	if numKeep >= len(distances) {
+	if numKeep >= len(ranked) {
 		return make([]peer.ID, 0)
 	}
 
-	sort.Sort(distances)
-	evictedPD := distances[numKeep:]
+	evictedPD := ranked[numKeep:]
 	evictedPids := make([]peer.ID, len(evictedPD))
 	for i, pd := range evictedPD {
 		evictedPids[i] = pd.ID
@@ -345,6 +554,20 @@ func evict(distances sorting.PeerDistances, numKeep int) []peer.ID {
 	return evictedPids
 }
 
+func computeDistanceWithRelayOffset(
+	computeDistance func(src peer.ID, dest peer.ID) *big.Int,
+	pid peer.ID,
+	selfPeerId peer.ID,
+	relayedConnsChecker p2p.RelayedConnectionsChecker,
+) *big.Int {
+	distance := computeDistance(pid, selfPeerId)
+	if relayedConnsChecker.IsRelayed(core.PeerID(pid)) {
+		distance.Add(distance, big.NewInt(relayedPeerDistanceOffset))
+	}
+
+	return distance
+}
+
 // computes the kademlia distance between 2 provided peers by doing byte xor operations and counting the resulting bits
 func computeDistanceByCountingBits(src peer.ID, dest peer.ID) *big.Int {
 	srcBuff := kbucket.ConvertPeerID(src)
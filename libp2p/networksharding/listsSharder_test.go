@@ -70,9 +70,10 @@ func countPeers(peers []peer.ID, shardID uint32, marker string) int {
 
 func createMockListSharderArguments() networksharding.ArgListsSharder {
 	return networksharding.ArgListsSharder{
-		PeerResolver:         createStringPeersShardResolver(),
-		SelfPeerId:           crtPid,
-		PreferredPeersHolder: &mock.PeersHolderStub{},
+		PeerResolver:              createStringPeersShardResolver(),
+		SelfPeerId:                crtPid,
+		PreferredPeersHolder:      &mock.PeersHolderStub{},
+		RelayedConnectionsChecker: &mock.RelayedConnectionsCheckerStub{},
 		P2pConfig: config.P2PConfig{
 			Sharding: config.ShardingConfig{
 				TargetPeerCount:         networksharding.MinAllowedConnectedPeersListSharder,
@@ -175,6 +176,17 @@ func TestNewListsSharder_NilPreferredPeersShouldErr(t *testing.T) {
 	assert.True(t, errors.Is(err, p2p.ErrNilPreferredPeersHolder))
 }
 
+func TestNewListsSharder_NilRelayedConnectionsCheckerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	arg := createMockListSharderArguments()
+	arg.RelayedConnectionsChecker = nil
+	ls, err := networksharding.NewListsSharder(arg)
+
+	assert.True(t, check.IfNil(ls))
+	assert.True(t, errors.Is(err, p2p.ErrNilRelayedConnectionsChecker))
+}
+
 func TestNewListsSharder_NormalShouldWork(t *testing.T) {
 	t.Parallel()
 
@@ -242,6 +254,33 @@ func TestListsSharder_ComputeEvictionListNotReachedValidatorsShouldRetEmpty(t *t
 	assert.Equal(t, 0, len(evictList))
 }
 
+func TestListsSharder_ComputeEvictionListShouldEvictRelayedPeersFirst(t *testing.T) {
+	t.Parallel()
+
+	pid1 := peer.ID(fmt.Sprintf("%d %s 1", crtShardId, validatorMarker))
+	pid2 := peer.ID(fmt.Sprintf("%d %s 2", crtShardId, validatorMarker))
+	pids := []peer.ID{pid1, pid2}
+
+	arg := createMockListSharderArguments()
+	ls, _ := networksharding.NewListsSharder(arg)
+	evictList := ls.ComputeEvictionList(pids)
+	require.Equal(t, 1, len(evictList))
+	keptPid := pid1
+	if evictList[0] == pid1 {
+		keptPid = pid2
+	}
+
+	arg.RelayedConnectionsChecker = &mock.RelayedConnectionsCheckerStub{
+		IsRelayedCalled: func(pid core.PeerID) bool {
+			return pid == core.PeerID(keptPid)
+		},
+	}
+	ls, _ = networksharding.NewListsSharder(arg)
+	evictList = ls.ComputeEvictionList(pids)
+
+	assert.Equal(t, []peer.ID{keptPid}, evictList)
+}
+
 func TestListsSharder_ComputeEvictionListNotReachedObserversShouldRetEmpty(t *testing.T) {
 	t.Parallel()
 
@@ -573,3 +612,163 @@ func TestListsSharder_SetPeerShardResolverShouldWork(t *testing.T) {
 	assert.True(t, ls.GetPeerShardResolver() == newPeerShardResolver)
 	assert.Nil(t, err)
 }
+
+// ------- UpdateShardingConfig
+
+func TestListsSharder_ComputeMissingPeers(t *testing.T) {
+	t.Parallel()
+
+	arg := createMockListSharderArguments()
+	arg.P2pConfig.Sharding.TargetPeerCount = 10
+	arg.P2pConfig.Sharding.MaxIntraShardValidators = 2
+	ls, _ := networksharding.NewListsSharder(arg)
+
+	pids := []peer.ID{
+		peer.ID(fmt.Sprintf("%d %s", crtShardId, validatorMarker)),
+		peer.ID(fmt.Sprintf("%d %s", crossShardId, validatorMarker)),
+		peer.ID(fmt.Sprintf("%d %s", crtShardId, observerMarker)),
+		peer.ID(fmt.Sprintf("%d %s", crtShardId, unknownMarker)),
+	}
+	quotas := ls.ComputeMissingPeers(pids)
+
+	expectedQuotas := []p2p.PeersCategoryQuota{
+		{Category: p2p.IntraShardValidatorsCategory, NumMissing: 1},
+		{Category: p2p.CrossShardObserversCategory, NumMissing: 1},
+	}
+	assert.Equal(t, expectedQuotas, quotas)
+}
+
+func TestListsSharder_ExplainEviction(t *testing.T) {
+	t.Parallel()
+
+	arg := createMockListSharderArguments()
+	arg.PreferredPeersHolder = &mock.PeersHolderStub{
+		ContainsCalled: func(peerID core.PeerID) bool {
+			return strings.Contains(string(peerID), "preferred")
+		},
+	}
+	arg.RelayedConnectionsChecker = &mock.RelayedConnectionsCheckerStub{
+		IsRelayedCalled: func(pid core.PeerID) bool {
+			return strings.Contains(string(pid), "relayed")
+		},
+	}
+	ls, _ := networksharding.NewListsSharder(arg)
+	seeder := peer.ID(fmt.Sprintf("%d %s", crossShardId, seederMarker))
+	ls.SetSeeders([]string{
+		"ip6/" + seeder.String(),
+	})
+
+	directValidator := peer.ID(fmt.Sprintf("%d %s", crtShardId, validatorMarker))
+	relayedValidator := peer.ID(fmt.Sprintf("%d %s relayed", crtShardId, validatorMarker))
+	crossObserver := peer.ID(fmt.Sprintf("%d %s", crossShardId, observerMarker))
+	unknownPeer := peer.ID(fmt.Sprintf("%d %s", crtShardId, unknownMarker))
+	preferredPeer := peer.ID(fmt.Sprintf("%d %s preferred", crtShardId, validatorMarker))
+	pids := []peer.ID{relayedValidator, seeder, directValidator, crossObserver, unknownPeer, preferredPeer}
+
+	explanations := ls.ExplainEviction(pids)
+	require.Equal(t, len(pids), len(explanations))
+
+	// the explanations keep the provided order and match the eviction list
+	evictList := ls.ComputeEvictionList(pids)
+	for i, explanation := range explanations {
+		assert.Equal(t, core.PeerID(pids[i]), explanation.Peer)
+		assert.Equal(t, ls.Has(pids[i], evictList), explanation.IsEvicted, string(pids[i]))
+		assert.NotEmpty(t, explanation.Reason)
+	}
+
+	assert.Equal(t, p2p.IntraShardValidatorsCategory, explanations[0].Category)
+	assert.True(t, explanations[0].IsRelayed)
+	assert.True(t, explanations[0].IsEvicted)
+	assert.Equal(t, 1, explanations[0].Rank)
+	assert.Equal(t, 1, explanations[0].Quota)
+	assert.True(t, strings.Contains(explanations[0].Reason, "relays"))
+
+	assert.Equal(t, p2p.SeedersCategory, explanations[1].Category)
+	assert.True(t, explanations[1].IsEvicted)
+	assert.Equal(t, 0, explanations[1].Quota)
+
+	assert.Equal(t, p2p.IntraShardValidatorsCategory, explanations[2].Category)
+	assert.False(t, explanations[2].IsEvicted)
+	assert.Equal(t, 0, explanations[2].Rank)
+	assert.True(t, explanations[0].Distance >= networksharding.RelayedPeerDistanceOffset)
+	assert.True(t, explanations[2].Distance < networksharding.RelayedPeerDistanceOffset)
+
+	// the spare slots of the cross shard validators and of the intra shard observers are passed along
+	assert.Equal(t, p2p.CrossShardObserversCategory, explanations[3].Category)
+	assert.Equal(t, 3, explanations[3].Quota)
+	assert.False(t, explanations[3].IsEvicted)
+
+	assert.Equal(t, p2p.UnknownPeersCategory, explanations[4].Category)
+	assert.Equal(t, 3, explanations[4].Quota)
+	assert.False(t, explanations[4].IsEvicted)
+
+	assert.Equal(t, p2p.PreferredPeersCategory, explanations[5].Category)
+	assert.False(t, explanations[5].IsEvicted)
+}
+
+func TestListsSharder_GetSelfPeerInfo(t *testing.T) {
+	t.Parallel()
+
+	arg := createMockListSharderArguments()
+	arg.SelfPeerId = peer.ID(fmt.Sprintf("%d %s", crtShardId, validatorMarker))
+	ls, _ := networksharding.NewListsSharder(arg)
+
+	selfPeerInfo := ls.GetSelfPeerInfo()
+	assert.Equal(t, core.ValidatorPeer, selfPeerInfo.PeerType)
+	assert.Equal(t, crtShardId, selfPeerInfo.ShardID)
+}
+
+func TestListsSharder_UpdateShardingConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid config should error and keep the old limits", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockListSharderArguments()
+		ls, _ := networksharding.NewListsSharder(arg)
+
+		newConfig := arg.P2pConfig.Sharding
+		newConfig.MaxIntraShardValidators = 0
+		err := ls.UpdateShardingConfig(newConfig)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+
+		newConfig = arg.P2pConfig.Sharding
+		newConfig.MaxSeeders = newConfig.TargetPeerCount
+		err = ls.UpdateShardingConfig(newConfig)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+
+		assert.Equal(t, int(arg.P2pConfig.Sharding.TargetPeerCount), ls.GetMaxPeerCount())
+		assert.Equal(t, int(arg.P2pConfig.Sharding.MaxIntraShardValidators), ls.GetMaxIntraShardValidators())
+		assert.Equal(t, int(arg.P2pConfig.Sharding.MaxSeeders), ls.GetMaxSeeders())
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockListSharderArguments()
+		arg.NodeOperationMode = p2p.FullArchiveMode
+		ls, _ := networksharding.NewListsSharder(arg)
+
+		newConfig := config.ShardingConfig{
+			TargetPeerCount:         20,
+			MaxIntraShardValidators: 5,
+			MaxCrossShardValidators: 4,
+			MaxIntraShardObservers:  3,
+			MaxCrossShardObservers:  2,
+			MaxSeeders:              1,
+			AdditionalConnections: config.AdditionalConnectionsConfig{
+				MaxFullHistoryObservers: 2,
+			},
+		}
+		err := ls.UpdateShardingConfig(newConfig)
+		assert.Nil(t, err)
+
+		assert.Equal(t, 22, ls.GetMaxPeerCount())
+		assert.Equal(t, 5, ls.GetMaxIntraShardValidators())
+		assert.Equal(t, 4, ls.GetMaxCrossShardValidators())
+		assert.Equal(t, 3, ls.GetMaxIntraShardObservers())
+		assert.Equal(t, 2, ls.GetMaxCrossShardObservers())
+		assert.Equal(t, 1, ls.GetMaxSeeders())
+		assert.Equal(t, 2, ls.GetMaxFullHistoryObservers())
+		assert.Equal(t, 5, ls.GetMaxUnknown())
+	})
+}
@@ -3,6 +3,7 @@ package networksharding
 import (
 	"github.com/TerraDharitri/drt-go-chain-core/core"
 	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
@@ -31,6 +32,11 @@ func (nls *nilListSharder) SetPeerShardResolver(_ p2p.PeerShardResolver) error {
 	return nil
 }
 
+// UpdateShardingConfig does nothing
+func (nls *nilListSharder) UpdateShardingConfig(_ config.ShardingConfig) error {
+	return nil
+}
+
 // SetSeeders does nothing
 func (nls *nilListSharder) SetSeeders(_ []string) {
 }
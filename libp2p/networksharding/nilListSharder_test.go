@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
 	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/networksharding"
 	"github.com/stretchr/testify/assert"
 )
@@ -15,4 +16,5 @@ func TestNilListSharderSharder(t *testing.T) {
 	assert.Equal(t, 0, len(nls.ComputeEvictionList(nil)))
 	assert.False(t, nls.Has("", nil))
 	assert.Nil(t, nls.SetPeerShardResolver(nil))
+	assert.Nil(t, nls.UpdateShardingConfig(config.ShardingConfig{}))
 }
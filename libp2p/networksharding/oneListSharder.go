@@ -3,9 +3,12 @@ package networksharding
 import (
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
 	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
 	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/networksharding/sorting"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
@@ -15,42 +18,68 @@ var _ p2p.Sharder = (*oneListSharder)(nil)
 const minAllowedConnectedPeersOneSharder = 3
 
 type oneListSharder struct {
-	selfPeerId      peer.ID
-	maxPeerCount    int
-	computeDistance func(src peer.ID, dest peer.ID) *big.Int
+	selfPeerId          peer.ID
+	mutMaxPeerCount     sync.RWMutex
+	maxPeerCount        int
+	computeDistance     func(src peer.ID, dest peer.ID) *big.Int
+	relayedConnsChecker p2p.RelayedConnectionsChecker
 }
 
 // NewOneListSharder creates a new sharder instance that is shard agnostic and uses one list
 func NewOneListSharder(
 	selfPeerId peer.ID,
 	maxPeerCount int,
+	relayedConnsChecker p2p.RelayedConnectionsChecker,
 ) (*oneListSharder, error) {
 	if maxPeerCount < minAllowedConnectedPeersOneSharder {
 		return nil, fmt.Errorf("%w, maxPeerCount should be at least %d", p2p.ErrInvalidValue, minAllowedConnectedPeersOneSharder)
 	}
+	if check.IfNil(relayedConnsChecker) {
+		return nil, p2p.ErrNilRelayedConnectionsChecker
+	}
 
 	return &oneListSharder{
-		selfPeerId:      selfPeerId,
-		maxPeerCount:    maxPeerCount,
-		computeDistance: computeDistanceByCountingBits,
+		selfPeerId:          selfPeerId,
+		maxPeerCount:        maxPeerCount,
+		computeDistance:     computeDistanceByCountingBits,
+		relayedConnsChecker: relayedConnsChecker,
 	}, nil
 }
 
 // ComputeEvictionList returns the eviction list
 func (ols *oneListSharder) ComputeEvictionList(pidList []peer.ID) []peer.ID {
 	list := ols.convertList(pidList)
-	evictionProposed := evict(list, ols.maxPeerCount)
+
+	ols.mutMaxPeerCount.RLock()
+	maxPeerCount := ols.maxPeerCount
+	ols.mutMaxPeerCount.RUnlock()
+
+	evictionProposed := evict(list, maxPeerCount)
 
 	return evictionProposed
 }
 
+// UpdateShardingConfig validates the provided sharding config and replaces the maximum peer count
+func (ols *oneListSharder) UpdateShardingConfig(shardingConfig config.ShardingConfig) error {
+	maxPeerCount := int(shardingConfig.TargetPeerCount)
+	if maxPeerCount < minAllowedConnectedPeersOneSharder {
+		return fmt.Errorf("%w, maxPeerCount should be at least %d", p2p.ErrInvalidValue, minAllowedConnectedPeersOneSharder)
+	}
+
+	ols.mutMaxPeerCount.Lock()
+	ols.maxPeerCount = maxPeerCount
+	ols.mutMaxPeerCount.Unlock()
+
+	return nil
+}
+
 func (ols *oneListSharder) convertList(peers []peer.ID) sorting.PeerDistances {
 	list := sorting.PeerDistances{}
 
 	for _, p := range peers {
 		pd := &sorting.PeerDistance{
 			ID:       p,
-			Distance: ols.computeDistance(p, ols.selfPeerId),
+			Distance: computeDistanceWithRelayOffset(ols.computeDistance, p, ols.selfPeerId, ols.relayedConnsChecker),
 		}
 		list = append(list, pd)
 	}
@@ -4,9 +4,12 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/TerraDharitri/drt-go-chain-core/core"
 	"github.com/TerraDharitri/drt-go-chain-core/core/check"
 	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
 	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/networksharding"
+	"github.com/TerraDharitri/drt-go-chain-p2p/mock"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
 )
@@ -17,6 +20,7 @@ func TestNewOneListSharder_InvalidMaxPeerCountShouldErr(t *testing.T) {
 	ols, err := networksharding.NewOneListSharder(
 		"",
 		networksharding.MinAllowedConnectedPeersOneSharder-1,
+		&mock.RelayedConnectionsCheckerStub{},
 	)
 
 	assert.True(t, check.IfNil(ols))
@@ -29,12 +33,26 @@ func TestNewOneListSharder_ShouldWork(t *testing.T) {
 	ols, err := networksharding.NewOneListSharder(
 		"",
 		networksharding.MinAllowedConnectedPeersOneSharder,
+		&mock.RelayedConnectionsCheckerStub{},
 	)
 
 	assert.False(t, check.IfNil(ols))
 	assert.Nil(t, err)
 }
 
+func TestNewOneListSharder_NilRelayedConnectionsCheckerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ols, err := networksharding.NewOneListSharder(
+		"",
+		networksharding.MinAllowedConnectedPeersOneSharder,
+		nil,
+	)
+
+	assert.True(t, check.IfNil(ols))
+	assert.Equal(t, p2p.ErrNilRelayedConnectionsChecker, err)
+}
+
 // ------- ComputeEvictionList
 
 func TestOneListSharder_ComputeEvictionListNotReachedShouldRetEmpty(t *testing.T) {
@@ -43,6 +61,7 @@ func TestOneListSharder_ComputeEvictionListNotReachedShouldRetEmpty(t *testing.T
 	ols, _ := networksharding.NewOneListSharder(
 		crtPid,
 		networksharding.MinAllowedConnectedPeersOneSharder,
+		&mock.RelayedConnectionsCheckerStub{},
 	)
 	pid1 := peer.ID("pid1")
 	pid2 := peer.ID("pid2")
@@ -59,6 +78,7 @@ func TestOneListSharder_ComputeEvictionListReachedIntraShardShouldSortAndEvict(t
 	ols, _ := networksharding.NewOneListSharder(
 		crtPid,
 		networksharding.MinAllowedConnectedPeersOneSharder,
+		&mock.RelayedConnectionsCheckerStub{},
 	)
 	pid1 := peer.ID("pid1")
 	pid2 := peer.ID("pid2")
@@ -72,6 +92,45 @@ func TestOneListSharder_ComputeEvictionListReachedIntraShardShouldSortAndEvict(t
 	assert.Equal(t, pid3, evictList[0])
 }
 
+func TestOneListSharder_ComputeEvictionListShouldEvictRelayedPeersFirst(t *testing.T) {
+	t.Parallel()
+
+	pid1 := peer.ID("pid1")
+	ols, _ := networksharding.NewOneListSharder(
+		crtPid,
+		networksharding.MinAllowedConnectedPeersOneSharder,
+		&mock.RelayedConnectionsCheckerStub{
+			IsRelayedCalled: func(pid core.PeerID) bool {
+				return pid == core.PeerID(pid1)
+			},
+		},
+	)
+	pids := []peer.ID{pid1, "pid2", "pid3", "pid4"}
+
+	evictList := ols.ComputeEvictionList(pids)
+
+	assert.Equal(t, []peer.ID{pid1}, evictList)
+}
+
+func TestOneListSharder_UpdateShardingConfig(t *testing.T) {
+	t.Parallel()
+
+	ols, _ := networksharding.NewOneListSharder(
+		crtPid,
+		networksharding.MinAllowedConnectedPeersOneSharder,
+		&mock.RelayedConnectionsCheckerStub{},
+	)
+	pids := []peer.ID{"pid1", "pid2", "pid3", "pid4"}
+
+	err := ols.UpdateShardingConfig(config.ShardingConfig{TargetPeerCount: networksharding.MinAllowedConnectedPeersOneSharder - 1})
+	assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	assert.Equal(t, 1, len(ols.ComputeEvictionList(pids)))
+
+	err = ols.UpdateShardingConfig(config.ShardingConfig{TargetPeerCount: uint32(len(pids))})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(ols.ComputeEvictionList(pids)))
+}
+
 // ------- Has
 
 func TestOneListSharder_HasNotFound(t *testing.T) {
@@ -81,6 +140,7 @@ func TestOneListSharder_HasNotFound(t *testing.T) {
 	ols, _ := networksharding.NewOneListSharder(
 		crtPid,
 		networksharding.MinAllowedConnectedPeersOneSharder,
+		&mock.RelayedConnectionsCheckerStub{},
 	)
 
 	assert.False(t, ols.Has("pid4", list))
@@ -93,6 +153,7 @@ func TestOneListSharder_HasEmpty(t *testing.T) {
 	ols, _ := networksharding.NewOneListSharder(
 		crtPid,
 		networksharding.MinAllowedConnectedPeersOneSharder,
+		&mock.RelayedConnectionsCheckerStub{},
 	)
 
 	assert.False(t, ols.Has("pid4", list))
@@ -105,6 +166,7 @@ func TestOneListSharder_HasFound(t *testing.T) {
 	ols, _ := networksharding.NewOneListSharder(
 		crtPid,
 		networksharding.MinAllowedConnectedPeersOneSharder,
+		&mock.RelayedConnectionsCheckerStub{},
 	)
 
 	assert.True(t, ols.Has("pid2", list))
@@ -123,6 +185,7 @@ func TestOneListSharder_SetPeerShardResolverShouldNotPanic(t *testing.T) {
 	ols, _ := networksharding.NewOneListSharder(
 		"",
 		networksharding.MinAllowedConnectedPeersOneSharder,
+		&mock.RelayedConnectionsCheckerStub{},
 	)
 
 	err := ols.SetPeerShardResolver(nil)
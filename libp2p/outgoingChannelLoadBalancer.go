@@ -2,6 +2,7 @@ package libp2p
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
@@ -9,18 +10,72 @@ import (
 
 var _ ChannelLoadBalancer = (*OutgoingChannelLoadBalancer)(nil)
 
-const defaultSendChannel = "default send channel"
+const (
+	defaultSendChannel          = "default send channel"
+	defaultChannelWeight        = 1
+	defaultChannelMaxQueueDepth = 10000
+)
+
+// channelQueue holds the messages written on a channel until they are collected
+type channelQueue struct {
+	name          string
+	weight        int64
+	currentWeight int64
+	maxQueueDepth int
+	dropPolicy    p2p.DropPolicy
+	items         []*SendableData
+	numDropped    uint64
+	isRemoved     bool
+}
+
+// push adds the object in the queue and returns the object dropped, if the queue was full or removed, along with
+// the reason it was dropped
+func (queue *channelQueue) push(obj *SendableData) (*SendableData, error) {
+	if queue.isRemoved {
+		return obj, p2p.ErrChannelRemoved
+	}
+	if len(queue.items) < queue.maxQueueDepth {
+		queue.items = append(queue.items, obj)
+		return nil, nil
+	}
+
+	queue.numDropped++
+	log.Trace("OutgoingChannelLoadBalancer: send channel full, message dropped",
+		"channel", queue.name,
+		"policy", queue.dropPolicy.String(),
+	)
+	if queue.dropPolicy == p2p.DropNewest {
+		return obj, p2p.ErrSendQueueFull
+	}
+
+	dropped := queue.items[0]
+	queue.items[0] = nil
+	queue.items = append(queue.items[1:], obj)
+
+	return dropped, p2p.ErrSendQueueFull
+}
+
+func (queue *channelQueue) pop() *SendableData {
+	obj := queue.items[0]
+	queue.items[0] = nil
+	queue.items = queue.items[1:]
+
+	return obj
+}
 
-// OutgoingChannelLoadBalancer is a component that evenly balances requests to be sent
+// OutgoingChannelLoadBalancer is a component that balances requests to be sent using the weights of the channels
 type OutgoingChannelLoadBalancer struct {
-	mut      sync.RWMutex
-	chans    []chan *SendableData
-	mainChan chan *SendableData
-	names    []string
+	mut    sync.RWMutex
+	chans  []chan *SendableData
+	queues []*channelQueue
+	names  []string
 	//namesChans is defined only for performance purposes as to fast search by name
 	//iteration is done directly on slices as that is used very often and is about 50x
 	//faster then an iteration over a map
 	namesChans map[string]chan *SendableData
+	chanNotify chan struct{}
+	//condFreed is signaled each time room is made in a queue so the objects written on the channels can wait for it
+	condFreed  *sync.Cond
 	cancelFunc context.CancelFunc
 	ctx        context.Context //we need the context saved here in order to call appendChannel from exported func AddChannel
 }
@@ -31,45 +86,125 @@ func NewOutgoingChannelLoadBalancer() *OutgoingChannelLoadBalancer {
 
 	oclb := &OutgoingChannelLoadBalancer{
 		chans:      make([]chan *SendableData, 0),
+		queues:     make([]*channelQueue, 0),
 		names:      make([]string, 0),
 		namesChans: make(map[string]chan *SendableData),
-		mainChan:   make(chan *SendableData),
+		chanNotify: make(chan struct{}, 1),
 		cancelFunc: cancelFunc,
 		ctx:        ctx,
 	}
+	oclb.condFreed = sync.NewCond(&oclb.mut)
 
-	oclb.appendChannel(defaultSendChannel)
+	oclb.appendChannel(defaultSendChannel, p2p.ChannelOptions{})
 
 	return oclb
 }
 
-func (oplb *OutgoingChannelLoadBalancer) appendChannel(channel string) {
+func checkChannelOptions(options p2p.ChannelOptions) error {
+	if options.MaxQueueDepth < 0 {
+		return fmt.Errorf("%w for MaxQueueDepth, got %d", p2p.ErrInvalidValue, options.MaxQueueDepth)
+	}
+
+	switch options.DropPolicy {
+	case p2p.DropOldest, p2p.DropNewest:
+		return nil
+	default:
+		return fmt.Errorf("%w for DropPolicy, got %d", p2p.ErrInvalidValue, options.DropPolicy)
+	}
+}
+
+func (oplb *OutgoingChannelLoadBalancer) appendChannel(channel string, options p2p.ChannelOptions) {
+	queue := &channelQueue{
+		name:          channel,
+		weight:        int64(options.Weight),
+		maxQueueDepth: options.MaxQueueDepth,
+		dropPolicy:    options.DropPolicy,
+		items:         make([]*SendableData, 0),
+	}
+	if queue.weight == 0 {
+		queue.weight = defaultChannelWeight
+	}
+	if queue.maxQueueDepth == 0 {
+		queue.maxQueueDepth = defaultChannelMaxQueueDepth
+	}
+
 	oplb.names = append(oplb.names, channel)
 	ch := make(chan *SendableData)
 	oplb.chans = append(oplb.chans, ch)
+	oplb.queues = append(oplb.queues, queue)
 	oplb.namesChans[channel] = ch
 
 	go func() {
 		for {
-			var obj *SendableData
-
 			select {
-			case obj = <-ch:
+			case obj, ok := <-ch:
+				if !ok {
+					return
+				}
+				err := oplb.enqueueWaiting(queue, obj)
+				if err != nil {
+					obj.notifyResult(err)
+				}
 			case <-oplb.ctx.Done():
 				log.Debug("closing OutgoingChannelLoadBalancer's append channel go routine")
 				return
 			}
-
-			oplb.mainChan <- obj
 		}
 	}()
 }
 
-// AddChannel adds a new channel to the throttler, if it does not exists
+func (oplb *OutgoingChannelLoadBalancer) enqueue(queue *channelQueue, obj *SendableData) (*SendableData, error) {
+	oplb.mut.Lock()
+	dropped, err := queue.push(obj)
+	oplb.mut.Unlock()
+
+	select {
+	case oplb.chanNotify <- struct{}{}:
+	default:
+	}
+
+	return dropped, err
+}
+
+// enqueueWaiting adds the object in the queue, waiting while the queue is full. The objects written on the channels
+// are never dropped as the writers are expected to block until their object is accepted
+func (oplb *OutgoingChannelLoadBalancer) enqueueWaiting(queue *channelQueue, obj *SendableData) error {
+	oplb.mut.Lock()
+	for len(queue.items) >= queue.maxQueueDepth && !queue.isRemoved && oplb.ctx.Err() == nil {
+		oplb.condFreed.Wait()
+	}
+	if oplb.ctx.Err() != nil {
+		oplb.mut.Unlock()
+		return p2p.ErrMessengerClosed
+	}
+	_, err := queue.push(obj)
+	oplb.mut.Unlock()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case oplb.chanNotify <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// AddChannel adds a new channel with the default options to the throttler, if it does not exists
 func (oplb *OutgoingChannelLoadBalancer) AddChannel(channel string) error {
+	return oplb.AddChannelWithOptions(channel, p2p.ChannelOptions{})
+}
+
+// AddChannelWithOptions adds a new channel with the provided options to the throttler, if it does not exists
+func (oplb *OutgoingChannelLoadBalancer) AddChannelWithOptions(channel string, options p2p.ChannelOptions) error {
 	if channel == defaultSendChannel {
 		return p2p.ErrChannelCanNotBeReAdded
 	}
+	err := checkChannelOptions(options)
+	if err != nil {
+		return fmt.Errorf("%w for channel %s", err, channel)
+	}
 
 	oplb.mut.Lock()
 	defer oplb.mut.Unlock()
@@ -80,17 +215,32 @@ func (oplb *OutgoingChannelLoadBalancer) AddChannel(channel string) error {
 		}
 	}
 
-	oplb.appendChannel(channel)
+	oplb.appendChannel(channel, options)
 
 	return nil
 }
 
-// RemoveChannel removes an existing channel from the throttler
+// RemoveChannel removes an existing channel from the throttler. The messages still queued on the channel are dropped,
+// their result handlers being notified with ErrChannelRemoved
 func (oplb *OutgoingChannelLoadBalancer) RemoveChannel(channel string) error {
 	if channel == defaultSendChannel {
 		return p2p.ErrChannelCanNotBeDeleted
 	}
 
+	discarded, err := oplb.removeChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range discarded {
+		obj.notifyResult(p2p.ErrChannelRemoved)
+	}
+
+	return nil
+}
+
+// removeChannel removes the channel and returns the objects that were still queued on it
+func (oplb *OutgoingChannelLoadBalancer) removeChannel(channel string) ([]*SendableData, error) {
 	oplb.mut.Lock()
 	defer oplb.mut.Unlock()
 
@@ -104,16 +254,25 @@ func (oplb *OutgoingChannelLoadBalancer) RemoveChannel(channel string) error {
 	}
 
 	if index == -1 {
-		return p2p.ErrChannelDoesNotExist
+		return nil, p2p.ErrChannelDoesNotExist
 	}
 
 	sendableChan := oplb.chans[index]
+	discarded := oplb.queues[index].items
+	oplb.queues[index].items = make([]*SendableData, 0)
+	// the queue might have been already fetched by a concurrent Enqueue call, the flag makes it refuse new objects
+	oplb.queues[index].isRemoved = true
 
 	//remove the index-th element in the chan slice
 	copy(oplb.chans[index:], oplb.chans[index+1:])
 	oplb.chans[len(oplb.chans)-1] = nil
 	oplb.chans = oplb.chans[:len(oplb.chans)-1]
 
+	//remove the index-th element in the queues slice
+	copy(oplb.queues[index:], oplb.queues[index+1:])
+	oplb.queues[len(oplb.queues)-1] = nil
+	oplb.queues = oplb.queues[:len(oplb.queues)-1]
+
 	//remove the index-th element in the names slice
 	copy(oplb.names[index:], oplb.names[index+1:])
 	oplb.names = oplb.names[:len(oplb.names)-1]
@@ -121,8 +280,9 @@ func (oplb *OutgoingChannelLoadBalancer) RemoveChannel(channel string) error {
 	close(sendableChan)
 
 	delete(oplb.namesChans, channel)
+	oplb.condFreed.Broadcast()
 
-	return nil
+	return discarded, nil
 }
 
 // GetChannelOrDefault fetches the required channel or the default if the channel is not present
@@ -138,19 +298,120 @@ func (oplb *OutgoingChannelLoadBalancer) GetChannelOrDefault(channel string) cha
 	return oplb.chans[0]
 }
 
-// CollectOneElementFromChannels gets the waiting object from mainChan. It is a blocking call.
+// Enqueue adds the data on the required channel or on the default channel if the channel is not present,
+// without blocking. It errors if the data was dropped because the channel queue is full or the channel was
+// removed meanwhile. If the channel drops the oldest data, the result handler of the dropped data is notified instead
+func (oplb *OutgoingChannelLoadBalancer) Enqueue(channel string, data *SendableData) error {
+	oplb.mut.RLock()
+	queue := oplb.queues[0]
+	for idx, name := range oplb.names {
+		if name == channel {
+			queue = oplb.queues[idx]
+			break
+		}
+	}
+	oplb.mut.RUnlock()
+
+	dropped, err := oplb.enqueue(queue, data)
+	if dropped == data {
+		return fmt.Errorf("%w for channel %s", err, queue.name)
+	}
+	if dropped != nil {
+		dropped.notifyResult(err)
+	}
+
+	return nil
+}
+
+// CollectOneElementFromChannels gets the next object to be sent, choosing the channel by its weight.
+// It is a blocking call.
 func (oplb *OutgoingChannelLoadBalancer) CollectOneElementFromChannels() *SendableData {
-	select {
-	case obj := <-oplb.mainChan:
-		return obj
-	case <-oplb.ctx.Done():
+	for {
+		obj := oplb.dequeue()
+		if obj != nil {
+			return obj
+		}
+
+		select {
+		case <-oplb.chanNotify:
+		case <-oplb.ctx.Done():
+			return nil
+		}
+	}
+}
+
+// dequeue uses the smooth weighted round-robin algorithm over the channels that have queued objects
+func (oplb *OutgoingChannelLoadBalancer) dequeue() *SendableData {
+	oplb.mut.Lock()
+	defer oplb.mut.Unlock()
+
+	var selected *channelQueue
+	totalWeight := int64(0)
+	for _, queue := range oplb.queues {
+		if len(queue.items) == 0 {
+			continue
+		}
+
+		queue.currentWeight += queue.weight
+		totalWeight += queue.weight
+		if selected == nil || queue.currentWeight > selected.currentWeight {
+			selected = queue
+		}
+	}
+
+	if selected == nil {
 		return nil
 	}
+
+	selected.currentWeight -= totalWeight
+	oplb.condFreed.Broadcast()
+
+	return selected.pop()
 }
 
-// Close finishes all started go routines in this instance
+// ChannelsStats returns the queue depth and the dropped objects counter for each channel
+func (oplb *OutgoingChannelLoadBalancer) ChannelsStats() []p2p.ChannelStats {
+	oplb.mut.RLock()
+	defer oplb.mut.RUnlock()
+
+	stats := make([]p2p.ChannelStats, 0, len(oplb.queues))
+	for _, queue := range oplb.queues {
+		stats = append(stats, p2p.ChannelStats{
+			Name:          queue.name,
+			Weight:        uint32(queue.weight),
+			QueueDepth:    len(queue.items),
+			MaxQueueDepth: queue.maxQueueDepth,
+			NumDropped:    queue.numDropped,
+		})
+	}
+
+	return stats
+}
+
+// DiscardAll removes all the queued objects, notifying their result handlers with ErrMessengerClosed.
+// It returns the number of discarded objects
+func (oplb *OutgoingChannelLoadBalancer) DiscardAll() int {
+	oplb.mut.Lock()
+	discarded := make([]*SendableData, 0)
+	for _, queue := range oplb.queues {
+		discarded = append(discarded, queue.items...)
+		queue.items = make([]*SendableData, 0)
+	}
+	oplb.condFreed.Broadcast()
+	oplb.mut.Unlock()
+
+	for _, obj := range discarded {
+		obj.notifyResult(p2p.ErrMessengerClosed)
+	}
+
+	return len(discarded)
+}
+
+// Close finishes all started go routines in this instance. The objects still queued are discarded
 func (oplb *OutgoingChannelLoadBalancer) Close() error {
 	oplb.cancelFunc()
+	_ = oplb.DiscardAll()
+
 	return nil
 }
 
@@ -101,6 +101,64 @@ func TestOutgoingChannelLoadBalancer_AddChannelReAddChannelShouldDoNothing(t *te
 	assert.Equal(t, 2, len(oclb.Chans()))
 }
 
+func TestOutgoingChannelLoadBalancer_AddChannelWithOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default channel should error", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+
+		err := oclb.AddChannelWithOptions(libp2p.DefaultSendChannel(), p2p.ChannelOptions{})
+		assert.Equal(t, p2p.ErrChannelCanNotBeReAdded, err)
+	})
+	t.Run("invalid max queue depth should error", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+
+		err := oclb.AddChannelWithOptions("test", p2p.ChannelOptions{MaxQueueDepth: -1})
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Equal(t, 1, len(oclb.Names()))
+	})
+	t.Run("invalid drop policy should error", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+
+		err := oclb.AddChannelWithOptions("test", p2p.ChannelOptions{DropPolicy: p2p.DropNewest + 1})
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Equal(t, 1, len(oclb.Names()))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+
+		err := oclb.AddChannelWithOptions("test", p2p.ChannelOptions{
+			Weight:        5,
+			MaxQueueDepth: 10,
+			DropPolicy:    p2p.DropNewest,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, checkIntegrity(oclb, "test"))
+
+		expectedStats := []p2p.ChannelStats{
+			{
+				Name:          libp2p.DefaultSendChannel(),
+				Weight:        1,
+				MaxQueueDepth: libp2p.DefaultChannelMaxQueueDepth,
+			},
+			{
+				Name:          "test",
+				Weight:        5,
+				MaxQueueDepth: 10,
+			},
+		}
+		assert.Equal(t, expectedStats, oclb.ChannelsStats())
+	})
+}
+
 //------- RemoveChannel
 
 func TestOutgoingChannelLoadBalancer_RemoveChannelRemoveDefaultShouldErr(t *testing.T) {
@@ -183,6 +241,30 @@ func TestOutgoingChannelLoadBalancer_RemoveChannelRemoveMiddleChannelAddedShould
 	assert.Nil(t, checkIntegrity(oclb, "test3"))
 }
 
+func TestOutgoingChannelLoadBalancer_RemoveChannelShouldNotifyTheQueuedData(t *testing.T) {
+	t.Parallel()
+
+	oclb := libp2p.NewOutgoingChannelLoadBalancer()
+	_ = oclb.AddChannel("test1")
+
+	numNotified := 0
+	resultHandler := func(err error) {
+		assert.Equal(t, p2p.ErrChannelRemoved, err)
+		numNotified++
+	}
+	for i := 0; i < 3; i++ {
+		_ = oclb.Enqueue("test1", &libp2p.SendableData{
+			ResultHandler: resultHandler,
+		})
+	}
+
+	err := oclb.RemoveChannel("test1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, numNotified)
+	assert.Equal(t, 1, len(oclb.ChannelsStats()))
+}
+
 //------- GetChannelOrDefault
 
 func TestOutgoingChannelLoadBalancer_GetChannelOrDefaultNotFoundShouldReturnDefault(t *testing.T) {
@@ -209,6 +291,89 @@ func TestOutgoingChannelLoadBalancer_GetChannelOrDefaultFoundShouldReturnChannel
 	assert.True(t, oclb.NamesChans()["test1"] == channel)
 }
 
+//------- Enqueue
+
+func TestOutgoingChannelLoadBalancer_Enqueue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing channel should use the default channel", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+
+		err := oclb.Enqueue("missing channel", &libp2p.SendableData{})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, oclb.ChannelsStats()[0].QueueDepth)
+	})
+	t.Run("full queue dropping the newest should error", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+		_ = oclb.AddChannelWithOptions("test", p2p.ChannelOptions{
+			MaxQueueDepth: 1,
+			DropPolicy:    p2p.DropNewest,
+		})
+
+		err := oclb.Enqueue("test", &libp2p.SendableData{})
+		assert.Nil(t, err)
+		err = oclb.Enqueue("test", &libp2p.SendableData{})
+		assert.True(t, errors.Is(err, p2p.ErrSendQueueFull))
+		assert.Equal(t, uint64(1), oclb.ChannelsStats()[1].NumDropped)
+	})
+	t.Run("full queue dropping the oldest should notify the dropped data", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+		_ = oclb.AddChannelWithOptions("test", p2p.ChannelOptions{
+			MaxQueueDepth: 1,
+			DropPolicy:    p2p.DropOldest,
+		})
+
+		var droppedErr error
+		err := oclb.Enqueue("test", &libp2p.SendableData{
+			ResultHandler: func(err error) {
+				droppedErr = err
+			},
+		})
+		assert.Nil(t, err)
+		err = oclb.Enqueue("test", &libp2p.SendableData{})
+		assert.Nil(t, err)
+		assert.Equal(t, p2p.ErrSendQueueFull, droppedErr)
+		assert.Equal(t, uint64(1), oclb.ChannelsStats()[1].NumDropped)
+	})
+	t.Run("channel removed concurrently should refuse the data", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+		_ = oclb.AddChannel("test")
+
+		err := oclb.EnqueueAfterRemovingChannel("test", &libp2p.SendableData{})
+		assert.Equal(t, p2p.ErrChannelRemoved, err)
+		assert.Equal(t, 1, len(oclb.ChannelsStats()))
+		assert.Equal(t, 0, oclb.ChannelsStats()[0].QueueDepth)
+	})
+}
+
+//------- Close
+
+func TestOutgoingChannelLoadBalancer_CloseShouldNotifyQueuedData(t *testing.T) {
+	t.Parallel()
+
+	oclb := libp2p.NewOutgoingChannelLoadBalancer()
+
+	var queuedErr error
+	_ = oclb.Enqueue(libp2p.DefaultSendChannel(), &libp2p.SendableData{
+		ResultHandler: func(err error) {
+			queuedErr = err
+		},
+	})
+
+	err := oclb.Close()
+	assert.Nil(t, err)
+	assert.Equal(t, p2p.ErrMessengerClosed, queuedErr)
+	assert.Nil(t, oclb.CollectOneElementFromChannels())
+}
+
 //------- CollectOneElementFromChannels
 
 func TestOutgoingChannelLoadBalancer_CollectFromChannelsNoObjectsShouldWaitBlocking(t *testing.T) {
@@ -299,3 +464,173 @@ func TestOutgoingChannelLoadBalancer_CollectOneElementFromChannelsShouldWork(t *
 		return
 	}
 }
+
+func writeOnChannel(oclb *libp2p.OutgoingChannelLoadBalancer, channel string, objs ...*libp2p.SendableData) {
+	for _, obj := range objs {
+		oclb.GetChannelOrDefault(channel) <- obj
+	}
+
+	// the objects are moved in the channel's queue on a separate go routine
+	time.Sleep(time.Millisecond * 100)
+}
+
+func enqueueOnChannel(oclb *libp2p.OutgoingChannelLoadBalancer, channel string, objs ...*libp2p.SendableData) {
+	for _, obj := range objs {
+		_ = oclb.Enqueue(channel, obj)
+	}
+}
+
+func TestOutgoingChannelLoadBalancer_CollectOneElementFromChannelsShouldUseWeights(t *testing.T) {
+	t.Parallel()
+
+	oclb := libp2p.NewOutgoingChannelLoadBalancer()
+	defer func() {
+		_ = oclb.Close()
+	}()
+
+	_ = oclb.AddChannelWithOptions("priority", p2p.ChannelOptions{Weight: 3})
+	_ = oclb.AddChannel("bulk")
+
+	for i := 0; i < 8; i++ {
+		oclb.GetChannelOrDefault("bulk") <- &libp2p.SendableData{Topic: "bulk"}
+		oclb.GetChannelOrDefault("priority") <- &libp2p.SendableData{Topic: "priority"}
+	}
+	// the objects are moved in the channels' queues on separate go routines
+	time.Sleep(time.Millisecond * 100)
+
+	collected := make(map[string]int)
+	for i := 0; i < 8; i++ {
+		obj := oclb.CollectOneElementFromChannels()
+		collected[obj.Topic]++
+	}
+	assert.Equal(t, 6, collected["priority"])
+	assert.Equal(t, 2, collected["bulk"])
+
+	// the remaining objects from the bulk channel should be collected after the priority channel empties
+	for i := 0; i < 8; i++ {
+		obj := oclb.CollectOneElementFromChannels()
+		collected[obj.Topic]++
+	}
+	assert.Equal(t, 8, collected["priority"])
+	assert.Equal(t, 8, collected["bulk"])
+}
+
+//------- Queue depth
+
+func TestOutgoingChannelLoadBalancer_FullQueueShouldDrop(t *testing.T) {
+	t.Parallel()
+
+	obj1 := &libp2p.SendableData{Topic: "obj1"}
+	obj2 := &libp2p.SendableData{Topic: "obj2"}
+	obj3 := &libp2p.SendableData{Topic: "obj3"}
+
+	t.Run("drop oldest", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+		defer func() {
+			_ = oclb.Close()
+		}()
+
+		_ = oclb.AddChannelWithOptions("test", p2p.ChannelOptions{
+			MaxQueueDepth: 2,
+			DropPolicy:    p2p.DropOldest,
+		})
+		enqueueOnChannel(oclb, "test", obj1, obj2, obj3)
+
+		stats := oclb.ChannelsStats()[1]
+		assert.Equal(t, 2, stats.QueueDepth)
+		assert.Equal(t, uint64(1), stats.NumDropped)
+
+		assert.True(t, obj2 == oclb.CollectOneElementFromChannels())
+		assert.True(t, obj3 == oclb.CollectOneElementFromChannels())
+		assert.Equal(t, 0, oclb.ChannelsStats()[1].QueueDepth)
+	})
+	t.Run("drop newest", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+		defer func() {
+			_ = oclb.Close()
+		}()
+
+		_ = oclb.AddChannelWithOptions("test", p2p.ChannelOptions{
+			MaxQueueDepth: 2,
+			DropPolicy:    p2p.DropNewest,
+		})
+		enqueueOnChannel(oclb, "test", obj1, obj2, obj3)
+
+		stats := oclb.ChannelsStats()[1]
+		assert.Equal(t, 2, stats.QueueDepth)
+		assert.Equal(t, uint64(1), stats.NumDropped)
+
+		assert.True(t, obj1 == oclb.CollectOneElementFromChannels())
+		assert.True(t, obj2 == oclb.CollectOneElementFromChannels())
+		assert.Equal(t, 0, oclb.ChannelsStats()[1].QueueDepth)
+	})
+}
+
+func TestOutgoingChannelLoadBalancer_FullQueueShouldBlockTheChannelWriters(t *testing.T) {
+	t.Parallel()
+
+	obj1 := &libp2p.SendableData{Topic: "obj1"}
+	obj2 := &libp2p.SendableData{Topic: "obj2"}
+	obj3 := &libp2p.SendableData{Topic: "obj3"}
+
+	t.Run("should not drop", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+		defer func() {
+			_ = oclb.Close()
+		}()
+
+		_ = oclb.AddChannelWithOptions("test", p2p.ChannelOptions{
+			MaxQueueDepth: 1,
+			DropPolicy:    p2p.DropNewest,
+		})
+		chanDone := make(chan struct{})
+		go func() {
+			writeOnChannel(oclb, "test", obj1, obj2, obj3)
+			close(chanDone)
+		}()
+
+		select {
+		case <-chanDone:
+			assert.Fail(t, "should have blocked while the queue is full")
+		case <-time.After(time.Millisecond * 300):
+		}
+
+		assert.True(t, obj1 == oclb.CollectOneElementFromChannels())
+		assert.True(t, obj2 == oclb.CollectOneElementFromChannels())
+		assert.True(t, obj3 == oclb.CollectOneElementFromChannels())
+		assert.Equal(t, uint64(0), oclb.ChannelsStats()[1].NumDropped)
+	})
+	t.Run("close should notify the waiting object", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+		_ = oclb.AddChannelWithOptions("test", p2p.ChannelOptions{
+			MaxQueueDepth: 1,
+		})
+
+		chanErr := make(chan error, 2)
+		resultHandler := func(err error) {
+			chanErr <- err
+		}
+		writeOnChannel(oclb, "test",
+			&libp2p.SendableData{ResultHandler: resultHandler},
+			&libp2p.SendableData{ResultHandler: resultHandler},
+		)
+
+		_ = oclb.Close()
+		for i := 0; i < 2; i++ {
+			select {
+			case err := <-chanErr:
+				assert.Equal(t, p2p.ErrMessengerClosed, err)
+			case <-time.After(durationWait):
+				assert.Fail(t, "timeout")
+			}
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package libp2p
+
+import (
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// createPeerScoreOptions converts the peer scoring config into gossipsub options. The provided inspect handler
+// will be periodically called with the current scores of the connected peers
+func createPeerScoreOptions(cfg config.PeerScoringConfig, inspect func(scores map[peer.ID]float64)) ([]pubsub.Option, error) {
+	if !cfg.Enabled {
+		return make([]pubsub.Option, 0), nil
+	}
+
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	params := &pubsub.PeerScoreParams{
+		SkipAtomicValidation: true,
+		Topics:               make(map[string]*pubsub.TopicScoreParams),
+		TopicScoreCap:        cfg.TopicScoreCap,
+		AppSpecificScore: func(_ peer.ID) float64 {
+			return 0
+		},
+		IPColocationFactorWeight:    cfg.IPColocationFactorWeight,
+		IPColocationFactorThreshold: cfg.IPColocationFactorThreshold,
+		BehaviourPenaltyWeight:      cfg.BehaviourPenaltyWeight,
+		BehaviourPenaltyThreshold:   cfg.BehaviourPenaltyThreshold,
+		BehaviourPenaltyDecay:       cfg.BehaviourPenaltyDecay,
+		DecayInterval:               time.Duration(cfg.DecayIntervalInSec) * time.Second,
+		DecayToZero:                 cfg.DecayToZero,
+		RetainScore:                 time.Duration(cfg.RetainScoreInSec) * time.Second,
+	}
+
+	for _, topicCfg := range cfg.Topics {
+		params.Topics[topicCfg.Name] = &pubsub.TopicScoreParams{
+			SkipAtomicValidation:           true,
+			TopicWeight:                    topicCfg.TopicWeight,
+			TimeInMeshWeight:               topicCfg.TimeInMeshWeight,
+			TimeInMeshQuantum:              time.Duration(topicCfg.TimeInMeshQuantumInMs) * time.Millisecond,
+			TimeInMeshCap:                  topicCfg.TimeInMeshCap,
+			FirstMessageDeliveriesWeight:   topicCfg.FirstMessageDeliveriesWeight,
+			FirstMessageDeliveriesDecay:    topicCfg.FirstMessageDeliveriesDecay,
+			FirstMessageDeliveriesCap:      topicCfg.FirstMessageDeliveriesCap,
+			InvalidMessageDeliveriesWeight: topicCfg.InvalidMessageDeliveriesWeight,
+			InvalidMessageDeliveriesDecay:  topicCfg.InvalidMessageDeliveriesDecay,
+		}
+	}
+
+	thresholds := &pubsub.PeerScoreThresholds{
+		GossipThreshold:             cfg.GossipThreshold,
+		PublishThreshold:            cfg.PublishThreshold,
+		GraylistThreshold:           cfg.GraylistThreshold,
+		AcceptPXThreshold:           cfg.AcceptPXThreshold,
+		OpportunisticGraftThreshold: cfg.OpportunisticGraftThreshold,
+	}
+
+	inspectInterval := time.Duration(cfg.InspectIntervalInSec) * time.Second
+
+	// the inspect option must be provided after the peer score option
+	return []pubsub.Option{
+		pubsub.WithPeerScore(params, thresholds),
+		pubsub.WithPeerScoreInspect(pubsub.PeerScoreInspectFn(inspect), inspectInterval),
+	}, nil
+}
@@ -0,0 +1,175 @@
+package libp2p_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/mock"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func createPeerScoringConfig() config.PeerScoringConfig {
+	return config.PeerScoringConfig{
+		Enabled:                     true,
+		DecayIntervalInSec:          1,
+		DecayToZero:                 0.01,
+		RetainScoreInSec:            60,
+		IPColocationFactorWeight:    -1,
+		IPColocationFactorThreshold: 10,
+		BehaviourPenaltyWeight:      -1,
+		BehaviourPenaltyDecay:       0.9,
+		GossipThreshold:             -100,
+		PublishThreshold:            -200,
+		GraylistThreshold:           -300,
+		InspectIntervalInSec:        1,
+		Topics: []config.TopicScoringConfig{
+			{
+				Name:                           "topic",
+				TopicWeight:                    1,
+				TimeInMeshWeight:               0.01,
+				TimeInMeshQuantumInMs:          1000,
+				TimeInMeshCap:                  10,
+				FirstMessageDeliveriesWeight:   1,
+				FirstMessageDeliveriesDecay:    0.5,
+				FirstMessageDeliveriesCap:      100,
+				InvalidMessageDeliveriesWeight: -10,
+				InvalidMessageDeliveriesDecay:  0.5,
+			},
+		},
+	}
+}
+
+func TestCreatePeerScoreOptions(t *testing.T) {
+	t.Parallel()
+
+	inspect := func(scores map[peer.ID]float64) {}
+
+	t.Run("disabled should return empty options", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createPeerScoringConfig()
+		cfg.Enabled = false
+		options, err := libp2p.CreatePeerScoreOptions(cfg, inspect)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(options))
+	})
+	t.Run("invalid decay interval should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createPeerScoringConfig()
+		cfg.DecayIntervalInSec = 0
+		options, err := libp2p.CreatePeerScoreOptions(cfg, inspect)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, strings.Contains(err.Error(), "DecayIntervalInSec"))
+		assert.Nil(t, options)
+	})
+	t.Run("invalid inspect interval should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createPeerScoringConfig()
+		cfg.InspectIntervalInSec = 0
+		options, err := libp2p.CreatePeerScoreOptions(cfg, inspect)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, strings.Contains(err.Error(), "InspectIntervalInSec"))
+		assert.Nil(t, options)
+	})
+	t.Run("empty topic name should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createPeerScoringConfig()
+		cfg.Topics = append(cfg.Topics, config.TopicScoringConfig{})
+		options, err := libp2p.CreatePeerScoreOptions(cfg, inspect)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, strings.Contains(err.Error(), "empty topic name"))
+		assert.Nil(t, options)
+	})
+	t.Run("duplicated topic should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createPeerScoringConfig()
+		cfg.Topics = append(cfg.Topics, cfg.Topics[0])
+		options, err := libp2p.CreatePeerScoreOptions(cfg, inspect)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, strings.Contains(err.Error(), "duplicated topic"))
+		assert.Nil(t, options)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		options, err := libp2p.CreatePeerScoreOptions(createPeerScoringConfig(), inspect)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(options))
+	})
+}
+
+func TestNetworkMessenger_PeerScoring(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid thresholds should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockNetworkArgs()
+		args.P2pConfig.PeerScoring = createPeerScoringConfig()
+		args.P2pConfig.PeerScoring.GossipThreshold = 10
+
+		messenger, err := libp2p.NewNetworkMessenger(args)
+		assert.NotNil(t, err)
+		assert.Nil(t, messenger)
+	})
+	t.Run("scores should be fed into the peers rating handler", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockNetworkArgs()
+		args.P2pConfig.PeerScoring = createPeerScoringConfig()
+		chanScores := make(chan map[core.PeerID]float64, 100)
+		args.PeersRatingHandler = &mock.PeersRatingHandlerStub{
+			UpdateMeshScoresCalled: func(scores map[core.PeerID]float64) {
+				chanScores <- scores
+			},
+		}
+
+		messenger, err := libp2p.NewNetworkMessenger(args)
+		assert.Nil(t, err)
+		defer closeMessengers(messenger)
+
+		providedPid := peer.ID("provided pid")
+		messenger.UpdateMeshScores(map[peer.ID]float64{providedPid: -5})
+
+		select {
+		case scores := <-chanScores:
+			assert.Equal(t, map[core.PeerID]float64{core.PeerID(providedPid): -5}, scores)
+		case <-time.After(timeoutWaitResponses):
+			assert.Fail(t, "timeout while waiting for the mesh scores")
+		}
+	})
+	t.Run("peers rating handler without mesh scores support should not panic", func(t *testing.T) {
+		t.Parallel()
+
+		defer func() {
+			r := recover()
+			assert.Nil(t, r)
+		}()
+
+		args := createMockNetworkArgs()
+		args.P2pConfig.PeerScoring = createPeerScoringConfig()
+		// embedding the interface hides the UpdateMeshScores method of the stub
+		args.PeersRatingHandler = struct {
+			p2p.PeersRatingHandler
+		}{
+			PeersRatingHandler: &mock.PeersRatingHandlerStub{},
+		}
+
+		messenger, err := libp2p.NewNetworkMessenger(args)
+		assert.Nil(t, err)
+		defer closeMessengers(messenger)
+
+		messenger.UpdateMeshScores(map[peer.ID]float64{"provided pid": -5})
+	})
+}
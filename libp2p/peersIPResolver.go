@@ -0,0 +1,48 @@
+package libp2p
+
+import (
+	"net"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+var _ p2p.PeersIPResolver = (*peersIPResolver)(nil)
+
+// peersIPResolver tells the IP of a connected peer from the connections opened by the host. The direct connections
+// are preferred, for a peer reachable only through circuit relays the relay's IP is returned
+type peersIPResolver struct {
+	network network.Network
+}
+
+func newPeersIPResolver(netw network.Network) *peersIPResolver {
+	return &peersIPResolver{
+		network: netw,
+	}
+}
+
+// GetPeerIP returns the remote IP of the connections with the provided peer
+func (resolver *peersIPResolver) GetPeerIP(pid core.PeerID) (net.IP, bool) {
+	var relayIP net.IP
+	for _, conn := range resolver.network.ConnsToPeer(peer.ID(pid)) {
+		ip, found := remoteIP(conn)
+		if !found {
+			continue
+		}
+		if !isRelayedConnection(conn) {
+			return ip, true
+		}
+		if relayIP == nil {
+			relayIP = ip
+		}
+	}
+
+	return relayIP, relayIP != nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (resolver *peersIPResolver) IsInterfaceNil() bool {
+	return resolver == nil
+}
@@ -0,0 +1,59 @@
+package libp2p_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/mock"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeersIPResolver_GetPeerIP(t *testing.T) {
+	t.Parallel()
+
+	createNetwork := func(conns ...network.Conn) *mock.NetworkStub {
+		return &mock.NetworkStub{
+			ConnsToPeerCalled: func(p peer.ID) []network.Conn {
+				return conns
+			},
+		}
+	}
+
+	t.Run("no connections should not find the IP", func(t *testing.T) {
+		t.Parallel()
+
+		resolver := libp2p.NewPeersIPResolver(createNetwork())
+		ip, found := resolver.GetPeerIP("pid")
+		assert.False(t, found)
+		assert.Nil(t, ip)
+	})
+	t.Run("direct connection should be preferred", func(t *testing.T) {
+		t.Parallel()
+
+		resolver := libp2p.NewPeersIPResolver(createNetwork(
+			createConnStubWithRemoteAddress(relayedAddress),
+			createConnStubWithRemoteAddress(directAddress),
+		))
+		ip, found := resolver.GetPeerIP("pid")
+		assert.True(t, found)
+		assert.True(t, net.ParseIP("10.0.0.2").Equal(ip))
+	})
+	t.Run("only relayed connections should return the relay's IP", func(t *testing.T) {
+		t.Parallel()
+
+		resolver := libp2p.NewPeersIPResolver(createNetwork(createConnStubWithRemoteAddress(relayedAddress)))
+		ip, found := resolver.GetPeerIP("pid")
+		assert.True(t, found)
+		assert.True(t, net.ParseIP("10.0.0.1").Equal(ip))
+	})
+	t.Run("address without IP should not find the IP", func(t *testing.T) {
+		t.Parallel()
+
+		resolver := libp2p.NewPeersIPResolver(createNetwork(createConnStubWithRemoteAddress("/dns4/example.com/tcp/37373")))
+		_, found := resolver.GetPeerIP("pid")
+		assert.False(t, found)
+	})
+}
@@ -0,0 +1,131 @@
+package libp2p
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
+	"github.com/multiformats/go-multiaddr"
+)
+
+var _ p2p.PeersLatencyProvider = (*peersLatencyTracker)(nil)
+var _ network.Notifiee = (*peersLatencyTracker)(nil)
+
+const maxPingDuration = time.Second * 10
+
+// peersLatencyTracker pings the connected peers so the host's peerstore keeps an up to date latency EWMA for each of
+// them. The peers are pinged periodically and right after they connect. A peer is not pinged again while its previous
+// ping is still in flight so the number of ping go routines is bounded by the number of connected peers
+type peersLatencyTracker struct {
+	ctx             context.Context
+	host            host.Host
+	pingInterval    time.Duration
+	maxPingDuration time.Duration
+	mutPinging      sync.Mutex
+	pinging         map[peer.ID]struct{}
+}
+
+func newPeersLatencyTracker(ctx context.Context, h host.Host, pingInterval time.Duration) (*peersLatencyTracker, error) {
+	if pingInterval < maxPingDuration {
+		return nil, fmt.Errorf("%w, the ping interval should not be lower than %v", p2p.ErrInvalidValue, maxPingDuration)
+	}
+
+	tracker := &peersLatencyTracker{
+		ctx:             ctx,
+		host:            h,
+		pingInterval:    pingInterval,
+		maxPingDuration: maxPingDuration,
+		pinging:         make(map[peer.ID]struct{}),
+	}
+	go tracker.pingPeersPeriodically()
+
+	return tracker, nil
+}
+
+func (tracker *peersLatencyTracker) pingPeersPeriodically() {
+	ticker := time.NewTicker(tracker.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-tracker.ctx.Done():
+			log.Debug("closing peersLatencyTracker's go routine")
+			return
+		case <-ticker.C:
+			for _, pid := range tracker.host.Network().Peers() {
+				tracker.startPinging(pid)
+			}
+		}
+	}
+}
+
+// startPinging pings the provided peer on a new go routine, unless a ping for the same peer is still in flight
+func (tracker *peersLatencyTracker) startPinging(pid peer.ID) {
+	tracker.mutPinging.Lock()
+	_, isPinging := tracker.pinging[pid]
+	if !isPinging {
+		tracker.pinging[pid] = struct{}{}
+	}
+	tracker.mutPinging.Unlock()
+
+	if isPinging {
+		return
+	}
+
+	go func() {
+		tracker.pingPeer(pid)
+
+		tracker.mutPinging.Lock()
+		delete(tracker.pinging, pid)
+		tracker.mutPinging.Unlock()
+	}()
+}
+
+// pingPeer sends one ping to the provided peer, the libp2p ping protocol recording the round trip time in the
+// peerstore's latency EWMA
+func (tracker *peersLatencyTracker) pingPeer(pid peer.ID) {
+	ctx, cancel := context.WithTimeout(tracker.ctx, tracker.maxPingDuration)
+	defer cancel()
+
+	result, ok := <-ping.Ping(ctx, tracker.host, pid)
+	if ok && result.Error != nil {
+		log.Trace("peersLatencyTracker: ping failed", "pid", pid.String(), "error", result.Error.Error())
+	}
+}
+
+// GetPeerLatency returns the latency EWMA of the provided peer, if any was measured
+func (tracker *peersLatencyTracker) GetPeerLatency(pid core.PeerID) (time.Duration, bool) {
+	latency := tracker.host.Peerstore().LatencyEWMA(peer.ID(pid))
+
+	return latency, latency > 0
+}
+
+// Listen is called when network starts listening on an addr
+func (tracker *peersLatencyTracker) Listen(network.Network, multiaddr.Multiaddr) {}
+
+// ListenClose is called when network stops listening on an addr
+func (tracker *peersLatencyTracker) ListenClose(network.Network, multiaddr.Multiaddr) {}
+
+// Connected is called when a connection opened. The peer is pinged if it does not have a measured latency yet
+func (tracker *peersLatencyTracker) Connected(_ network.Network, conn network.Conn) {
+	pid := conn.RemotePeer()
+	_, isMeasured := tracker.GetPeerLatency(core.PeerID(pid))
+	if !isMeasured {
+		tracker.startPinging(pid)
+	}
+}
+
+// Disconnected is called when a connection closed
+func (tracker *peersLatencyTracker) Disconnected(network.Network, network.Conn) {}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (tracker *peersLatencyTracker) IsInterfaceNil() bool {
+	return tracker == nil
+}
@@ -0,0 +1,101 @@
+package libp2p_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p"
+	"github.com/libp2p/go-libp2p/core/network"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPeersLatencyTracker_InvalidPingIntervalShouldErr(t *testing.T) {
+	t.Parallel()
+
+	netw := mocknet.New()
+	h, err := netw.GenPeer()
+	require.Nil(t, err)
+
+	tracker, err := libp2p.NewPeersLatencyTracker(context.Background(), h, 0)
+	assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	assert.Nil(t, tracker)
+
+	tracker, err = libp2p.NewPeersLatencyTracker(context.Background(), h, time.Second*9)
+	assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	assert.Nil(t, tracker)
+}
+
+func TestPeersLatencyTracker_ConnectedPeerShouldBePinged(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	netw := mocknet.New()
+	h1, err := netw.GenPeer()
+	require.Nil(t, err)
+	h2, err := netw.GenPeer()
+	require.Nil(t, err)
+	_ = ping.NewPingService(h2)
+
+	tracker, err := libp2p.NewPeersLatencyTracker(ctx, h1, time.Hour)
+	require.Nil(t, err)
+	h1.Network().Notify(tracker)
+
+	_, found := tracker.GetPeerLatency(core.PeerID(h2.ID()))
+	assert.False(t, found)
+
+	_, err = netw.LinkPeers(h1.ID(), h2.ID())
+	require.Nil(t, err)
+	_, err = netw.ConnectPeers(h1.ID(), h2.ID())
+	require.Nil(t, err)
+
+	assert.Eventually(t, func() bool {
+		latency, isMeasured := tracker.GetPeerLatency(core.PeerID(h2.ID()))
+		return isMeasured && latency > 0
+	}, time.Second*5, time.Millisecond*10)
+}
+
+func TestPeersLatencyTracker_PeerWithPingInFlightShouldNotBePingedAgain(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	netw := mocknet.New()
+	h1, err := netw.GenPeer()
+	require.Nil(t, err)
+	h2, err := netw.GenPeer()
+	require.Nil(t, err)
+	numPings := uint32(0)
+	h2.SetStreamHandler(ping.ID, func(stream network.Stream) {
+		// never answers so the ping stays in flight until the context is done
+		atomic.AddUint32(&numPings, 1)
+	})
+
+	_, err = netw.LinkPeers(h1.ID(), h2.ID())
+	require.Nil(t, err)
+	_, err = netw.ConnectPeers(h1.ID(), h2.ID())
+	require.Nil(t, err)
+
+	tracker, err := libp2p.NewPeersLatencyTracker(ctx, h1, time.Hour)
+	require.Nil(t, err)
+
+	tracker.StartPinging(h2.ID())
+	assert.Eventually(t, func() bool {
+		return atomic.LoadUint32(&numPings) == 1
+	}, time.Second*5, time.Millisecond*10)
+
+	tracker.StartPinging(h2.ID())
+	tracker.StartPinging(h2.ID())
+	time.Sleep(time.Millisecond * 200)
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&numPings))
+}
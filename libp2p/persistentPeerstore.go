@@ -0,0 +1,361 @@
+package libp2p
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-storage/types"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/multiformats/go-multiaddr"
+)
+
+const (
+	peerstoreBatchDelayInSec = 2
+	peerstoreMaxBatchSize    = 100
+	peerstoreMaxOpenFiles    = 10
+)
+
+// peerRecord is the information saved for each identified peer
+type peerRecord struct {
+	LastSeen  int64    `json:"lastSeen"`
+	Addresses []string `json:"addresses"`
+	Protocols []string `json:"protocols"`
+}
+
+type argsPersistentPeerstore struct {
+	persister  types.Persister
+	peerstore  peerstore.Peerstore
+	recordTTL  time.Duration
+	maxPeers   int
+	gcInterval time.Duration
+}
+
+// persistentPeerstore saves in a persister the addresses, the protocols and the last time the identified peers were
+// seen so the recently good peers can be redialed after a restart. The records older than the TTL are removed
+// periodically and, when the maximum number of peers is reached, the least recently seen peers are removed first
+type persistentPeerstore struct {
+	mut            sync.Mutex
+	persister      types.Persister
+	peerstore      peerstore.Peerstore
+	records        map[peer.ID]*peerRecord
+	recordTTL      time.Duration
+	maxPeers       int
+	gcInterval     time.Duration
+	getTimeHandler func() time.Time
+}
+
+func newPersistentPeerstore(args argsPersistentPeerstore) (*persistentPeerstore, error) {
+	if check.IfNil(args.persister) {
+		return nil, p2p.ErrNilPersister
+	}
+	if args.peerstore == nil {
+		return nil, p2p.ErrNilPeerstore
+	}
+	if args.recordTTL <= 0 || args.gcInterval <= 0 {
+		return nil, fmt.Errorf("%w for the persistent peerstore durations", p2p.ErrInvalidDurationProvided)
+	}
+	if args.maxPeers <= 0 {
+		return nil, fmt.Errorf("%w for the persistent peerstore maximum number of peers", p2p.ErrInvalidValue)
+	}
+
+	pps := &persistentPeerstore{
+		persister:      args.persister,
+		peerstore:      args.peerstore,
+		records:        make(map[peer.ID]*peerRecord),
+		recordTTL:      args.recordTTL,
+		maxPeers:       args.maxPeers,
+		gcInterval:     args.gcInterval,
+		getTimeHandler: time.Now,
+	}
+	pps.loadRecords()
+
+	return pps, nil
+}
+
+func (pps *persistentPeerstore) loadRecords() {
+	pps.mut.Lock()
+	defer pps.mut.Unlock()
+
+	invalidKeys := make([][]byte, 0)
+	pps.persister.RangeKeys(func(key []byte, val []byte) bool {
+		pid, err := peer.IDFromBytes(key)
+		if err != nil {
+			invalidKeys = append(invalidKeys, key)
+			return true
+		}
+
+		record := &peerRecord{}
+		err = json.Unmarshal(val, record)
+		if err != nil {
+			invalidKeys = append(invalidKeys, key)
+			return true
+		}
+
+		pps.records[pid] = record
+		return true
+	})
+
+	for _, key := range invalidKeys {
+		log.LogIfError(pps.persister.Remove(key))
+	}
+
+	numExpired := pps.removeExpiredRecords()
+	numEvicted := pps.evictOverCapRecords()
+
+	log.Debug("persistent peerstore loaded",
+		"num records", len(pps.records),
+		"num invalid", len(invalidKeys),
+		"num expired", numExpired,
+		"num evicted", numEvicted,
+	)
+}
+
+// startWatching saves the records of the peers as they are identified or disconnected and periodically removes the
+// expired records until the context is done
+func (pps *persistentPeerstore) startWatching(ctx context.Context, bus event.Bus) error {
+	subscription, err := bus.Subscribe([]interface{}{
+		new(event.EvtPeerIdentificationCompleted),
+		new(event.EvtPeerConnectednessChanged),
+	})
+	if err != nil {
+		return err
+	}
+
+	go pps.processEvents(ctx, subscription)
+
+	return nil
+}
+
+func (pps *persistentPeerstore) processEvents(ctx context.Context, subscription event.Subscription) {
+	// the ticker is created once so the frequent events do not postpone the removal of the expired records
+	gcTicker := time.NewTicker(pps.gcInterval)
+	defer func() {
+		gcTicker.Stop()
+		_ = subscription.Close()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Debug("closing persistentPeerstore's go routine")
+			return
+		case <-gcTicker.C:
+			pps.removeExpired()
+		case evt, ok := <-subscription.Out():
+			if !ok {
+				return
+			}
+
+			switch typedEvent := evt.(type) {
+			case event.EvtPeerIdentificationCompleted:
+				pps.savePeer(typedEvent.Peer)
+			case event.EvtPeerConnectednessChanged:
+				if typedEvent.Connectedness == network.NotConnected {
+					pps.updateKnownPeer(typedEvent.Peer)
+				}
+			}
+		}
+	}
+}
+
+// savePeer creates or updates the record of the provided peer with its current addresses and protocols
+func (pps *persistentPeerstore) savePeer(pid peer.ID) {
+	pps.mut.Lock()
+	defer pps.mut.Unlock()
+
+	pps.savePeerUnprotected(pid)
+}
+
+// updateKnownPeer updates the record of the provided peer only if the peer was already saved
+func (pps *persistentPeerstore) updateKnownPeer(pid peer.ID) {
+	pps.mut.Lock()
+	defer pps.mut.Unlock()
+
+	_, found := pps.records[pid]
+	if !found {
+		return
+	}
+
+	pps.savePeerUnprotected(pid)
+}
+
+// updateKnownPeers updates the records of the provided peers that were already saved
+func (pps *persistentPeerstore) updateKnownPeers(pids []peer.ID) {
+	for _, pid := range pids {
+		pps.updateKnownPeer(pid)
+	}
+}
+
+func (pps *persistentPeerstore) savePeerUnprotected(pid peer.ID) {
+	record, found := pps.records[pid]
+	if !found {
+		record = &peerRecord{}
+	}
+
+	addresses := pps.peerstore.Addrs(pid)
+	if len(addresses) > 0 {
+		record.Addresses = make([]string, 0, len(addresses))
+		for _, address := range addresses {
+			record.Addresses = append(record.Addresses, address.String())
+		}
+	}
+	protocols, err := pps.peerstore.GetProtocols(pid)
+	if err == nil && len(protocols) > 0 {
+		record.Protocols = make([]string, 0, len(protocols))
+		for _, protocol := range protocols {
+			record.Protocols = append(record.Protocols, string(protocol))
+		}
+	}
+	if len(record.Addresses) == 0 {
+		// a peer without addresses can not be redialed
+		return
+	}
+
+	record.LastSeen = pps.getTimeHandler().Unix()
+	pps.records[pid] = record
+
+	buff, err := json.Marshal(record)
+	if err != nil {
+		log.Warn("persistentPeerstore.savePeer: marshal record", "pid", pid.String(), "error", err)
+		return
+	}
+	err = pps.persister.Put([]byte(pid), buff)
+	if err != nil {
+		log.Debug("persistentPeerstore.savePeer: put record", "pid", pid.String(), "error", err)
+	}
+
+	// a save adds at most one record, so at most the least recently seen one needs to be evicted
+	if len(pps.records) > pps.maxPeers {
+		pps.evictLeastRecentlySeenRecord()
+	}
+}
+
+func (pps *persistentPeerstore) removeExpired() {
+	pps.mut.Lock()
+	numExpired := pps.removeExpiredRecords()
+	pps.mut.Unlock()
+
+	if numExpired > 0 {
+		log.Debug("persistentPeerstore: removed expired records", "num expired", numExpired)
+	}
+}
+
+func (pps *persistentPeerstore) removeExpiredRecords() int {
+	oldestAllowed := pps.getTimeHandler().Add(-pps.recordTTL).Unix()
+
+	numExpired := 0
+	for pid, record := range pps.records {
+		if record.LastSeen >= oldestAllowed {
+			continue
+		}
+
+		pps.removeRecord(pid)
+		numExpired++
+	}
+
+	return numExpired
+}
+
+func (pps *persistentPeerstore) evictOverCapRecords() int {
+	numOverCap := len(pps.records) - pps.maxPeers
+	if numOverCap <= 0 {
+		return 0
+	}
+
+	pids := pps.sortedPeersUnprotected()
+	for _, pid := range pids[len(pids)-numOverCap:] {
+		pps.removeRecord(pid)
+	}
+
+	return numOverCap
+}
+
+func (pps *persistentPeerstore) evictLeastRecentlySeenRecord() {
+	var oldestPid peer.ID
+	var oldestRecord *peerRecord
+	for pid, record := range pps.records {
+		if oldestRecord == nil || record.LastSeen < oldestRecord.LastSeen {
+			oldestPid = pid
+			oldestRecord = record
+		}
+	}
+	if oldestRecord == nil {
+		return
+	}
+
+	pps.removeRecord(oldestPid)
+}
+
+func (pps *persistentPeerstore) removeRecord(pid peer.ID) {
+	delete(pps.records, pid)
+	log.LogIfError(pps.persister.Remove([]byte(pid)))
+}
+
+// sortedPeersUnprotected returns the saved peers, most recently seen first
+func (pps *persistentPeerstore) sortedPeersUnprotected() []peer.ID {
+	pids := make([]peer.ID, 0, len(pps.records))
+	for pid := range pps.records {
+		pids = append(pids, pid)
+	}
+	sort.Slice(pids, func(i, j int) bool {
+		return pps.records[pids[i]].LastSeen > pps.records[pids[j]].LastSeen
+	})
+
+	return pids
+}
+
+// RecentPeers returns at most maxPeers saved peers that were seen within the TTL, most recently seen first
+func (pps *persistentPeerstore) RecentPeers(maxPeers int) []peer.AddrInfo {
+	pps.mut.Lock()
+	defer pps.mut.Unlock()
+
+	oldestAllowed := pps.getTimeHandler().Add(-pps.recordTTL).Unix()
+	peersInfo := make([]peer.AddrInfo, 0, maxPeers)
+	for _, pid := range pps.sortedPeersUnprotected() {
+		if len(peersInfo) >= maxPeers {
+			break
+		}
+
+		record := pps.records[pid]
+		if record.LastSeen < oldestAllowed {
+			break
+		}
+
+		addresses := make([]multiaddr.Multiaddr, 0, len(record.Addresses))
+		for _, address := range record.Addresses {
+			maddr, err := multiaddr.NewMultiaddr(address)
+			if err != nil {
+				continue
+			}
+
+			addresses = append(addresses, maddr)
+		}
+		if len(addresses) == 0 {
+			continue
+		}
+
+		peersInfo = append(peersInfo, peer.AddrInfo{
+			ID:    pid,
+			Addrs: addresses,
+		})
+	}
+
+	return peersInfo
+}
+
+// Close closes the underlying persister
+func (pps *persistentPeerstore) Close() error {
+	pps.mut.Lock()
+	defer pps.mut.Unlock()
+
+	return pps.persister.Close()
+}
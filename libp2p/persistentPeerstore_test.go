@@ -0,0 +1,211 @@
+package libp2p_test
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p"
+	"github.com/TerraDharitri/drt-go-chain-storage/memorydb"
+	libp2pCrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/libp2p/go-libp2p/p2p/host/eventbus"
+	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoremem"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRecordTTL = time.Hour
+
+func createPersistentPeerstorePid() peer.ID {
+	prvKey, _, _ := libp2pCrypto.GenerateSecp256k1Key(rand.Reader)
+	pid, _ := peer.IDFromPublicKey(prvKey.GetPublic())
+
+	return pid
+}
+
+func addPeerToPeerstore(t *testing.T, ps peerstore.Peerstore, address string) peer.ID {
+	pid := createPersistentPeerstorePid()
+	ps.AddAddr(pid, multiaddr.StringCast(address), peerstore.PermanentAddrTTL)
+	require.Nil(t, ps.SetProtocols(pid, protocol.ID("/drt/kad/1.0.0")))
+
+	return pid
+}
+
+func TestNewPersistentPeerstore(t *testing.T) {
+	t.Parallel()
+
+	ps, _ := pstoremem.NewPeerstore()
+
+	pps, err := libp2p.NewPersistentPeerstore(nil, ps, testRecordTTL, 10, time.Minute)
+	assert.Equal(t, p2p.ErrNilPersister, err)
+	assert.Nil(t, pps)
+
+	pps, err = libp2p.NewPersistentPeerstore(memorydb.New(), nil, testRecordTTL, 10, time.Minute)
+	assert.Equal(t, p2p.ErrNilPeerstore, err)
+	assert.Nil(t, pps)
+
+	pps, err = libp2p.NewPersistentPeerstore(memorydb.New(), ps, 0, 10, time.Minute)
+	assert.True(t, errors.Is(err, p2p.ErrInvalidDurationProvided))
+	assert.Nil(t, pps)
+
+	pps, err = libp2p.NewPersistentPeerstore(memorydb.New(), ps, testRecordTTL, 0, time.Minute)
+	assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	assert.Nil(t, pps)
+
+	pps, err = libp2p.NewPersistentPeerstore(memorydb.New(), ps, testRecordTTL, 10, time.Minute)
+	assert.Nil(t, err)
+	assert.NotNil(t, pps)
+}
+
+func TestPersistentPeerstore_RecordsShouldSurviveRestarts(t *testing.T) {
+	t.Parallel()
+
+	persister := memorydb.New()
+	ps, _ := pstoremem.NewPeerstore()
+	pid1 := addPeerToPeerstore(t, ps, "/ip4/10.0.0.1/tcp/37373")
+	pid2 := addPeerToPeerstore(t, ps, "/ip4/10.0.0.2/tcp/37373")
+	pidWithoutAddresses := createPersistentPeerstorePid()
+	_ = persister.Put([]byte("invalid key"), []byte("invalid record"))
+
+	// the records loaded at construction are checked against the current time
+	currentTime := time.Now()
+	pps, _ := libp2p.NewPersistentPeerstore(persister, ps, testRecordTTL, 10, time.Minute)
+	pps.SetTimeHandler(func() time.Time {
+		return currentTime
+	})
+	assert.Equal(t, 0, pps.NumRecords())
+
+	pps.SavePeer(pid1)
+	currentTime = currentTime.Add(time.Second)
+	pps.SavePeer(pid2)
+	pps.SavePeer(pidWithoutAddresses)
+	assert.Equal(t, 2, pps.NumRecords())
+
+	otherPs, _ := pstoremem.NewPeerstore()
+	restartedPps, _ := libp2p.NewPersistentPeerstore(persister, otherPs, testRecordTTL, 10, time.Minute)
+	restartedPps.SetTimeHandler(func() time.Time {
+		return currentTime
+	})
+	assert.Equal(t, 2, restartedPps.NumRecords())
+
+	peersInfo := restartedPps.RecentPeers(10)
+	require.Equal(t, 2, len(peersInfo))
+	assert.Equal(t, pid2, peersInfo[0].ID)
+	assert.Equal(t, "/ip4/10.0.0.2/tcp/37373", peersInfo[0].Addrs[0].String())
+	assert.Equal(t, pid1, peersInfo[1].ID)
+
+	peersInfo = restartedPps.RecentPeers(1)
+	require.Equal(t, 1, len(peersInfo))
+	assert.Equal(t, pid2, peersInfo[0].ID)
+
+	// the invalid record was removed from the persister
+	numKeys := 0
+	persister.RangeKeys(func(_ []byte, _ []byte) bool {
+		numKeys++
+		return true
+	})
+	assert.Equal(t, 2, numKeys)
+}
+
+func TestPersistentPeerstore_ExpiredRecordsShouldBeRemoved(t *testing.T) {
+	t.Parallel()
+
+	persister := memorydb.New()
+	ps, _ := pstoremem.NewPeerstore()
+	pid1 := addPeerToPeerstore(t, ps, "/ip4/10.0.0.1/tcp/37373")
+	pid2 := addPeerToPeerstore(t, ps, "/ip4/10.0.0.2/tcp/37373")
+
+	currentTime := time.Unix(1000000, 0)
+	pps, _ := libp2p.NewPersistentPeerstore(persister, ps, testRecordTTL, 10, time.Minute)
+	pps.SetTimeHandler(func() time.Time {
+		return currentTime
+	})
+	pps.SavePeer(pid1)
+	currentTime = currentTime.Add(time.Minute)
+	pps.SavePeer(pid2)
+
+	currentTime = currentTime.Add(testRecordTTL - time.Second)
+	peersInfo := pps.RecentPeers(10)
+	require.Equal(t, 1, len(peersInfo))
+	assert.Equal(t, pid2, peersInfo[0].ID)
+
+	pps.RemoveExpired()
+	assert.Equal(t, 1, pps.NumRecords())
+
+	currentTime = currentTime.Add(time.Minute)
+	pps.RemoveExpired()
+	assert.Equal(t, 0, pps.NumRecords())
+	assert.Equal(t, 0, len(pps.RecentPeers(10)))
+}
+
+func TestPersistentPeerstore_ShouldKeepTheMostRecentlySeenPeersUnderTheCap(t *testing.T) {
+	t.Parallel()
+
+	persister := memorydb.New()
+	ps, _ := pstoremem.NewPeerstore()
+	pid1 := addPeerToPeerstore(t, ps, "/ip4/10.0.0.1/tcp/37373")
+	pid2 := addPeerToPeerstore(t, ps, "/ip4/10.0.0.2/tcp/37373")
+	pid3 := addPeerToPeerstore(t, ps, "/ip4/10.0.0.3/tcp/37373")
+
+	currentTime := time.Unix(1000000, 0)
+	pps, _ := libp2p.NewPersistentPeerstore(persister, ps, testRecordTTL, 2, time.Minute)
+	pps.SetTimeHandler(func() time.Time {
+		return currentTime
+	})
+	for _, pid := range []peer.ID{pid1, pid2, pid3} {
+		pps.SavePeer(pid)
+		currentTime = currentTime.Add(time.Second)
+	}
+
+	// updating an already saved peer makes it the most recently seen one
+	pps.UpdateKnownPeers([]peer.ID{pid2, createPersistentPeerstorePid()})
+	assert.Equal(t, 2, pps.NumRecords())
+
+	peersInfo := pps.RecentPeers(10)
+	require.Equal(t, 2, len(peersInfo))
+	assert.Equal(t, pid2, peersInfo[0].ID)
+	assert.Equal(t, pid3, peersInfo[1].ID)
+
+	_, err := persister.Get([]byte(pid1))
+	assert.NotNil(t, err)
+}
+
+func TestPersistentPeerstore_ShouldSaveThePeersOnIdentificationAndDisconnection(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ps, _ := pstoremem.NewPeerstore()
+	pidIdentified := addPeerToPeerstore(t, ps, "/ip4/10.0.0.1/tcp/37373")
+	pidNotIdentified := addPeerToPeerstore(t, ps, "/ip4/10.0.0.2/tcp/37373")
+
+	bus := eventbus.NewBus()
+	identificationEmitter, err := bus.Emitter(new(event.EvtPeerIdentificationCompleted))
+	require.Nil(t, err)
+	connectednessEmitter, err := bus.Emitter(new(event.EvtPeerConnectednessChanged))
+	require.Nil(t, err)
+
+	pps, _ := libp2p.NewPersistentPeerstore(memorydb.New(), ps, testRecordTTL, 10, time.Minute)
+	err = pps.StartWatching(ctx, bus)
+	require.Nil(t, err)
+
+	_ = connectednessEmitter.Emit(event.EvtPeerConnectednessChanged{Peer: pidNotIdentified, Connectedness: network.NotConnected})
+	_ = identificationEmitter.Emit(event.EvtPeerIdentificationCompleted{Peer: pidIdentified})
+	assert.Eventually(t, func() bool {
+		return pps.NumRecords() == 1
+	}, time.Second, time.Millisecond*10)
+
+	peersInfo := pps.RecentPeers(10)
+	require.Equal(t, 1, len(peersInfo))
+	assert.Equal(t, pidIdentified, peersInfo[0].ID)
+}
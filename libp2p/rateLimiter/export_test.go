@@ -0,0 +1,23 @@
+package rateLimiter
+
+import (
+	"time"
+)
+
+const SweepInterval = sweepInterval
+
+// SetTimeHandler -
+func (limiter *peerTopicRateLimiter) SetTimeHandler(handler func() time.Time) {
+	limiter.mut.Lock()
+	limiter.getTimeHandler = handler
+	limiter.lastSweep = handler()
+	limiter.mut.Unlock()
+}
+
+// NumBuckets -
+func (limiter *peerTopicRateLimiter) NumBuckets() int {
+	limiter.mut.Lock()
+	defer limiter.mut.Unlock()
+
+	return len(limiter.buckets)
+}
@@ -0,0 +1,187 @@
+package rateLimiter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	logger "github.com/TerraDharitri/drt-go-chain-logger"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+)
+
+var log = logger.GetOrCreate("p2p/libp2p/ratelimiter")
+
+const sweepInterval = time.Minute
+
+type limit struct {
+	messagesPerSecond float64
+	burst             float64
+}
+
+type tokenBucket struct {
+	tokens     float64
+	lastUpdate time.Time
+	limit      limit
+}
+
+type bucketKey struct {
+	pid   core.PeerID
+	topic string
+}
+
+type violations struct {
+	num         uint32
+	windowStart time.Time
+}
+
+type peerTopicRateLimiter struct {
+	mut              sync.Mutex
+	defaultLimit     limit
+	topicLimits      map[string]limit
+	maxViolations    uint32
+	violationsWindow time.Duration
+	buckets          map[bucketKey]*tokenBucket
+	violations       map[core.PeerID]*violations
+	lastSweep        time.Time
+	getTimeHandler   func() time.Time
+}
+
+// NewPeerTopicRateLimiter creates a token bucket rate limiter keyed by (peer, topic)
+func NewPeerTopicRateLimiter(cfg config.InboundRateLimiterConfig) (*peerTopicRateLimiter, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	topicLimits := make(map[string]limit, len(cfg.Topics))
+	for _, topicCfg := range cfg.Topics {
+		topicLimits[topicCfg.Name] = limit{
+			messagesPerSecond: float64(topicCfg.MessagesPerSecond),
+			burst:             float64(topicCfg.Burst),
+		}
+	}
+
+	return &peerTopicRateLimiter{
+		defaultLimit: limit{
+			messagesPerSecond: float64(cfg.DefaultMessagesPerSecond),
+			burst:             float64(cfg.DefaultBurst),
+		},
+		topicLimits:      topicLimits,
+		maxViolations:    cfg.MaxViolations,
+		violationsWindow: time.Duration(cfg.ViolationsWindowInSec) * time.Second,
+		buckets:          make(map[bucketKey]*tokenBucket),
+		violations:       make(map[core.PeerID]*violations),
+		lastSweep:        time.Now(),
+		getTimeHandler:   time.Now,
+	}, nil
+}
+
+// Allow returns true if the provided peer can send one more message on the provided topic. The second returned
+// value is true if the peer exceeded the limits more than the maximum violations number in the violations window
+// and should be denied
+func (limiter *peerTopicRateLimiter) Allow(pid core.PeerID, topic string) (bool, bool) {
+	limiter.mut.Lock()
+	defer limiter.mut.Unlock()
+
+	now := limiter.getTimeHandler()
+	limiter.sweepIfNeeded(now)
+
+	topicLimit, found := limiter.topicLimits[topic]
+	if !found {
+		topicLimit = limiter.defaultLimit
+	}
+	if topicLimit.messagesPerSecond == 0 {
+		return true, false
+	}
+
+	key := bucketKey{
+		pid:   pid,
+		topic: topic,
+	}
+	bucket, found := limiter.buckets[key]
+	if !found {
+		bucket = &tokenBucket{
+			tokens:     topicLimit.burst,
+			lastUpdate: now,
+			limit:      topicLimit,
+		}
+		limiter.buckets[key] = bucket
+	}
+
+	bucket.refill(now)
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, false
+	}
+
+	return false, limiter.addViolation(pid, now)
+}
+
+func (bucket *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(bucket.lastUpdate).Seconds()
+	bucket.lastUpdate = now
+	if elapsed <= 0 {
+		return
+	}
+
+	bucket.tokens += elapsed * bucket.limit.messagesPerSecond
+	if bucket.tokens > bucket.limit.burst {
+		bucket.tokens = bucket.limit.burst
+	}
+}
+
+func (bucket *tokenBucket) isFull(now time.Time) bool {
+	elapsed := now.Sub(bucket.lastUpdate).Seconds()
+	return bucket.tokens+elapsed*bucket.limit.messagesPerSecond >= bucket.limit.burst
+}
+
+func (limiter *peerTopicRateLimiter) addViolation(pid core.PeerID, now time.Time) bool {
+	if limiter.maxViolations == 0 {
+		return false
+	}
+
+	v, found := limiter.violations[pid]
+	if !found || now.Sub(v.windowStart) > limiter.violationsWindow {
+		v = &violations{
+			windowStart: now,
+		}
+		limiter.violations[pid] = v
+	}
+
+	v.num++
+	if v.num < limiter.maxViolations {
+		return false
+	}
+
+	delete(limiter.violations, pid)
+	log.Debug("peer exceeded the inbound rate limits too many times",
+		"pid", pid.Pretty(),
+		"violations", limiter.maxViolations,
+	)
+
+	return true
+}
+
+// sweepIfNeeded removes the full buckets and the expired violations as they hold no information
+func (limiter *peerTopicRateLimiter) sweepIfNeeded(now time.Time) {
+	if now.Sub(limiter.lastSweep) < sweepInterval {
+		return
+	}
+	limiter.lastSweep = now
+
+	for key, bucket := range limiter.buckets {
+		if bucket.isFull(now) {
+			delete(limiter.buckets, key)
+		}
+	}
+	for pid, v := range limiter.violations {
+		if now.Sub(v.windowStart) > limiter.violationsWindow {
+			delete(limiter.violations, pid)
+		}
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (limiter *peerTopicRateLimiter) IsInterfaceNil() bool {
+	return limiter == nil
+}
@@ -0,0 +1,224 @@
+package rateLimiter_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p/rateLimiter"
+	"github.com/stretchr/testify/assert"
+)
+
+const limitedTopic = "limited topic"
+
+func createMockConfig() config.InboundRateLimiterConfig {
+	return config.InboundRateLimiterConfig{
+		Enabled:                  true,
+		DefaultMessagesPerSecond: 0,
+		DefaultBurst:             0,
+		Topics: []config.TopicRateLimitConfig{
+			{
+				Name:              limitedTopic,
+				MessagesPerSecond: 2,
+				Burst:             4,
+			},
+		},
+		MaxViolations:         3,
+		ViolationsWindowInSec: 10,
+		BanDurationInSec:      60,
+	}
+}
+
+func TestNewPeerTopicRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default rate without burst should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createMockConfig()
+		cfg.DefaultMessagesPerSecond = 10
+		limiter, err := rateLimiter.NewPeerTopicRateLimiter(cfg)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, check.IfNil(limiter))
+	})
+	t.Run("empty topic name should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createMockConfig()
+		cfg.Topics[0].Name = ""
+		limiter, err := rateLimiter.NewPeerTopicRateLimiter(cfg)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, check.IfNil(limiter))
+	})
+	t.Run("invalid topic limits should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createMockConfig()
+		cfg.Topics[0].Burst = 0
+		limiter, err := rateLimiter.NewPeerTopicRateLimiter(cfg)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, check.IfNil(limiter))
+	})
+	t.Run("max violations without window should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createMockConfig()
+		cfg.ViolationsWindowInSec = 0
+		limiter, err := rateLimiter.NewPeerTopicRateLimiter(cfg)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, check.IfNil(limiter))
+	})
+	t.Run("max violations without ban duration should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createMockConfig()
+		cfg.BanDurationInSec = 0
+		limiter, err := rateLimiter.NewPeerTopicRateLimiter(cfg)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, check.IfNil(limiter))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		limiter, err := rateLimiter.NewPeerTopicRateLimiter(createMockConfig())
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(limiter))
+	})
+}
+
+func TestPeerTopicRateLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("topic without limits should allow", func(t *testing.T) {
+		t.Parallel()
+
+		limiter, _ := rateLimiter.NewPeerTopicRateLimiter(createMockConfig())
+		for i := 0; i < 100; i++ {
+			isAllowed, shouldDeny := limiter.Allow("pid", "other topic")
+			assert.True(t, isAllowed)
+			assert.False(t, shouldDeny)
+		}
+		assert.Equal(t, 0, limiter.NumBuckets())
+	})
+	t.Run("burst should be allowed then the tokens should refill", func(t *testing.T) {
+		t.Parallel()
+
+		limiter, _ := rateLimiter.NewPeerTopicRateLimiter(createMockConfig())
+		currentTime := time.Now()
+		limiter.SetTimeHandler(func() time.Time {
+			return currentTime
+		})
+
+		for i := 0; i < 4; i++ {
+			isAllowed, _ := limiter.Allow("pid", limitedTopic)
+			assert.True(t, isAllowed)
+		}
+		isAllowed, shouldDeny := limiter.Allow("pid", limitedTopic)
+		assert.False(t, isAllowed)
+		assert.False(t, shouldDeny)
+
+		// other peers have their own buckets
+		isAllowed, _ = limiter.Allow("other pid", limitedTopic)
+		assert.True(t, isAllowed)
+
+		currentTime = currentTime.Add(time.Second)
+		isAllowed, _ = limiter.Allow("pid", limitedTopic)
+		assert.True(t, isAllowed)
+		isAllowed, _ = limiter.Allow("pid", limitedTopic)
+		assert.True(t, isAllowed)
+		isAllowed, _ = limiter.Allow("pid", limitedTopic)
+		assert.False(t, isAllowed)
+	})
+	t.Run("default limits should apply on the topics not defined", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createMockConfig()
+		cfg.DefaultMessagesPerSecond = 1
+		cfg.DefaultBurst = 1
+		limiter, _ := rateLimiter.NewPeerTopicRateLimiter(cfg)
+
+		isAllowed, _ := limiter.Allow("pid", "other topic")
+		assert.True(t, isAllowed)
+		isAllowed, _ = limiter.Allow("pid", "other topic")
+		assert.False(t, isAllowed)
+	})
+	t.Run("peer and topic pairs with the same concatenation should not share a bucket", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createMockConfig()
+		cfg.DefaultMessagesPerSecond = 1
+		cfg.DefaultBurst = 1
+		limiter, _ := rateLimiter.NewPeerTopicRateLimiter(cfg)
+
+		isAllowed, _ := limiter.Allow("pid", "topic")
+		assert.True(t, isAllowed)
+		isAllowed, _ = limiter.Allow("pidt", "opic")
+		assert.True(t, isAllowed)
+		assert.Equal(t, 2, limiter.NumBuckets())
+	})
+	t.Run("repeated violations should request denial", func(t *testing.T) {
+		t.Parallel()
+
+		limiter, _ := rateLimiter.NewPeerTopicRateLimiter(createMockConfig())
+		currentTime := time.Now()
+		limiter.SetTimeHandler(func() time.Time {
+			return currentTime
+		})
+
+		for i := 0; i < 4; i++ {
+			_, _ = limiter.Allow("pid", limitedTopic)
+		}
+		_, shouldDeny := limiter.Allow("pid", limitedTopic)
+		assert.False(t, shouldDeny)
+		_, shouldDeny = limiter.Allow("pid", limitedTopic)
+		assert.False(t, shouldDeny)
+		_, shouldDeny = limiter.Allow("pid", limitedTopic)
+		assert.True(t, shouldDeny)
+
+		// the violations counter is reset after the denial request
+		_, shouldDeny = limiter.Allow("pid", limitedTopic)
+		assert.False(t, shouldDeny)
+	})
+	t.Run("violations outside the window should not request denial", func(t *testing.T) {
+		t.Parallel()
+
+		limiter, _ := rateLimiter.NewPeerTopicRateLimiter(createMockConfig())
+		currentTime := time.Now()
+		limiter.SetTimeHandler(func() time.Time {
+			return currentTime
+		})
+
+		for i := 0; i < 4; i++ {
+			_, _ = limiter.Allow("pid", limitedTopic)
+		}
+		_, _ = limiter.Allow("pid", limitedTopic)
+		_, _ = limiter.Allow("pid", limitedTopic)
+
+		currentTime = currentTime.Add(time.Second * 11)
+		for i := 0; i < 4; i++ {
+			_, _ = limiter.Allow("pid", limitedTopic)
+		}
+		_, shouldDeny := limiter.Allow("pid", limitedTopic)
+		assert.False(t, shouldDeny)
+	})
+	t.Run("full buckets should be swept", func(t *testing.T) {
+		t.Parallel()
+
+		limiter, _ := rateLimiter.NewPeerTopicRateLimiter(createMockConfig())
+		currentTime := time.Now()
+		limiter.SetTimeHandler(func() time.Time {
+			return currentTime
+		})
+
+		_, _ = limiter.Allow("pid1", limitedTopic)
+		_, _ = limiter.Allow("pid2", limitedTopic)
+		assert.Equal(t, 2, limiter.NumBuckets())
+
+		currentTime = currentTime.Add(rateLimiter.SweepInterval)
+		_, _ = limiter.Allow("pid3", limitedTopic)
+		assert.Equal(t, 1, limiter.NumBuckets())
+	})
+}
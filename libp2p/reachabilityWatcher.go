@@ -0,0 +1,86 @@
+package libp2p
+
+import (
+	"context"
+	"sync/atomic"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/network"
+)
+
+// reachabilityWatcher keeps the last reachability detected by AutoNAT and forwards its changes as network events
+type reachabilityWatcher struct {
+	reachability   uint32
+	eventsNotifier p2p.EventsNotifier
+}
+
+func newReachabilityWatcher(
+	ctx context.Context,
+	bus event.Bus,
+	eventsNotifier p2p.EventsNotifier,
+) (*reachabilityWatcher, error) {
+	subscription, err := bus.Subscribe(new(event.EvtLocalReachabilityChanged))
+	if err != nil {
+		return nil, err
+	}
+
+	watcher := &reachabilityWatcher{
+		eventsNotifier: eventsNotifier,
+	}
+	go watcher.processEvents(ctx, subscription)
+
+	return watcher, nil
+}
+
+func (watcher *reachabilityWatcher) processEvents(ctx context.Context, subscription event.Subscription) {
+	defer func() {
+		_ = subscription.Close()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Debug("closing reachabilityWatcher's go routine")
+			return
+		case evt, ok := <-subscription.Out():
+			if !ok {
+				return
+			}
+
+			reachabilityChanged, isReachabilityEvent := evt.(event.EvtLocalReachabilityChanged)
+			if isReachabilityEvent {
+				watcher.setReachability(convertReachability(reachabilityChanged.Reachability))
+			}
+		}
+	}
+}
+
+func (watcher *reachabilityWatcher) setReachability(reachability p2p.Reachability) {
+	oldReachability := p2p.Reachability(atomic.SwapUint32(&watcher.reachability, uint32(reachability)))
+	if oldReachability == reachability {
+		return
+	}
+
+	log.Debug("node's reachability changed", "old", oldReachability.String(), "new", reachability.String())
+	watcher.eventsNotifier.NotifyEvent(p2p.Event{
+		Type:         p2p.ReachabilityChangedEvent,
+		Reachability: reachability,
+	})
+}
+
+// Reachability returns the last reachability detected by AutoNAT
+func (watcher *reachabilityWatcher) Reachability() p2p.Reachability {
+	return p2p.Reachability(atomic.LoadUint32(&watcher.reachability))
+}
+
+func convertReachability(reachability network.Reachability) p2p.Reachability {
+	switch reachability {
+	case network.ReachabilityPublic:
+		return p2p.ReachabilityPublic
+	case network.ReachabilityPrivate:
+		return p2p.ReachabilityPrivate
+	default:
+		return p2p.ReachabilityUnknown
+	}
+}
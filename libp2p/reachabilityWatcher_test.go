@@ -0,0 +1,64 @@
+package libp2p_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/mock"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/p2p/host/eventbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReachabilityWatcher_ShouldTrackTheReachabilityChanges(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mutEvents := sync.Mutex{}
+	events := make([]p2p.Event, 0)
+	notifier := &mock.EventsNotifierStub{
+		NotifyEventCalled: func(event p2p.Event) {
+			mutEvents.Lock()
+			events = append(events, event)
+			mutEvents.Unlock()
+		},
+	}
+	getNumEvents := func() int {
+		mutEvents.Lock()
+		defer mutEvents.Unlock()
+
+		return len(events)
+	}
+
+	bus := eventbus.NewBus()
+	emitter, err := bus.Emitter(new(event.EvtLocalReachabilityChanged), eventbus.Stateful)
+	require.Nil(t, err)
+
+	watcher, err := libp2p.NewReachabilityWatcher(ctx, bus, notifier)
+	require.Nil(t, err)
+	assert.Equal(t, p2p.ReachabilityUnknown, watcher.Reachability())
+
+	_ = emitter.Emit(event.EvtLocalReachabilityChanged{Reachability: network.ReachabilityPrivate})
+	assert.Eventually(t, func() bool {
+		return watcher.Reachability() == p2p.ReachabilityPrivate
+	}, time.Second, time.Millisecond*10)
+
+	_ = emitter.Emit(event.EvtLocalReachabilityChanged{Reachability: network.ReachabilityPrivate})
+	_ = emitter.Emit(event.EvtLocalReachabilityChanged{Reachability: network.ReachabilityPublic})
+	assert.Eventually(t, func() bool {
+		return watcher.Reachability() == p2p.ReachabilityPublic
+	}, time.Second, time.Millisecond*10)
+
+	require.Equal(t, 2, getNumEvents())
+	assert.Equal(t, p2p.ReachabilityChangedEvent, events[0].Type)
+	assert.Equal(t, p2p.ReachabilityPrivate, events[0].Reachability)
+	assert.Equal(t, p2p.ReachabilityPublic, events[1].Reachability)
+}
@@ -0,0 +1,96 @@
+package libp2p
+
+import (
+	"fmt"
+	"time"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/host/autorelay"
+	relayv2 "github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/relay"
+)
+
+func createRelayOptions(relayConfig config.RelayConfig) ([]libp2p.Option, error) {
+	if !relayConfig.Enabled {
+		// we need to disable relay option in order to save the node's bandwidth as much as possible
+		return []libp2p.Option{libp2p.DisableRelay()}, nil
+	}
+
+	options := []libp2p.Option{libp2p.EnableRelay()}
+	if relayConfig.Service.Enabled {
+		options = append(options,
+			libp2p.EnableRelayService(relayv2.WithResources(createRelayResources(relayConfig.Service))),
+			// the relay service is started only on publicly reachable nodes
+			libp2p.ForceReachabilityPublic(),
+		)
+	}
+	if relayConfig.Client.Enabled {
+		staticRelays, err := parseStaticRelays(relayConfig.Client.StaticRelays)
+		if err != nil {
+			return nil, err
+		}
+
+		numRelays := getNumRelays(relayConfig.Client, len(staticRelays))
+		options = append(options, libp2p.EnableAutoRelayWithStaticRelays(staticRelays, autorelay.WithNumRelays(numRelays)))
+	}
+	if relayConfig.EnableHolePunching {
+		options = append(options, libp2p.EnableHolePunching())
+	}
+
+	return options, nil
+}
+
+// getNumRelays returns the number of relays the node keeps reservations with, all the static relays if not configured
+func getNumRelays(clientConfig config.RelayClientConfig, numStaticRelays int) int {
+	if clientConfig.NumRelays == 0 {
+		return numStaticRelays
+	}
+
+	return int(clientConfig.NumRelays)
+}
+
+func createRelayResources(serviceConfig config.RelayServiceConfig) relayv2.Resources {
+	resources := relayv2.DefaultResources()
+	if serviceConfig.MaxReservations > 0 {
+		resources.MaxReservations = int(serviceConfig.MaxReservations)
+	}
+	if serviceConfig.MaxReservationsPerPeer > 0 {
+		resources.MaxReservationsPerPeer = int(serviceConfig.MaxReservationsPerPeer)
+	}
+	if serviceConfig.MaxReservationsPerIP > 0 {
+		resources.MaxReservationsPerIP = int(serviceConfig.MaxReservationsPerIP)
+	}
+	if serviceConfig.MaxCircuits > 0 {
+		resources.MaxCircuits = int(serviceConfig.MaxCircuits)
+	}
+	if serviceConfig.BufferSizeInBytes > 0 {
+		resources.BufferSize = int(serviceConfig.BufferSizeInBytes)
+	}
+	if serviceConfig.ReservationTTLInSec > 0 {
+		resources.ReservationTTL = time.Second * time.Duration(serviceConfig.ReservationTTLInSec)
+	}
+	if serviceConfig.MaxCircuitDurationInSec > 0 {
+		resources.Limit.Duration = time.Second * time.Duration(serviceConfig.MaxCircuitDurationInSec)
+	}
+	if serviceConfig.MaxCircuitDataInBytes > 0 {
+		resources.Limit.Data = int64(serviceConfig.MaxCircuitDataInBytes)
+	}
+
+	return resources
+}
+
+func parseStaticRelays(addresses []string) ([]peer.AddrInfo, error) {
+	staticRelays := make([]peer.AddrInfo, 0, len(addresses))
+	for _, address := range addresses {
+		addrInfo, err := peer.AddrInfoFromString(address)
+		if err != nil {
+			return nil, fmt.Errorf("%w for static relay %s: %s", p2p.ErrInvalidValue, address, err.Error())
+		}
+
+		staticRelays = append(staticRelays, *addrInfo)
+	}
+
+	return staticRelays, nil
+}
@@ -0,0 +1,73 @@
+package libp2p_test
+
+import (
+	"errors"
+	"testing"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRelayOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled relay should only disable the relay transport", func(t *testing.T) {
+		t.Parallel()
+
+		options, err := libp2p.CreateRelayOptions(config.RelayConfig{})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(options))
+	})
+	t.Run("invalid static relay should error", func(t *testing.T) {
+		t.Parallel()
+
+		relayConfig := config.RelayConfig{
+			Enabled: true,
+			Client: config.RelayClientConfig{
+				Enabled:      true,
+				StaticRelays: []string{"invalid address"},
+			},
+		}
+		options, err := libp2p.CreateRelayOptions(relayConfig)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Nil(t, options)
+	})
+	t.Run("all relay features enabled should work", func(t *testing.T) {
+		t.Parallel()
+
+		relayConfig := config.RelayConfig{
+			Enabled:            true,
+			EnableHolePunching: true,
+			Service: config.RelayServiceConfig{
+				Enabled:         true,
+				MaxReservations: 10,
+				MaxCircuits:     4,
+			},
+			Client: config.RelayClientConfig{
+				Enabled:      true,
+				StaticRelays: []string{"/ip4/10.0.0.1/tcp/37373/p2p/16Uiu2HAm6yvbp1oZ6zjnWsn9FdRqBSaQkbhELyaThuq48ybdorrr"},
+				NumRelays:    1,
+			},
+		}
+		options, err := libp2p.CreateRelayOptions(relayConfig)
+		assert.Nil(t, err)
+		assert.Equal(t, 5, len(options))
+	})
+}
+
+func TestGetNumRelays(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unset num relays should keep all the static relays", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, 3, libp2p.GetNumRelays(config.RelayClientConfig{}, 3))
+	})
+	t.Run("set num relays should be used", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, 1, libp2p.GetNumRelays(config.RelayClientConfig{NumRelays: 1}, 3))
+	})
+}
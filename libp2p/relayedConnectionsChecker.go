@@ -0,0 +1,58 @@
+package libp2p
+
+import (
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+var _ p2p.RelayedConnectionsChecker = (*relayedConnectionsChecker)(nil)
+
+// relayedConnectionsChecker checks the connections opened by the host in order to tell if a peer is reachable
+// only through circuit relays
+type relayedConnectionsChecker struct {
+	network network.Network
+}
+
+func newRelayedConnectionsChecker(netw network.Network) *relayedConnectionsChecker {
+	return &relayedConnectionsChecker{
+		network: netw,
+	}
+}
+
+// IsRelayed returns true if all the connections with the provided peer go through circuit relays.
+// A peer that was reached directly after a hole punching is not considered relayed
+func (checker *relayedConnectionsChecker) IsRelayed(pid core.PeerID) bool {
+	conns := checker.network.ConnsToPeer(peer.ID(pid))
+	if len(conns) == 0 {
+		return false
+	}
+
+	for _, conn := range conns {
+		if !isRelayedConnection(conn) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isRelayedConnection(conn network.Conn) bool {
+	return isRelayedAddress(conn.RemoteMultiaddr())
+}
+
+func isRelayedAddress(address multiaddr.Multiaddr) bool {
+	if address == nil {
+		return false
+	}
+
+	_, err := address.ValueForProtocol(multiaddr.P_CIRCUIT)
+	return err == nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (checker *relayedConnectionsChecker) IsInterfaceNil() bool {
+	return checker == nil
+}
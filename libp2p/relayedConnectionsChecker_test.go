@@ -0,0 +1,64 @@
+package libp2p_test
+
+import (
+	"testing"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/mock"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+)
+
+const relayedAddress = "/ip4/10.0.0.1/tcp/37373/p2p/16Uiu2HAm6yvbp1oZ6zjnWsn9FdRqBSaQkbhELyaThuq48ybdorrr/p2p-circuit"
+const directAddress = "/ip4/10.0.0.2/tcp/37373"
+
+func createConnStubWithRemoteAddress(address string) network.Conn {
+	return &mock.ConnStub{
+		RemoteMultiaddrCalled: func() multiaddr.Multiaddr {
+			return multiaddr.StringCast(address)
+		},
+	}
+}
+
+func TestRelayedConnectionsChecker_IsRelayed(t *testing.T) {
+	t.Parallel()
+
+	createChecker := func(conns ...network.Conn) p2p.RelayedConnectionsChecker {
+		return libp2p.NewRelayedConnectionsChecker(&mock.NetworkStub{
+			ConnsToPeerCalled: func(p peer.ID) []network.Conn {
+				return conns
+			},
+		})
+	}
+
+	t.Run("no connections should return false", func(t *testing.T) {
+		t.Parallel()
+
+		checker := createChecker()
+		assert.False(t, checker.IsRelayed("pid"))
+	})
+	t.Run("direct connection should return false", func(t *testing.T) {
+		t.Parallel()
+
+		checker := createChecker(createConnStubWithRemoteAddress(directAddress))
+		assert.False(t, checker.IsRelayed("pid"))
+	})
+	t.Run("relayed and direct connections should return false", func(t *testing.T) {
+		t.Parallel()
+
+		checker := createChecker(
+			createConnStubWithRemoteAddress(relayedAddress),
+			createConnStubWithRemoteAddress(directAddress),
+		)
+		assert.False(t, checker.IsRelayed("pid"))
+	})
+	t.Run("only relayed connections should return true", func(t *testing.T) {
+		t.Parallel()
+
+		checker := createChecker(createConnStubWithRemoteAddress(relayedAddress))
+		assert.True(t, checker.IsRelayed("pid"))
+	})
+}
@@ -0,0 +1,348 @@
+package libp2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/data"
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+const (
+	// RequestResponseID represents the protocol ID for sending requests and receiving their responses
+	RequestResponseID = protocol.ID("/drt/requestresponse/1.0.0")
+
+	defaultRequestTimeout        = time.Second * 10
+	timeoutProcessRequest        = time.Second * 10
+	maxRequestBuffSize           = 1 << 18 // 256 kB
+	requestResponseHeaderSize    = 1 << 10 // 1 kB
+	maxConcurrentRequestsPerPeer = 10
+	// requestFailedMessage is sent instead of the responder's error as to not disclose the node's internals
+	requestFailedMessage = "request failed"
+)
+
+var maxResponseBuffSize = maxSendBuffSize
+
+// ArgsRequestResponseHandler defines the arguments used to create a request-response handler
+type ArgsRequestResponseHandler struct {
+	Context            context.Context
+	Host               host.Host
+	PeersRatingHandler p2p.PeersRatingHandler
+	Debugger           p2p.Debugger
+	RateLimitChecker   InboundRateLimitChecker
+}
+
+type requestResponseHandler struct {
+	ctx                context.Context
+	hostP2P            host.Host
+	peersRatingHandler p2p.PeersRatingHandler
+	debugger           p2p.Debugger
+	rateLimitChecker   InboundRateLimitChecker
+	requestCounter     uint64
+	mutResponders      sync.RWMutex
+	responders         map[string]p2p.RequestResponder
+	mutInProcess       sync.Mutex
+	inProcess          map[core.PeerID]uint32
+}
+
+// NewRequestResponseHandler returns a new instance of a request-response handler
+func NewRequestResponseHandler(args ArgsRequestResponseHandler) (*requestResponseHandler, error) {
+	if args.Context == nil {
+		return nil, p2p.ErrNilContext
+	}
+	if args.Host == nil {
+		return nil, p2p.ErrNilHost
+	}
+	if check.IfNil(args.PeersRatingHandler) {
+		return nil, p2p.ErrNilPeersRatingHandler
+	}
+	if check.IfNil(args.Debugger) {
+		return nil, p2p.ErrNilDebugger
+	}
+	if check.IfNil(args.RateLimitChecker) {
+		return nil, p2p.ErrNilInboundRateLimitChecker
+	}
+
+	rrh := &requestResponseHandler{
+		ctx:                args.Context,
+		hostP2P:            args.Host,
+		peersRatingHandler: args.PeersRatingHandler,
+		debugger:           args.Debugger,
+		rateLimitChecker:   args.RateLimitChecker,
+		requestCounter:     uint64(time.Now().UnixNano()),
+		responders:         make(map[string]p2p.RequestResponder),
+		inProcess:          make(map[core.PeerID]uint32),
+	}
+
+	args.Host.SetStreamHandler(RequestResponseID, rrh.requestStreamHandler)
+
+	return rrh, nil
+}
+
+// RegisterRequestResponder sets the responder for the provided topic
+func (rrh *requestResponseHandler) RegisterRequestResponder(topic string, responder p2p.RequestResponder) error {
+	if check.IfNil(responder) {
+		return fmt.Errorf("%w for topic %s", p2p.ErrNilRequestResponder, topic)
+	}
+
+	rrh.mutResponders.Lock()
+	defer rrh.mutResponders.Unlock()
+
+	_, found := rrh.responders[topic]
+	if found {
+		return fmt.Errorf("%w, topic %s", p2p.ErrRequestResponderAlreadyDefined, topic)
+	}
+
+	rrh.responders[topic] = responder
+
+	return nil
+}
+
+// UnregisterRequestResponder removes the responder for the provided topic
+func (rrh *requestResponseHandler) UnregisterRequestResponder(topic string) error {
+	rrh.mutResponders.Lock()
+	delete(rrh.responders, topic)
+	rrh.mutResponders.Unlock()
+
+	return nil
+}
+
+// SendRequest sends the request to the provided peer and waits for the response. If the provided context does
+// not have a deadline, a default timeout will be applied. A timed out request decreases the peer's rating while a
+// request canceled by the caller returns the context's error and leaves the rating unchanged
+func (rrh *requestResponseHandler) SendRequest(ctx context.Context, topic string, buff []byte, pid core.PeerID) ([]byte, error) {
+	if ctx == nil {
+		return nil, p2p.ErrNilContext
+	}
+	if len(buff) == 0 {
+		return nil, p2p.ErrEmptyBufferToSend
+	}
+	if len(buff) > maxRequestBuffSize {
+		return nil, fmt.Errorf("%w, to be sent: %d, maximum: %d", p2p.ErrMessageTooLarge, len(buff), maxRequestBuffSize)
+	}
+
+	request := &data.RequestMessage{
+		RequestID: atomic.AddUint64(&rrh.requestCounter, 1),
+		Topic:     topic,
+		Payload:   buff,
+	}
+
+	isSelf := peer.ID(pid) == rrh.hostP2P.ID()
+	if isSelf {
+		response := rrh.createResponse(request, pid)
+		if len(response.Error) > 0 {
+			return nil, fmt.Errorf("%w, topic %s, error: %s", p2p.ErrRequestFailed, topic, response.Error)
+		}
+
+		return response.Payload, nil
+	}
+
+	if rrh.hostP2P.Network().Connectedness(peer.ID(pid)) != network.Connected {
+		return nil, p2p.ErrPeerNotDirectlyConnected
+	}
+
+	_, hasDeadline := ctx.Deadline()
+	if !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultRequestTimeout)
+		defer cancel()
+	}
+
+	response, err := rrh.exchange(ctx, request, pid)
+	rrh.debugger.AddOutgoingMessage(topic, uint64(len(buff)), err != nil)
+	if err != nil {
+		// a request canceled by the caller says nothing about the peer
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return nil, ctx.Err()
+		}
+		if isTimeoutError(ctx, err) {
+			rrh.peersRatingHandler.DecreaseRating(pid)
+			return nil, fmt.Errorf("%w for topic %s, peer %s", p2p.ErrRequestTimeout, topic, pid.Pretty())
+		}
+
+		return nil, err
+	}
+
+	if response.RequestID != request.RequestID {
+		rrh.peersRatingHandler.DecreaseRating(pid)
+		return nil, fmt.Errorf("%w, request ID mismatch: expected %d, got %d",
+			p2p.ErrInvalidResponse, request.RequestID, response.RequestID)
+	}
+	if len(response.Error) > 0 {
+		return nil, fmt.Errorf("%w, topic %s, error: %s", p2p.ErrRequestFailed, topic, response.Error)
+	}
+
+	rrh.peersRatingHandler.IncreaseRating(pid)
+
+	return response.Payload, nil
+}
+
+func (rrh *requestResponseHandler) exchange(ctx context.Context, request *data.RequestMessage, pid core.PeerID) (*data.ResponseMessage, error) {
+	stream, err := rrh.hostP2P.NewStream(ctx, peer.ID(pid), RequestResponseID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	// reset the stream when either context is done so the blocking read/write operations will return
+	chanDone := make(chan struct{})
+	defer close(chanDone)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = stream.Reset()
+		case <-rrh.ctx.Done():
+			_ = stream.Reset()
+		case <-chanDone:
+		}
+	}()
+
+	deadline, _ := ctx.Deadline()
+	_ = stream.SetDeadline(deadline)
+
+	writer := ggio.NewDelimitedWriter(stream)
+	err = writer.WriteMsg(request)
+	if err != nil {
+		_ = stream.Reset()
+		return nil, err
+	}
+	_ = stream.CloseWrite()
+
+	response := &data.ResponseMessage{}
+	reader := ggio.NewDelimitedReader(stream, maxResponseBuffSize+requestResponseHeaderSize)
+	err = reader.ReadMsg(response)
+	if err != nil {
+		_ = stream.Reset()
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func isTimeoutError(ctx context.Context, err error) bool {
+	return errors.Is(ctx.Err(), context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded)
+}
+
+func (rrh *requestResponseHandler) requestStreamHandler(s network.Stream) {
+	fromConnectedPeer := core.PeerID(s.Conn().RemotePeer())
+	if !rrh.startProcessing(fromConnectedPeer) {
+		_ = s.Reset()
+		log.Trace("too many requests in process", "from", s.Conn().RemotePeer())
+		return
+	}
+	defer func() {
+		rrh.endProcessing(fromConnectedPeer)
+		_ = s.Close()
+	}()
+
+	_ = s.SetDeadline(time.Now().Add(timeoutProcessRequest))
+
+	request := &data.RequestMessage{}
+	reader := ggio.NewDelimitedReader(s, maxRequestBuffSize+requestResponseHeaderSize)
+	err := reader.ReadMsg(request)
+	if err != nil {
+		_ = s.Reset()
+		log.Trace("error reading request",
+			"from", s.Conn().RemotePeer(),
+			"error", err.Error(),
+		)
+		return
+	}
+
+	// the requests share the inbound rate limits of the topic messages so the flooding peers will not reach the
+	// responders
+	if rrh.rateLimitChecker.IsRateLimited(fromConnectedPeer, request.Topic, len(request.Payload)) {
+		_ = s.Reset()
+		return
+	}
+
+	response := rrh.createResponse(request, fromConnectedPeer)
+	rrh.debugger.AddIncomingMessage(request.Topic, uint64(len(request.Payload)), len(response.Error) > 0)
+
+	writer := ggio.NewDelimitedWriter(s)
+	err = writer.WriteMsg(response)
+	if err != nil {
+		_ = s.Reset()
+		log.Trace("error writing response",
+			"to", s.Conn().RemotePeer(),
+			"topic", request.Topic,
+			"error", err.Error(),
+		)
+	}
+}
+
+// startProcessing returns false if the peer already has the maximum number of requests in process
+func (rrh *requestResponseHandler) startProcessing(pid core.PeerID) bool {
+	rrh.mutInProcess.Lock()
+	defer rrh.mutInProcess.Unlock()
+
+	if rrh.inProcess[pid] >= maxConcurrentRequestsPerPeer {
+		return false
+	}
+	rrh.inProcess[pid]++
+
+	return true
+}
+
+func (rrh *requestResponseHandler) endProcessing(pid core.PeerID) {
+	rrh.mutInProcess.Lock()
+	defer rrh.mutInProcess.Unlock()
+
+	rrh.inProcess[pid]--
+	if rrh.inProcess[pid] == 0 {
+		delete(rrh.inProcess, pid)
+	}
+}
+
+func (rrh *requestResponseHandler) createResponse(request *data.RequestMessage, fromConnectedPeer core.PeerID) *data.ResponseMessage {
+	response := &data.ResponseMessage{
+		RequestID: request.RequestID,
+	}
+
+	rrh.mutResponders.RLock()
+	responder := rrh.responders[request.Topic]
+	rrh.mutResponders.RUnlock()
+
+	if check.IfNil(responder) {
+		response.Error = fmt.Sprintf("no responder for topic %s", request.Topic)
+		return response
+	}
+
+	payload, err := responder.ProcessRequest(request.Topic, request.Payload, fromConnectedPeer)
+	if err != nil {
+		log.Trace("p2p request responder",
+			"error", err.Error(),
+			"topic", request.Topic,
+			"from connected peer", p2p.PeerIdToShortString(fromConnectedPeer),
+		)
+		response.Error = requestFailedMessage
+		return response
+	}
+	if len(payload) > maxResponseBuffSize {
+		response.Error = fmt.Sprintf("%s, to be sent: %d, maximum: %d", p2p.ErrMessageTooLarge.Error(), len(payload), maxResponseBuffSize)
+		return response
+	}
+
+	response.Payload = payload
+
+	return response
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rrh *requestResponseHandler) IsInterfaceNil() bool {
+	return rrh == nil
+}
@@ -0,0 +1,370 @@
+package libp2p_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/mock"
+	"github.com/libp2p/go-libp2p/core/host"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const requestTopic = "request topic"
+
+func createMockArgsRequestResponseHandler(h host.Host) libp2p.ArgsRequestResponseHandler {
+	return libp2p.ArgsRequestResponseHandler{
+		Context:            context.Background(),
+		Host:               h,
+		PeersRatingHandler: &mock.PeersRatingHandlerStub{},
+		Debugger:           &mock.DebuggerStub{},
+		RateLimitChecker:   &mock.InboundRateLimitCheckerStub{},
+	}
+}
+
+func createConnectedHosts(t *testing.T) (host.Host, host.Host) {
+	netw := mocknet.New()
+	h1, err := netw.GenPeer()
+	require.Nil(t, err)
+	h2, err := netw.GenPeer()
+	require.Nil(t, err)
+
+	err = netw.LinkAll()
+	require.Nil(t, err)
+	err = netw.ConnectAllButSelf()
+	require.Nil(t, err)
+
+	return h1, h2
+}
+
+func TestNewRequestResponseHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil context should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRequestResponseHandler(generateHostStub())
+		args.Context = nil
+		rrh, err := libp2p.NewRequestResponseHandler(args)
+		assert.Equal(t, p2p.ErrNilContext, err)
+		assert.True(t, check.IfNil(rrh))
+	})
+	t.Run("nil host should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRequestResponseHandler(nil)
+		rrh, err := libp2p.NewRequestResponseHandler(args)
+		assert.Equal(t, p2p.ErrNilHost, err)
+		assert.True(t, check.IfNil(rrh))
+	})
+	t.Run("nil peers rating handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRequestResponseHandler(generateHostStub())
+		args.PeersRatingHandler = nil
+		rrh, err := libp2p.NewRequestResponseHandler(args)
+		assert.Equal(t, p2p.ErrNilPeersRatingHandler, err)
+		assert.True(t, check.IfNil(rrh))
+	})
+	t.Run("nil debugger should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRequestResponseHandler(generateHostStub())
+		args.Debugger = nil
+		rrh, err := libp2p.NewRequestResponseHandler(args)
+		assert.Equal(t, p2p.ErrNilDebugger, err)
+		assert.True(t, check.IfNil(rrh))
+	})
+	t.Run("nil rate limit checker should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRequestResponseHandler(generateHostStub())
+		args.RateLimitChecker = nil
+		rrh, err := libp2p.NewRequestResponseHandler(args)
+		assert.Equal(t, p2p.ErrNilInboundRateLimitChecker, err)
+		assert.True(t, check.IfNil(rrh))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		rrh, err := libp2p.NewRequestResponseHandler(createMockArgsRequestResponseHandler(generateHostStub()))
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(rrh))
+	})
+}
+
+func TestRequestResponseHandler_RegisterRequestResponder(t *testing.T) {
+	t.Parallel()
+
+	rrh, _ := libp2p.NewRequestResponseHandler(createMockArgsRequestResponseHandler(generateHostStub()))
+
+	err := rrh.RegisterRequestResponder(requestTopic, nil)
+	assert.True(t, errors.Is(err, p2p.ErrNilRequestResponder))
+
+	err = rrh.RegisterRequestResponder(requestTopic, &mock.RequestResponderStub{})
+	assert.Nil(t, err)
+
+	err = rrh.RegisterRequestResponder(requestTopic, &mock.RequestResponderStub{})
+	assert.True(t, errors.Is(err, p2p.ErrRequestResponderAlreadyDefined))
+
+	err = rrh.UnregisterRequestResponder(requestTopic)
+	assert.Nil(t, err)
+
+	err = rrh.RegisterRequestResponder(requestTopic, &mock.RequestResponderStub{})
+	assert.Nil(t, err)
+}
+
+func TestRequestResponseHandler_SendRequest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil context should error", func(t *testing.T) {
+		t.Parallel()
+
+		rrh, _ := libp2p.NewRequestResponseHandler(createMockArgsRequestResponseHandler(generateHostStub()))
+		var ctx context.Context = nil
+		response, err := rrh.SendRequest(ctx, requestTopic, []byte("request"), "pid")
+		assert.Equal(t, p2p.ErrNilContext, err)
+		assert.Nil(t, response)
+	})
+	t.Run("empty buffer should error", func(t *testing.T) {
+		t.Parallel()
+
+		rrh, _ := libp2p.NewRequestResponseHandler(createMockArgsRequestResponseHandler(generateHostStub()))
+		response, err := rrh.SendRequest(context.Background(), requestTopic, nil, "pid")
+		assert.Equal(t, p2p.ErrEmptyBufferToSend, err)
+		assert.Nil(t, response)
+	})
+	t.Run("buffer too large should error", func(t *testing.T) {
+		t.Parallel()
+
+		rrh, _ := libp2p.NewRequestResponseHandler(createMockArgsRequestResponseHandler(generateHostStub()))
+		response, err := rrh.SendRequest(context.Background(), requestTopic, make([]byte, libp2p.MaxRequestBuffSize+1), "pid")
+		assert.True(t, errors.Is(err, p2p.ErrMessageTooLarge))
+		assert.Nil(t, response)
+	})
+	t.Run("peer not connected should error", func(t *testing.T) {
+		t.Parallel()
+
+		netw := mocknet.New()
+		h, _ := netw.GenPeer()
+		rrh, _ := libp2p.NewRequestResponseHandler(createMockArgsRequestResponseHandler(h))
+		response, err := rrh.SendRequest(context.Background(), requestTopic, []byte("request"), "pid")
+		assert.Equal(t, p2p.ErrPeerNotDirectlyConnected, err)
+		assert.Nil(t, response)
+	})
+	t.Run("request to self should work", func(t *testing.T) {
+		t.Parallel()
+
+		netw := mocknet.New()
+		h, _ := netw.GenPeer()
+		rrh, _ := libp2p.NewRequestResponseHandler(createMockArgsRequestResponseHandler(h))
+		_ = rrh.RegisterRequestResponder(requestTopic, &mock.RequestResponderStub{
+			ProcessRequestCalled: func(topic string, request []byte, fromConnectedPeer core.PeerID) ([]byte, error) {
+				assert.Equal(t, core.PeerID(h.ID()), fromConnectedPeer)
+				return append(request, []byte(" response")...), nil
+			},
+		})
+
+		response, err := rrh.SendRequest(context.Background(), requestTopic, []byte("request"), core.PeerID(h.ID()))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("request response"), response)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedHosts(t)
+		numIncreases := int32(0)
+		args1 := createMockArgsRequestResponseHandler(h1)
+		args1.PeersRatingHandler = &mock.PeersRatingHandlerStub{
+			IncreaseRatingCalled: func(pid core.PeerID) {
+				assert.Equal(t, core.PeerID(h2.ID()), pid)
+				atomic.AddInt32(&numIncreases, 1)
+			},
+		}
+		rrh1, _ := libp2p.NewRequestResponseHandler(args1)
+		rrh2, _ := libp2p.NewRequestResponseHandler(createMockArgsRequestResponseHandler(h2))
+		_ = rrh2.RegisterRequestResponder(requestTopic, &mock.RequestResponderStub{
+			ProcessRequestCalled: func(topic string, request []byte, fromConnectedPeer core.PeerID) ([]byte, error) {
+				assert.Equal(t, requestTopic, topic)
+				assert.Equal(t, core.PeerID(h1.ID()), fromConnectedPeer)
+				return append(request, []byte(" response")...), nil
+			},
+		})
+
+		response, err := rrh1.SendRequest(context.Background(), requestTopic, []byte("request"), core.PeerID(h2.ID()))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("request response"), response)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&numIncreases))
+	})
+	t.Run("responder error should not be disclosed", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedHosts(t)
+		rrh1, _ := libp2p.NewRequestResponseHandler(createMockArgsRequestResponseHandler(h1))
+		rrh2, _ := libp2p.NewRequestResponseHandler(createMockArgsRequestResponseHandler(h2))
+		_ = rrh2.RegisterRequestResponder(requestTopic, &mock.RequestResponderStub{
+			ProcessRequestCalled: func(topic string, request []byte, fromConnectedPeer core.PeerID) ([]byte, error) {
+				return nil, errors.New("data not found")
+			},
+		})
+
+		response, err := rrh1.SendRequest(context.Background(), requestTopic, []byte("request"), core.PeerID(h2.ID()))
+		assert.True(t, errors.Is(err, p2p.ErrRequestFailed))
+		assert.False(t, strings.Contains(err.Error(), "data not found"))
+		assert.True(t, strings.HasSuffix(err.Error(), "error: request failed"))
+		assert.Nil(t, response)
+	})
+	t.Run("missing responder should error", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedHosts(t)
+		rrh1, _ := libp2p.NewRequestResponseHandler(createMockArgsRequestResponseHandler(h1))
+		_, _ = libp2p.NewRequestResponseHandler(createMockArgsRequestResponseHandler(h2))
+
+		response, err := rrh1.SendRequest(context.Background(), requestTopic, []byte("request"), core.PeerID(h2.ID()))
+		assert.True(t, errors.Is(err, p2p.ErrRequestFailed))
+		assert.True(t, strings.Contains(err.Error(), "no responder"))
+		assert.Nil(t, response)
+	})
+	t.Run("timeout should error and decrease rating", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedHosts(t)
+		numDecreases := int32(0)
+		args1 := createMockArgsRequestResponseHandler(h1)
+		args1.PeersRatingHandler = &mock.PeersRatingHandlerStub{
+			DecreaseRatingCalled: func(pid core.PeerID) {
+				assert.Equal(t, core.PeerID(h2.ID()), pid)
+				atomic.AddInt32(&numDecreases, 1)
+			},
+		}
+		rrh1, _ := libp2p.NewRequestResponseHandler(args1)
+		rrh2, _ := libp2p.NewRequestResponseHandler(createMockArgsRequestResponseHandler(h2))
+		_ = rrh2.RegisterRequestResponder(requestTopic, &mock.RequestResponderStub{
+			ProcessRequestCalled: func(topic string, request []byte, fromConnectedPeer core.PeerID) ([]byte, error) {
+				time.Sleep(time.Second)
+				return []byte("late response"), nil
+			},
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+		defer cancel()
+
+		response, err := rrh1.SendRequest(ctx, requestTopic, []byte("request"), core.PeerID(h2.ID()))
+		assert.True(t, errors.Is(err, p2p.ErrRequestTimeout))
+		assert.Nil(t, response)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&numDecreases))
+	})
+	t.Run("canceled context should error without changing the rating", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedHosts(t)
+		args1 := createMockArgsRequestResponseHandler(h1)
+		args1.PeersRatingHandler = &mock.PeersRatingHandlerStub{
+			DecreaseRatingCalled: func(pid core.PeerID) {
+				assert.Fail(t, "should have not called DecreaseRating")
+			},
+			IncreaseRatingCalled: func(pid core.PeerID) {
+				assert.Fail(t, "should have not called IncreaseRating")
+			},
+		}
+		rrh1, _ := libp2p.NewRequestResponseHandler(args1)
+		rrh2, _ := libp2p.NewRequestResponseHandler(createMockArgsRequestResponseHandler(h2))
+		_ = rrh2.RegisterRequestResponder(requestTopic, &mock.RequestResponderStub{
+			ProcessRequestCalled: func(topic string, request []byte, fromConnectedPeer core.PeerID) ([]byte, error) {
+				time.Sleep(time.Second)
+				return []byte("late response"), nil
+			},
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		go func() {
+			time.Sleep(time.Millisecond * 100)
+			cancel()
+		}()
+
+		response, err := rrh1.SendRequest(ctx, requestTopic, []byte("request"), core.PeerID(h2.ID()))
+		assert.Equal(t, context.Canceled, err)
+		assert.Nil(t, response)
+	})
+}
+
+func TestRequestResponseHandler_ReceivedRequests(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rate limited request should not reach the responder", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedHosts(t)
+		rrh1, _ := libp2p.NewRequestResponseHandler(createMockArgsRequestResponseHandler(h1))
+		args2 := createMockArgsRequestResponseHandler(h2)
+		numChecks := int32(0)
+		args2.RateLimitChecker = &mock.InboundRateLimitCheckerStub{
+			IsRateLimitedCalled: func(fromConnectedPeer core.PeerID, topic string, size int) bool {
+				assert.Equal(t, core.PeerID(h1.ID()), fromConnectedPeer)
+				assert.Equal(t, requestTopic, topic)
+				assert.Equal(t, len("request"), size)
+				atomic.AddInt32(&numChecks, 1)
+				return true
+			},
+		}
+		rrh2, _ := libp2p.NewRequestResponseHandler(args2)
+		_ = rrh2.RegisterRequestResponder(requestTopic, &mock.RequestResponderStub{
+			ProcessRequestCalled: func(topic string, request []byte, fromConnectedPeer core.PeerID) ([]byte, error) {
+				assert.Fail(t, "should have not called ProcessRequest")
+				return nil, nil
+			},
+		})
+
+		response, err := rrh1.SendRequest(context.Background(), requestTopic, []byte("request"), core.PeerID(h2.ID()))
+		assert.NotNil(t, err)
+		assert.Nil(t, response)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&numChecks))
+	})
+	t.Run("too many concurrent requests from the same peer should be refused", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedHosts(t)
+		rrh1, _ := libp2p.NewRequestResponseHandler(createMockArgsRequestResponseHandler(h1))
+		rrh2, _ := libp2p.NewRequestResponseHandler(createMockArgsRequestResponseHandler(h2))
+		chanRelease := make(chan struct{})
+		numProcessing := int32(0)
+		_ = rrh2.RegisterRequestResponder(requestTopic, &mock.RequestResponderStub{
+			ProcessRequestCalled: func(topic string, request []byte, fromConnectedPeer core.PeerID) ([]byte, error) {
+				atomic.AddInt32(&numProcessing, 1)
+				<-chanRelease
+				return []byte("response"), nil
+			},
+		})
+
+		numRequests := libp2p.MaxConcurrentRequestsPerPeer
+		chanErrors := make(chan error, numRequests)
+		for i := 0; i < numRequests; i++ {
+			go func() {
+				_, err := rrh1.SendRequest(context.Background(), requestTopic, []byte("request"), core.PeerID(h2.ID()))
+				chanErrors <- err
+			}()
+		}
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&numProcessing) == int32(numRequests)
+		}, time.Second*5, time.Millisecond*10)
+
+		response, err := rrh1.SendRequest(context.Background(), requestTopic, []byte("request"), core.PeerID(h2.ID()))
+		assert.NotNil(t, err)
+		assert.Nil(t, response)
+
+		close(chanRelease)
+		for i := 0; i < numRequests; i++ {
+			assert.Nil(t, <-chanErrors)
+		}
+	})
+}
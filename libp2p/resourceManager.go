@@ -0,0 +1,119 @@
+package libp2p
+
+import (
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	"github.com/libp2p/go-libp2p"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+)
+
+// kadProtocolSuffix is appended by the kad DHT to the configured protocol ID
+const kadProtocolSuffix = protocol.ID("/kad/1.0.0")
+
+var pubSubProtocols = []protocol.ID{pubsub.GossipSubID_v11, pubsub.GossipSubID_v10, pubsub.FloodSubID}
+
+// createResourceManager creates the libp2p resource manager using the libp2p default limits, scaled with the available
+// memory and file descriptors, overwritten by the configured limits
+func createResourceManager(p2pConfig config.P2PConfig) (network.ResourceManager, error) {
+	scalingLimits := rcmgr.DefaultLimits
+	libp2p.SetDefaultServiceLimits(&scalingLimits)
+
+	limitsConfig := p2pConfig.ResourceLimits
+	partialLimits := rcmgr.PartialLimitConfig{
+		System:      toResourceLimits(limitsConfig.System),
+		Transient:   toResourceLimits(limitsConfig.Transient),
+		PeerDefault: toResourceLimits(limitsConfig.Peer),
+		Protocol:    make(map[protocol.ID]rcmgr.ResourceLimits),
+	}
+	partialLimits.Protocol[DirectSendID] = toResourceLimits(limitsConfig.DirectSend)
+	partialLimits.Protocol[RequestResponseID] = toResourceLimits(limitsConfig.RequestResponse)
+	for _, pubSubProtocol := range pubSubProtocols {
+		partialLimits.Protocol[pubSubProtocol] = toResourceLimits(limitsConfig.PubSub)
+	}
+	kadProtocol, isKadEnabled := getKadProtocol(p2pConfig.KadDhtPeerDiscovery)
+	if isKadEnabled {
+		partialLimits.Protocol[kadProtocol] = toResourceLimits(limitsConfig.Kad)
+	}
+
+	limiter := rcmgr.NewFixedLimiter(partialLimits.Build(scalingLimits.AutoScale()))
+
+	return rcmgr.NewResourceManager(limiter)
+}
+
+func toResourceLimits(limits config.ScopeLimitsConfig) rcmgr.ResourceLimits {
+	return rcmgr.ResourceLimits{
+		Streams:         toLimitVal(limits.Streams),
+		StreamsInbound:  toLimitVal(limits.StreamsInbound),
+		StreamsOutbound: toLimitVal(limits.StreamsOutbound),
+		Conns:           toLimitVal(limits.Conns),
+		ConnsInbound:    toLimitVal(limits.ConnsInbound),
+		ConnsOutbound:   toLimitVal(limits.ConnsOutbound),
+		FD:              toLimitVal(limits.FD),
+		Memory:          rcmgr.LimitVal64(int64(limits.MemoryInMB) << 20),
+	}
+}
+
+// toLimitVal keeps the libp2p default for the 0 value
+func toLimitVal(limit uint32) rcmgr.LimitVal {
+	if limit == 0 {
+		return rcmgr.DefaultLimit
+	}
+
+	return rcmgr.LimitVal(limit)
+}
+
+func getKadProtocol(kadConfig config.KadDhtPeerDiscoveryConfig) (protocol.ID, bool) {
+	if !kadConfig.Enabled || len(kadConfig.ProtocolID) == 0 {
+		return "", false
+	}
+
+	return protocol.ID(kadConfig.ProtocolID) + kadProtocolSuffix, true
+}
+
+// limitedProtocols returns the protocols with limits set from the configuration
+func limitedProtocols(kadConfig config.KadDhtPeerDiscoveryConfig) []protocol.ID {
+	protocols := append([]protocol.ID{DirectSendID, RequestResponseID}, pubSubProtocols...)
+	kadProtocol, isKadEnabled := getKadProtocol(kadConfig)
+	if isKadEnabled {
+		protocols = append(protocols, kadProtocol)
+	}
+
+	return protocols
+}
+
+func getResourceUsage(resourceManager network.ResourceManager, protocols []protocol.ID) p2p.ResourceUsage {
+	usage := p2p.ResourceUsage{
+		Protocols: make(map[string]p2p.ResourceScopeUsage, len(protocols)),
+	}
+
+	_ = resourceManager.ViewSystem(func(scope network.ResourceScope) error {
+		usage.System = toResourceScopeUsage(scope.Stat())
+		return nil
+	})
+	_ = resourceManager.ViewTransient(func(scope network.ResourceScope) error {
+		usage.Transient = toResourceScopeUsage(scope.Stat())
+		return nil
+	})
+	for _, protocolID := range protocols {
+		_ = resourceManager.ViewProtocol(protocolID, func(scope network.ProtocolScope) error {
+			usage.Protocols[string(protocolID)] = toResourceScopeUsage(scope.Stat())
+			return nil
+		})
+	}
+
+	return usage
+}
+
+func toResourceScopeUsage(stat network.ScopeStat) p2p.ResourceScopeUsage {
+	return p2p.ResourceScopeUsage{
+		NumStreamsInbound:  stat.NumStreamsInbound,
+		NumStreamsOutbound: stat.NumStreamsOutbound,
+		NumConnsInbound:    stat.NumConnsInbound,
+		NumConnsOutbound:   stat.NumConnsOutbound,
+		NumFD:              stat.NumFD,
+		Memory:             stat.Memory,
+	}
+}
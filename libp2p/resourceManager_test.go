@@ -0,0 +1,102 @@
+package libp2p_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
+	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openProtocolStreams(t *testing.T, resourceManager network.ResourceManager, protocolID protocol.ID, numStreams int) int {
+	numOpened := 0
+	for i := 0; i < numStreams; i++ {
+		pid := peer.ID(fmt.Sprintf("pid%d", i))
+		stream, err := resourceManager.OpenStream(pid, network.DirInbound)
+		require.Nil(t, err)
+
+		err = stream.SetProtocol(protocolID)
+		if err != nil {
+			stream.Done()
+			continue
+		}
+		numOpened++
+	}
+
+	return numOpened
+}
+
+func TestCreateResourceManager_ShouldApplyTheProtocolsLimits(t *testing.T) {
+	t.Parallel()
+
+	p2pConfig := config.P2PConfig{
+		KadDhtPeerDiscovery: config.KadDhtPeerDiscoveryConfig{
+			Enabled:    true,
+			ProtocolID: "/drt/kad/1.0.0",
+		},
+		ResourceLimits: config.ResourceLimitsConfig{
+			DirectSend: config.ScopeLimitsConfig{
+				Streams:        3,
+				StreamsInbound: 2,
+			},
+			PubSub: config.ScopeLimitsConfig{
+				StreamsInbound: 1,
+			},
+			Kad: config.ScopeLimitsConfig{
+				StreamsInbound: 3,
+			},
+			RequestResponse: config.ScopeLimitsConfig{
+				StreamsInbound: 4,
+			},
+		},
+	}
+	resourceManager, err := libp2p.CreateResourceManager(p2pConfig)
+	require.Nil(t, err)
+	defer func() {
+		_ = resourceManager.Close()
+	}()
+
+	assert.Equal(t, 2, openProtocolStreams(t, resourceManager, libp2p.DirectSendID, 5))
+	assert.Equal(t, 1, openProtocolStreams(t, resourceManager, "/meshsub/1.1.0", 5))
+	assert.Equal(t, 3, openProtocolStreams(t, resourceManager, "/drt/kad/1.0.0/kad/1.0.0", 5))
+	assert.Equal(t, 4, openProtocolStreams(t, resourceManager, libp2p.RequestResponseID, 5))
+	// the protocols without configured limits keep the libp2p defaults
+	assert.Equal(t, 5, openProtocolStreams(t, resourceManager, "/other/1.0.0", 5))
+
+	_ = resourceManager.ViewProtocol(libp2p.DirectSendID, func(scope network.ProtocolScope) error {
+		assert.Equal(t, 2, scope.Stat().NumStreamsInbound)
+		return nil
+	})
+}
+
+func TestCreateResourceManager_ShouldApplyThePeerLimits(t *testing.T) {
+	t.Parallel()
+
+	p2pConfig := config.P2PConfig{
+		ResourceLimits: config.ResourceLimitsConfig{
+			Peer: config.ScopeLimitsConfig{
+				StreamsInbound: 2,
+			},
+		},
+	}
+	resourceManager, err := libp2p.CreateResourceManager(p2pConfig)
+	require.Nil(t, err)
+	defer func() {
+		_ = resourceManager.Close()
+	}()
+
+	numOpened := 0
+	for i := 0; i < 5; i++ {
+		stream, errOpen := resourceManager.OpenStream("pid", network.DirInbound)
+		if errOpen == nil {
+			defer stream.Done()
+			numOpened++
+		}
+	}
+	assert.Equal(t, 2, numOpened)
+}
@@ -0,0 +1,46 @@
+package libp2p
+
+import (
+	"fmt"
+
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+)
+
+// applyTopicOptionsDefaults replaces the unset limits with the package-wide values
+func applyTopicOptionsDefaults(options p2p.TopicOptions, maxAllowedMessageSize int) p2p.TopicOptions {
+	if options.MaxMessageSize == 0 {
+		options.MaxMessageSize = maxAllowedMessageSize
+	}
+	if options.MaxMessageAge == 0 {
+		options.MaxMessageAge = pubsubTimeCacheDuration
+	}
+	if options.MaxClockSkew == 0 {
+		options.MaxClockSkew = acceptMessagesInAdvanceDuration
+	}
+
+	return options
+}
+
+func checkTopicOptions(options p2p.TopicOptions, maxAllowedMessageSize int) error {
+	err := checkCompressionCodec(options.Compression)
+	if err != nil {
+		return err
+	}
+	if options.MaxMessageSize < 0 || options.MaxMessageSize > maxAllowedMessageSize {
+		return fmt.Errorf("%w for MaxMessageSize, maximum %d, got %d",
+			p2p.ErrInvalidValue, maxAllowedMessageSize, options.MaxMessageSize)
+	}
+	// the messages older than the pubsub time cache duration could be replayed as they are no longer marked as seen
+	if options.MaxMessageAge < 0 || options.MaxMessageAge > pubsubTimeCacheDuration {
+		return fmt.Errorf("%w for MaxMessageAge, maximum %v, got %v",
+			p2p.ErrInvalidValue, pubsubTimeCacheDuration, options.MaxMessageAge)
+	}
+	if options.MaxClockSkew < 0 {
+		return fmt.Errorf("%w for MaxClockSkew, got %v", p2p.ErrInvalidValue, options.MaxClockSkew)
+	}
+	if options.CreateChannel {
+		return checkChannelOptions(options.ChannelOptions)
+	}
+
+	return nil
+}
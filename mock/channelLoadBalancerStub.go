@@ -1,15 +1,20 @@
 package mock
 
 import (
+	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
 	"github.com/TerraDharitri/drt-go-chain-p2p/libp2p"
 )
 
 // ChannelLoadBalancerStub -
 type ChannelLoadBalancerStub struct {
 	AddChannelCalled                    func(pipe string) error
+	AddChannelWithOptionsCalled         func(pipe string, options p2p.ChannelOptions) error
 	RemoveChannelCalled                 func(pipe string) error
 	GetChannelOrDefaultCalled           func(pipe string) chan *libp2p.SendableData
+	EnqueueCalled                       func(pipe string, data *libp2p.SendableData) error
 	CollectOneElementFromChannelsCalled func() *libp2p.SendableData
+	ChannelsStatsCalled                 func() []p2p.ChannelStats
+	DiscardAllCalled                    func() int
 	CloseCalled                         func() error
 }
 
@@ -18,6 +23,15 @@ func (clbs *ChannelLoadBalancerStub) AddChannel(pipe string) error {
 	return clbs.AddChannelCalled(pipe)
 }
 
+// AddChannelWithOptions -
+func (clbs *ChannelLoadBalancerStub) AddChannelWithOptions(pipe string, options p2p.ChannelOptions) error {
+	if clbs.AddChannelWithOptionsCalled != nil {
+		return clbs.AddChannelWithOptionsCalled(pipe, options)
+	}
+
+	return nil
+}
+
 // RemoveChannel -
 func (clbs *ChannelLoadBalancerStub) RemoveChannel(pipe string) error {
 	return clbs.RemoveChannelCalled(pipe)
@@ -28,11 +42,38 @@ func (clbs *ChannelLoadBalancerStub) GetChannelOrDefault(pipe string) chan *libp
 	return clbs.GetChannelOrDefaultCalled(pipe)
 }
 
+// Enqueue -
+func (clbs *ChannelLoadBalancerStub) Enqueue(pipe string, data *libp2p.SendableData) error {
+	if clbs.EnqueueCalled != nil {
+		return clbs.EnqueueCalled(pipe, data)
+	}
+
+	return nil
+}
+
 // CollectOneElementFromChannels -
 func (clbs *ChannelLoadBalancerStub) CollectOneElementFromChannels() *libp2p.SendableData {
 	return clbs.CollectOneElementFromChannelsCalled()
 }
 
+// ChannelsStats -
+func (clbs *ChannelLoadBalancerStub) ChannelsStats() []p2p.ChannelStats {
+	if clbs.ChannelsStatsCalled != nil {
+		return clbs.ChannelsStatsCalled()
+	}
+
+	return make([]p2p.ChannelStats, 0)
+}
+
+// DiscardAll -
+func (clbs *ChannelLoadBalancerStub) DiscardAll() int {
+	if clbs.DiscardAllCalled != nil {
+		return clbs.DiscardAllCalled()
+	}
+
+	return 0
+}
+
 // Close -
 func (clbs *ChannelLoadBalancerStub) Close() error {
 	if clbs.CloseCalled != nil {
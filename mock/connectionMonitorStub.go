@@ -14,6 +14,7 @@ type ConnectionMonitorStub struct {
 	IsConnectedToTheNetworkCalled       func(netw network.Network) bool
 	SetThresholdMinConnectedPeersCalled func(thresholdMinConnectedPeers int, netw network.Network)
 	ThresholdMinConnectedPeersCalled    func() int
+	TrimConnectionsCalled               func(netw network.Network)
 }
 
 // Listen -
@@ -69,6 +70,13 @@ func (cms *ConnectionMonitorStub) ThresholdMinConnectedPeers() int {
 	return 0
 }
 
+// TrimConnections -
+func (cms *ConnectionMonitorStub) TrimConnections(netw network.Network) {
+	if cms.TrimConnectionsCalled != nil {
+		cms.TrimConnectionsCalled(netw)
+	}
+}
+
 // Close -
 func (cms *ConnectionMonitorStub) Close() error {
 	return nil
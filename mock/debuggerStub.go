@@ -0,0 +1,52 @@
+package mock
+
+// DebuggerStub -
+type DebuggerStub struct {
+	AddIncomingMessageCalled    func(topic string, size uint64, isRejected bool)
+	AddOutgoingMessageCalled    func(topic string, size uint64, isRejected bool)
+	AddCompressedMessageCalled  func(topic string, uncompressedSize uint64, compressedSize uint64)
+	AddRateLimitedMessageCalled func(topic string, size uint64)
+	CloseCalled                 func() error
+}
+
+// AddIncomingMessage -
+func (stub *DebuggerStub) AddIncomingMessage(topic string, size uint64, isRejected bool) {
+	if stub.AddIncomingMessageCalled != nil {
+		stub.AddIncomingMessageCalled(topic, size, isRejected)
+	}
+}
+
+// AddOutgoingMessage -
+func (stub *DebuggerStub) AddOutgoingMessage(topic string, size uint64, isRejected bool) {
+	if stub.AddOutgoingMessageCalled != nil {
+		stub.AddOutgoingMessageCalled(topic, size, isRejected)
+	}
+}
+
+// AddCompressedMessage -
+func (stub *DebuggerStub) AddCompressedMessage(topic string, uncompressedSize uint64, compressedSize uint64) {
+	if stub.AddCompressedMessageCalled != nil {
+		stub.AddCompressedMessageCalled(topic, uncompressedSize, compressedSize)
+	}
+}
+
+// AddRateLimitedMessage -
+func (stub *DebuggerStub) AddRateLimitedMessage(topic string, size uint64) {
+	if stub.AddRateLimitedMessageCalled != nil {
+		stub.AddRateLimitedMessageCalled(topic, size)
+	}
+}
+
+// Close -
+func (stub *DebuggerStub) Close() error {
+	if stub.CloseCalled != nil {
+		return stub.CloseCalled()
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *DebuggerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
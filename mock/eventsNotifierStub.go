@@ -0,0 +1,20 @@
+package mock
+
+import p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+
+// EventsNotifierStub -
+type EventsNotifierStub struct {
+	NotifyEventCalled func(event p2p.Event)
+}
+
+// NotifyEvent -
+func (stub *EventsNotifierStub) NotifyEvent(event p2p.Event) {
+	if stub.NotifyEventCalled != nil {
+		stub.NotifyEventCalled(event)
+	}
+}
+
+// IsInterfaceNil -
+func (stub *EventsNotifierStub) IsInterfaceNil() bool {
+	return stub == nil
+}
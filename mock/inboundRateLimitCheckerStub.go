@@ -0,0 +1,22 @@
+package mock
+
+import "github.com/TerraDharitri/drt-go-chain-core/core"
+
+// InboundRateLimitCheckerStub -
+type InboundRateLimitCheckerStub struct {
+	IsRateLimitedCalled func(fromConnectedPeer core.PeerID, topic string, size int) bool
+}
+
+// IsRateLimited -
+func (stub *InboundRateLimitCheckerStub) IsRateLimited(fromConnectedPeer core.PeerID, topic string, size int) bool {
+	if stub.IsRateLimitedCalled != nil {
+		return stub.IsRateLimitedCalled(fromConnectedPeer, topic, size)
+	}
+
+	return false
+}
+
+// IsInterfaceNil -
+func (stub *InboundRateLimitCheckerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -13,6 +13,8 @@ type KadSharderStub struct {
 	SetPeerShardResolverCalled func(psp p2p.PeerShardResolver) error
 	SetSeedersCalled           func(addresses []string)
 	IsSeederCalled             func(pid core.PeerID) bool
+	ComputeMissingPeersCalled  func(pidList []peer.ID) []p2p.PeersCategoryQuota
+	GetSelfPeerInfoCalled      func() core.P2PPeerInfo
 }
 
 // ComputeEvictionList -
@@ -58,6 +60,24 @@ func (kss *KadSharderStub) IsSeeder(pid core.PeerID) bool {
 	return false
 }
 
+// ComputeMissingPeers -
+func (kss *KadSharderStub) ComputeMissingPeers(pidList []peer.ID) []p2p.PeersCategoryQuota {
+	if kss.ComputeMissingPeersCalled != nil {
+		return kss.ComputeMissingPeersCalled(pidList)
+	}
+
+	return make([]p2p.PeersCategoryQuota, 0)
+}
+
+// GetSelfPeerInfo -
+func (kss *KadSharderStub) GetSelfPeerInfo() core.P2PPeerInfo {
+	if kss.GetSelfPeerInfoCalled != nil {
+		return kss.GetSelfPeerInfoCalled()
+	}
+
+	return core.P2PPeerInfo{}
+}
+
 // IsInterfaceNil -
 func (kss *KadSharderStub) IsInterfaceNil() bool {
 	return kss == nil
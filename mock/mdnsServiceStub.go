@@ -0,0 +1,25 @@
+package mock
+
+// MdnsServiceStub -
+type MdnsServiceStub struct {
+	StartCalled func() error
+	CloseCalled func() error
+}
+
+// Start -
+func (mss *MdnsServiceStub) Start() error {
+	if mss.StartCalled != nil {
+		return mss.StartCalled()
+	}
+
+	return nil
+}
+
+// Close -
+func (mss *MdnsServiceStub) Close() error {
+	if mss.CloseCalled != nil {
+		return mss.CloseCalled()
+	}
+
+	return nil
+}
@@ -1,9 +1,12 @@
 package mock
 
+import "time"
+
 // PeerDiscovererStub -
 type PeerDiscovererStub struct {
-	BootstrapCalled func() error
-	CloseCalled     func() error
+	BootstrapCalled               func() error
+	CloseCalled                   func() error
+	SetPeersRefreshIntervalCalled func(interval time.Duration) error
 }
 
 // Bootstrap -
@@ -16,6 +19,15 @@ func (pds *PeerDiscovererStub) Name() string {
 	return "PeerDiscovererStub"
 }
 
+// SetPeersRefreshInterval -
+func (pds *PeerDiscovererStub) SetPeersRefreshInterval(interval time.Duration) error {
+	if pds.SetPeersRefreshIntervalCalled != nil {
+		return pds.SetPeersRefreshIntervalCalled(interval)
+	}
+
+	return nil
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (pds *PeerDiscovererStub) IsInterfaceNil() bool {
 	return pds == nil
@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"net"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+)
+
+// PeersIPResolverStub -
+type PeersIPResolverStub struct {
+	GetPeerIPCalled func(pid core.PeerID) (net.IP, bool)
+}
+
+// GetPeerIP -
+func (stub *PeersIPResolverStub) GetPeerIP(pid core.PeerID) (net.IP, bool) {
+	if stub.GetPeerIPCalled != nil {
+		return stub.GetPeerIPCalled(pid)
+	}
+
+	return nil, false
+}
+
+// IsInterfaceNil -
+func (stub *PeersIPResolverStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+)
+
+// PeersLatencyProviderStub -
+type PeersLatencyProviderStub struct {
+	GetPeerLatencyCalled func(pid core.PeerID) (time.Duration, bool)
+}
+
+// GetPeerLatency -
+func (stub *PeersLatencyProviderStub) GetPeerLatency(pid core.PeerID) (time.Duration, bool) {
+	if stub.GetPeerLatencyCalled != nil {
+		return stub.GetPeerLatencyCalled(pid)
+	}
+
+	return 0, false
+}
+
+// IsInterfaceNil -
+func (stub *PeersLatencyProviderStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -7,6 +7,7 @@ type PeersRatingHandlerStub struct {
 	AddPeerCalled                  func(pid core.PeerID)
 	IncreaseRatingCalled           func(pid core.PeerID)
 	DecreaseRatingCalled           func(pid core.PeerID)
+	UpdateMeshScoresCalled         func(scores map[core.PeerID]float64)
 	GetTopRatedPeersFromListCalled func(peers []core.PeerID, numOfPeers int) []core.PeerID
 }
 
@@ -31,6 +32,13 @@ func (stub *PeersRatingHandlerStub) DecreaseRating(pid core.PeerID) {
 	}
 }
 
+// UpdateMeshScores -
+func (stub *PeersRatingHandlerStub) UpdateMeshScores(scores map[core.PeerID]float64) {
+	if stub.UpdateMeshScoresCalled != nil {
+		stub.UpdateMeshScoresCalled(scores)
+	}
+}
+
 // GetTopRatedPeersFromList -
 func (stub *PeersRatingHandlerStub) GetTopRatedPeersFromList(peers []core.PeerID, numOfPeers int) []core.PeerID {
 	if stub.GetTopRatedPeersFromListCalled != nil {
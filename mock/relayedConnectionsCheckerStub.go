@@ -0,0 +1,24 @@
+package mock
+
+import (
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+)
+
+// RelayedConnectionsCheckerStub -
+type RelayedConnectionsCheckerStub struct {
+	IsRelayedCalled func(pid core.PeerID) bool
+}
+
+// IsRelayed -
+func (rccs *RelayedConnectionsCheckerStub) IsRelayed(pid core.PeerID) bool {
+	if rccs.IsRelayedCalled != nil {
+		return rccs.IsRelayedCalled(pid)
+	}
+
+	return false
+}
+
+// IsInterfaceNil -
+func (rccs *RelayedConnectionsCheckerStub) IsInterfaceNil() bool {
+	return rccs == nil
+}
@@ -0,0 +1,22 @@
+package mock
+
+import "github.com/TerraDharitri/drt-go-chain-core/core"
+
+// RequestResponderStub -
+type RequestResponderStub struct {
+	ProcessRequestCalled func(topic string, request []byte, fromConnectedPeer core.PeerID) ([]byte, error)
+}
+
+// ProcessRequest -
+func (stub *RequestResponderStub) ProcessRequest(topic string, request []byte, fromConnectedPeer core.PeerID) ([]byte, error) {
+	if stub.ProcessRequestCalled != nil {
+		return stub.ProcessRequestCalled(topic, request, fromConnectedPeer)
+	}
+
+	return nil, nil
+}
+
+// IsInterfaceNil -
+func (stub *RequestResponderStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -0,0 +1,36 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/discovery"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// RoutingDiscoveryStub -
+type RoutingDiscoveryStub struct {
+	AdvertiseCalled func(ctx context.Context, ns string, opts ...discovery.Option) (time.Duration, error)
+	FindPeersCalled func(ctx context.Context, ns string, opts ...discovery.Option) (<-chan peer.AddrInfo, error)
+}
+
+// Advertise -
+func (rds *RoutingDiscoveryStub) Advertise(ctx context.Context, ns string, opts ...discovery.Option) (time.Duration, error) {
+	if rds.AdvertiseCalled != nil {
+		return rds.AdvertiseCalled(ctx, ns, opts...)
+	}
+
+	return time.Hour, nil
+}
+
+// FindPeers -
+func (rds *RoutingDiscoveryStub) FindPeers(ctx context.Context, ns string, opts ...discovery.Option) (<-chan peer.AddrInfo, error) {
+	if rds.FindPeersCalled != nil {
+		return rds.FindPeersCalled(ctx, ns, opts...)
+	}
+
+	ch := make(chan peer.AddrInfo)
+	close(ch)
+
+	return ch, nil
+}
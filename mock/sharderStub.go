@@ -3,6 +3,7 @@ package mock
 import (
 	"github.com/TerraDharitri/drt-go-chain-core/core"
 	p2p "github.com/TerraDharitri/drt-go-chain-p2p"
+	"github.com/TerraDharitri/drt-go-chain-p2p/config"
 )
 
 // SharderStub -
@@ -10,6 +11,7 @@ type SharderStub struct {
 	SetPeerShardResolverCalled func(psp p2p.PeerShardResolver) error
 	SetSeedersCalled           func(addresses []string)
 	IsSeederCalled             func(pid core.PeerID) bool
+	UpdateShardingConfigCalled func(shardingConfig config.ShardingConfig) error
 }
 
 // SetPeerShardResolver -
@@ -37,6 +39,15 @@ func (ss *SharderStub) IsSeeder(pid core.PeerID) bool {
 	return false
 }
 
+// UpdateShardingConfig -
+func (ss *SharderStub) UpdateShardingConfig(shardingConfig config.ShardingConfig) error {
+	if ss.UpdateShardingConfigCalled != nil {
+		return ss.UpdateShardingConfigCalled(shardingConfig)
+	}
+
+	return nil
+}
+
 // IsInterfaceNil -
 func (ss *SharderStub) IsInterfaceNil() bool {
 	return ss == nil
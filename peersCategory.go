@@ -0,0 +1,71 @@
+package p2p
+
+import "github.com/TerraDharitri/drt-go-chain-core/core"
+
+// PeersCategory defines a category of peers kept by the sharder
+type PeersCategory uint8
+
+const (
+	// IntraShardValidatorsCategory holds the validators from the node's shard
+	IntraShardValidatorsCategory PeersCategory = iota
+	// IntraShardObserversCategory holds the observers from the node's shard
+	IntraShardObserversCategory
+	// CrossShardValidatorsCategory holds the validators from the other shards
+	CrossShardValidatorsCategory
+	// CrossShardObserversCategory holds the observers from the other shards
+	CrossShardObserversCategory
+	// SeedersCategory holds the seeders
+	SeedersCategory
+	// FullHistoryObserversCategory holds the full history observers from the node's shard, kept only by the full
+	// archive nodes
+	FullHistoryObserversCategory
+	// UnknownPeersCategory holds the peers whose shard and type are not known
+	UnknownPeersCategory
+	// PreferredPeersCategory holds the preferred peers, which are never evicted
+	PreferredPeersCategory
+)
+
+// String returns the human-readable form of the peers category
+func (pc PeersCategory) String() string {
+	switch pc {
+	case IntraShardValidatorsCategory:
+		return "intra shard validators"
+	case IntraShardObserversCategory:
+		return "intra shard observers"
+	case CrossShardValidatorsCategory:
+		return "cross shard validators"
+	case CrossShardObserversCategory:
+		return "cross shard observers"
+	case SeedersCategory:
+		return "seeders"
+	case FullHistoryObserversCategory:
+		return "full history observers"
+	case UnknownPeersCategory:
+		return "unknown peers"
+	case PreferredPeersCategory:
+		return "preferred peers"
+	default:
+		return "unknown"
+	}
+}
+
+// PeersCategoryQuota holds the number of peers that can still be connected for a sharder's peers category
+type PeersCategoryQuota struct {
+	Category   PeersCategory
+	NumMissing int
+}
+
+// PeerEvictionExplanation holds the sharder's decision about a connected peer. The Quota is the number of peers the
+// category can keep, including the spare slots passed along from the other categories, and the Rank is the position
+// of the peer in its category, the closest peer having the rank 0. The peers whose Rank is not lower than the Quota
+// are evicted
+type PeerEvictionExplanation struct {
+	Peer      core.PeerID
+	Category  PeersCategory
+	Distance  uint64
+	IsRelayed bool
+	Quota     int
+	Rank      int
+	IsEvicted bool
+	Reason    string
+}
@@ -34,6 +34,7 @@ type ArgPeersRatingHandler struct {
 type peersRatingHandler struct {
 	topRatedCache types.Cacher
 	badRatedCache types.Cacher
+	meshScores    map[core.PeerID]float64
 	mut           sync.Mutex
 }
 
@@ -47,6 +48,7 @@ func NewPeersRatingHandler(args ArgPeersRatingHandler) (*peersRatingHandler, err
 	prh := &peersRatingHandler{
 		topRatedCache: args.TopRatedCache,
 		badRatedCache: args.BadRatedCache,
+		meshScores:    make(map[core.PeerID]float64),
 	}
 
 	return prh, nil
@@ -93,6 +95,21 @@ func (prh *peersRatingHandler) DecreaseRating(pid core.PeerID) {
 	prh.updateRatingIfNeeded(pid, decreaseFactor)
 }
 
+// UpdateMeshScores replaces the gossipsub mesh scores known for the connected peers
+// a top rated peer that has a negative mesh score will be treated as a bad rated peer. Only the sign of the score is
+// used: the score is built from the gossip behaviour so it is not comparable with the requests rating and a positive
+// score says nothing about how well a peer answers the requests, while a negative one means it was penalized
+func (prh *peersRatingHandler) UpdateMeshScores(scores map[core.PeerID]float64) {
+	newMeshScores := make(map[core.PeerID]float64, len(scores))
+	for pid, score := range scores {
+		newMeshScores[pid] = score
+	}
+
+	prh.mut.Lock()
+	prh.meshScores = newMeshScores
+	prh.mut.Unlock()
+}
+
 func (prh *peersRatingHandler) getOldRating(pid core.PeerID) (int32, bool) {
 	oldRating, found := prh.topRatedCache.Get(pid.Bytes())
 	if found {
@@ -206,9 +223,9 @@ func (prh *peersRatingHandler) displayPeersRating(peers *[]core.PeerID, minNumOf
 
 		ratingInt, ok := rating.(int32)
 		if ok {
-			strPeersRatings += fmt.Sprintf("\n peerID: %s, rating: %d", peer.Pretty(), ratingInt)
+			strPeersRatings += fmt.Sprintf("\n peerID: %s, rating: %d, mesh score: %.2f", peer.Pretty(), ratingInt, prh.meshScores[peer])
 		} else {
-			strPeersRatings += fmt.Sprintf("\n peerID: %s, rating: invalid", peer.Pretty())
+			strPeersRatings += fmt.Sprintf("\n peerID: %s, rating: invalid, mesh score: %.2f", peer.Pretty(), prh.meshScores[peer])
 		}
 	}
 
@@ -221,6 +238,11 @@ func (prh *peersRatingHandler) splitPeersByTiers(peers []core.PeerID) ([]core.Pe
 
 	for _, peer := range peers {
 		if prh.topRatedCache.Has(peer.Bytes()) {
+			if prh.hasNegativeMeshScore(peer) {
+				badRated = append(badRated, peer)
+				continue
+			}
+
 			topRated = append(topRated, peer)
 		}
 
@@ -232,6 +254,12 @@ func (prh *peersRatingHandler) splitPeersByTiers(peers []core.PeerID) ([]core.Pe
 	return topRated, badRated
 }
 
+func (prh *peersRatingHandler) hasNegativeMeshScore(pid core.PeerID) bool {
+	score, found := prh.meshScores[pid]
+
+	return found && score < 0
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (prh *peersRatingHandler) IsInterfaceNil() bool {
 	return prh == nil
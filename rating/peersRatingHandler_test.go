@@ -423,4 +423,54 @@ func TestPeersRatingHandler_GetTopRatedPeersFromList(t *testing.T) {
 		res := prh.GetTopRatedPeersFromList(providedListOfPeers, 2)
 		assert.Equal(t, expectedListOfPeers, res)
 	})
+	t.Run("top rated peer with negative mesh score should be returned after the other top rated peers", func(t *testing.T) {
+		t.Parallel()
+
+		providedPid1, providedPid2 := core.PeerID("provided pid 1"), core.PeerID("provided pid 2")
+		args := createMockArgs()
+		args.TopRatedCache = &mock.CacherStub{
+			HasCalled: func(key []byte) bool {
+				return bytes.Equal(key, providedPid1.Bytes()) || bytes.Equal(key, providedPid2.Bytes())
+			},
+		}
+		prh, _ := NewPeersRatingHandler(args)
+		assert.False(t, check.IfNil(prh))
+
+		prh.UpdateMeshScores(map[core.PeerID]float64{
+			providedPid1: -10,
+			providedPid2: 5,
+		})
+
+		providedListOfPeers := []core.PeerID{providedPid1, providedPid2}
+		res := prh.GetTopRatedPeersFromList(providedListOfPeers, 1)
+		assert.Equal(t, []core.PeerID{providedPid2}, res)
+
+		res = prh.GetTopRatedPeersFromList(providedListOfPeers, 2)
+		assert.Equal(t, []core.PeerID{providedPid2, providedPid1}, res)
+	})
+}
+
+func TestPeersRatingHandler_UpdateMeshScores(t *testing.T) {
+	t.Parallel()
+
+	prh, _ := NewPeersRatingHandler(createMockArgs())
+	assert.False(t, check.IfNil(prh))
+
+	var handler p2p.PeersRatingHandler = prh
+	_, ok := handler.(p2p.MeshScoresHandler)
+	assert.True(t, ok)
+
+	providedScores := map[core.PeerID]float64{
+		"pid 1": -1,
+		"pid 2": 2,
+	}
+	prh.UpdateMeshScores(providedScores)
+	assert.Equal(t, providedScores, prh.meshScores)
+
+	// the provided map should be copied
+	providedScores["pid 3"] = 3
+	assert.Equal(t, 2, len(prh.meshScores))
+
+	prh.UpdateMeshScores(map[core.PeerID]float64{"pid 3": 3})
+	assert.Equal(t, map[core.PeerID]float64{"pid 3": 3}, prh.meshScores)
 }
@@ -0,0 +1,25 @@
+package p2p
+
+// Reachability defines whether the node can be dialed by the other peers, as detected by AutoNAT
+type Reachability uint8
+
+const (
+	// ReachabilityUnknown signals that the reachability was not yet determined
+	ReachabilityUnknown Reachability = iota
+	// ReachabilityPublic signals that the node can be dialed directly by the other peers
+	ReachabilityPublic
+	// ReachabilityPrivate signals that the node is behind a NAT or a firewall and can not be dialed directly
+	ReachabilityPrivate
+)
+
+// String returns the human-readable form of the reachability
+func (r Reachability) String() string {
+	switch r {
+	case ReachabilityPublic:
+		return "public"
+	case ReachabilityPrivate:
+		return "private"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,92 @@
+package p2p
+
+import "time"
+
+// CompressionCodec defines the algorithm used to compress the payload of the messages sent on a topic
+type CompressionCodec uint32
+
+const (
+	// NoCompression sends the payload as it is
+	NoCompression CompressionCodec = iota
+	// SnappyCompression compresses the payload using the snappy block format
+	SnappyCompression
+	// ZstdCompression compresses the payload using the zstd algorithm
+	ZstdCompression
+)
+
+// String returns the human-readable form of the compression codec
+func (cc CompressionCodec) String() string {
+	switch cc {
+	case NoCompression:
+		return "none"
+	case SnappyCompression:
+		return "snappy"
+	case ZstdCompression:
+		return "zstd"
+	default:
+		return "unknown"
+	}
+}
+
+// DropPolicy defines which message is discarded when a message is added on a full send channel
+type DropPolicy uint32
+
+const (
+	// DropOldest discards the oldest queued message to make room for the new one
+	DropOldest DropPolicy = iota
+	// DropNewest discards the new message, keeping the queued ones
+	DropNewest
+)
+
+// String returns the human-readable form of the drop policy
+func (dp DropPolicy) String() string {
+	switch dp {
+	case DropOldest:
+		return "drop oldest"
+	case DropNewest:
+		return "drop newest"
+	default:
+		return "unknown"
+	}
+}
+
+// ChannelOptions holds the settings of a send channel from the LoadBalancer
+type ChannelOptions struct {
+	// Weight is the share of the send slots the channel gets when several channels have queued messages.
+	// A channel with weight 4 sends 4 messages for each message sent by a channel with weight 1. 0 means the default value
+	Weight uint32
+	// MaxQueueDepth is the maximum number of messages waiting to be sent on the channel. 0 means the default value
+	MaxQueueDepth int
+	// DropPolicy selects the message discarded when the queue is full. It applies to the non-blocking broadcasts only,
+	// the blocking broadcasts wait until the queue has room for their message
+	DropPolicy DropPolicy
+}
+
+// ChannelStats holds the metrics of a send channel from the LoadBalancer
+type ChannelStats struct {
+	Name          string
+	Weight        uint32
+	QueueDepth    int
+	MaxQueueDepth int
+	NumDropped    uint64
+}
+
+// TopicOptions holds the settings that can be applied when creating a topic
+type TopicOptions struct {
+	// CreateChannel creates a channel in the LoadBalancer for this topic (otherwise, the topic will use a default channel)
+	CreateChannel bool
+	// ChannelOptions are the settings of the channel created for this topic, used only if CreateChannel is set
+	ChannelOptions ChannelOptions
+	// Compression is the codec used when sending messages on this topic. The received messages are decompressed
+	// using the codec carried by each message so the peers do not need to opt in at the same time
+	Compression CompressionCodec
+	// MaxMessageSize is the maximum payload size, in bytes, of the messages sent or received on this topic.
+	// A value larger than the maximum message size is only usable on direct sends with large payloads enabled.
+	// 0 means no topic specific limit
+	MaxMessageSize int
+	// MaxMessageAge is how old a received message can be, it can not exceed the pubsub seen messages cache
+	// duration. 0 means the default value
+	MaxMessageAge time.Duration
+	// MaxClockSkew is how far in the future the timestamp of a received message can be. 0 means the default value
+	MaxClockSkew time.Duration
+}
@@ -0,0 +1,44 @@
+package p2p
+
+import "errors"
+
+// ValidationResult defines the outcome of a received message validation
+type ValidationResult int
+
+const (
+	// ValidationAccept signals that the message is valid and can be propagated to the connected peers
+	ValidationAccept ValidationResult = iota
+	// ValidationIgnore signals that the message should not be propagated but the sender should not be penalized
+	// (e.g. the message was already processed)
+	ValidationIgnore
+	// ValidationReject signals that the message is invalid and the sender should be penalized
+	ValidationReject
+)
+
+// String returns the human-readable form of the validation result
+func (vr ValidationResult) String() string {
+	switch vr {
+	case ValidationAccept:
+		return "accept"
+	case ValidationIgnore:
+		return "ignore"
+	case ValidationReject:
+		return "reject"
+	default:
+		return "unknown"
+	}
+}
+
+// ValidationResultFromError converts the error returned by a MessageProcessor into a ValidationResult.
+// A nil error means accept, an error that wraps ErrMessageIgnored or ErrAlreadySeenMessage means ignore and
+// any other error means reject
+func ValidationResultFromError(err error) ValidationResult {
+	if err == nil {
+		return ValidationAccept
+	}
+	if errors.Is(err, ErrMessageIgnored) || errors.Is(err, ErrAlreadySeenMessage) {
+		return ValidationIgnore
+	}
+
+	return ValidationReject
+}